@@ -4,6 +4,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
@@ -21,6 +22,145 @@ func RandomChoice() *balancerConfig.Config {
 	return &balancerConfig.Config{}
 }
 
+// LeastLatency creates balancer which tracks the moving average of the latency and the count of in-flight requests
+// of every connection and chooses the better of two random connections, so the slow or overloaded nodes get
+// less traffic
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func LeastLatency() *balancerConfig.Config {
+	return &balancerConfig.Config{
+		Algorithm: balancerConfig.LeastLatency,
+	}
+}
+
+type weightedByLoadOption func(c *balancerConfig.Config)
+
+// WithLocationWeight multiplies the weights of the nodes in the location by weight. The nodes in the locations
+// without weight have multiplier 1, the nodes in the location with zero weight get traffic only if there are
+// no other nodes. Negative weight is ignored
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLocationWeight(location string, weight float64) weightedByLoadOption {
+	return func(c *balancerConfig.Config) {
+		if weight < 0 {
+			return
+		}
+		if c.LocationWeights == nil {
+			c.LocationWeights = make(map[string]float64)
+		}
+		c.LocationWeights[location] = weight
+	}
+}
+
+// WeightedByLoad creates balancer which chooses the connection at random with the weights derived from the load
+// factors of the nodes. The load factors are refreshed on every discovery, so the traffic moves away from the hot
+// nodes without waiting for errors
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WeightedByLoad(opts ...weightedByLoadOption) *balancerConfig.Config {
+	c := &balancerConfig.Config{
+		Algorithm: balancerConfig.WeightedByLoad,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+
+	return c
+}
+
+const (
+	defaultOutlierConsecutiveErrors = 5
+	defaultOutlierErrorRate         = 0.5
+	defaultOutlierMinRequests       = 20
+	defaultOutlierInterval          = 10 * time.Second
+	defaultOutlierProbeInterval     = time.Second
+	defaultOutlierRampUp            = 30 * time.Second
+	defaultOutlierMaxEjectedRatio   = 0.5
+)
+
+type outlierDetectionOption func(o *balancerConfig.OutlierDetection)
+
+// WithOutlierConsecutiveErrors ejects the endpoint after count consecutive transport errors, zero count disables
+// the ejection by the consecutive errors
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithOutlierConsecutiveErrors(count int) outlierDetectionOption {
+	return func(o *balancerConfig.OutlierDetection) {
+		o.ConsecutiveErrors = count
+	}
+}
+
+// WithOutlierErrorRate ejects the endpoint if the share of the transport errors of at least minRequests requests
+// in interval reaches rate, zero rate disables the ejection by the error rate
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithOutlierErrorRate(rate float64, minRequests int, interval time.Duration) outlierDetectionOption {
+	return func(o *balancerConfig.OutlierDetection) {
+		o.ErrorRate = rate
+		o.MinRequests = minRequests
+		o.Interval = interval
+	}
+}
+
+// WithOutlierProbeInterval defines the interval of the probes of the ejected endpoints, the non-positive interval
+// is ignored
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithOutlierProbeInterval(interval time.Duration) outlierDetectionOption {
+	return func(o *balancerConfig.OutlierDetection) {
+		if interval > 0 {
+			o.ProbeInterval = interval
+		}
+	}
+}
+
+// WithOutlierRampUp defines the duration of the gradual growth of the traffic to the restored endpoint
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithOutlierRampUp(rampUp time.Duration) outlierDetectionOption {
+	return func(o *balancerConfig.OutlierDetection) {
+		o.RampUp = rampUp
+	}
+}
+
+// WithOutlierMaxEjectedRatio defines the maximal share of the ejected endpoints
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithOutlierMaxEjectedRatio(ratio float64) outlierDetectionOption {
+	return func(o *balancerConfig.OutlierDetection) {
+		o.MaxEjectedRatio = ratio
+	}
+}
+
+// WithOutlierDetection enables the ejection of the failing endpoints from the balancing. The ejected endpoints and
+// the endpoints banned on the transport errors are probed in background and restored with the gradual growth of
+// the traffic after the successful probe
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithOutlierDetection(
+	balancer *balancerConfig.Config, opts ...outlierDetectionOption,
+) *balancerConfig.Config {
+	outlierDetection := &balancerConfig.OutlierDetection{
+		ConsecutiveErrors: defaultOutlierConsecutiveErrors,
+		ErrorRate:         defaultOutlierErrorRate,
+		MinRequests:       defaultOutlierMinRequests,
+		Interval:          defaultOutlierInterval,
+		ProbeInterval:     defaultOutlierProbeInterval,
+		RampUp:            defaultOutlierRampUp,
+		MaxEjectedRatio:   defaultOutlierMaxEjectedRatio,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(outlierDetection)
+		}
+	}
+	balancer.OutlierDetection = outlierDetection
+
+	return balancer
+}
+
 func SingleConn() *balancerConfig.Config {
 	return &balancerConfig.Config{
 		SingleConn: true,
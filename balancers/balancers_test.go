@@ -2,6 +2,7 @@ package balancers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -57,6 +58,27 @@ func TestPreferLocationsWithFallback(t *testing.T) {
 	require.Equal(t, []conn.Conn{conns[0], conns[2]}, applyPreferFilter(balancerConfig.Info{}, rr, conns))
 }
 
+func TestWeightedByLoad(t *testing.T) {
+	b := PreferNearestDC(WeightedByLoad(WithLocationWeight("zero", 2), WithLocationWeight("one", 0.5)))
+	require.Equal(t, balancerConfig.WeightedByLoad, b.Algorithm)
+	require.Equal(t, map[string]float64{"zero": 2, "one": 0.5}, b.LocationWeights)
+	require.Equal(t,
+		"WeightedByLoad{DetectNearestDC=true,AllowFallback=false,LocationWeights={one:0.5,zero:2},Filter=LocalDC}",
+		b.String(),
+	)
+
+	b = WeightedByLoad(WithLocationWeight("zero", -1), WithLocationWeight("one", 0))
+	require.Equal(t, map[string]float64{"one": 0}, b.LocationWeights, "the negative weight is ignored")
+}
+
+func TestWithOutlierDetection(t *testing.T) {
+	b := WithOutlierDetection(RandomChoice(), WithOutlierProbeInterval(5*time.Second))
+	require.Equal(t, 5*time.Second, b.OutlierDetection.ProbeInterval)
+
+	b = WithOutlierDetection(RandomChoice(), WithOutlierProbeInterval(0))
+	require.Equal(t, defaultOutlierProbeInterval, b.OutlierDetection.ProbeInterval, "the zero interval is ignored")
+}
+
 func applyPreferFilter(info balancerConfig.Info, b *balancerConfig.Config, conns []conn.Conn) []conn.Conn {
 	if b.Filter == nil {
 		b.Filter = filterFunc(func(info balancerConfig.Info, e endpoint.Info) bool { return true })
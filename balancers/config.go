@@ -3,6 +3,7 @@ package balancers
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
@@ -11,10 +12,12 @@ import (
 type balancerType string
 
 const (
-	typeRoundRobin   = balancerType("round_robin")
-	typeRandomChoice = balancerType("random_choice")
-	typeSingle       = balancerType("single")
-	typeDisable      = balancerType("disable")
+	typeRoundRobin     = balancerType("round_robin")
+	typeRandomChoice   = balancerType("random_choice")
+	typeLeastLatency   = balancerType("least_latency")
+	typeWeightedByLoad = balancerType("weighted_by_load")
+	typeSingle         = balancerType("single")
+	typeDisable        = balancerType("disable")
 )
 
 type preferType string
@@ -34,6 +37,69 @@ type balancersConfig struct {
 	Prefer    preferType   `json:"prefer,omitempty"`
 	Fallback  bool         `json:"fallback,omitempty"`
 	Locations []string     `json:"locations,omitempty"`
+
+	LocationWeights map[string]float64 `json:"location_weights,omitempty"`
+
+	OutlierDetection *outlierDetectionConfig `json:"outlier_detection,omitempty"`
+}
+
+type outlierDetectionConfig struct {
+	ConsecutiveErrors *int     `json:"consecutive_errors,omitempty"`
+	ErrorRate         *float64 `json:"error_rate,omitempty"`
+	MinRequests       *int     `json:"min_requests,omitempty"`
+	Interval          string   `json:"interval,omitempty"`
+	ProbeInterval     string   `json:"probe_interval,omitempty"`
+	RampUp            string   `json:"ramp_up,omitempty"`
+	MaxEjectedRatio   *float64 `json:"max_ejected_ratio,omitempty"`
+}
+
+func (c *outlierDetectionConfig) options() (opts []outlierDetectionOption, _ error) {
+	if c.ConsecutiveErrors != nil {
+		opts = append(opts, WithOutlierConsecutiveErrors(*c.ConsecutiveErrors))
+	}
+	if c.ErrorRate != nil || c.MinRequests != nil || c.Interval != "" {
+		var (
+			rate        = defaultOutlierErrorRate
+			minRequests = defaultOutlierMinRequests
+			interval    = defaultOutlierInterval
+		)
+		if c.ErrorRate != nil {
+			rate = *c.ErrorRate
+		}
+		if c.MinRequests != nil {
+			minRequests = *c.MinRequests
+		}
+		if c.Interval != "" {
+			d, err := time.ParseDuration(c.Interval)
+			if err != nil {
+				return nil, xerrors.WithStackTrace(err)
+			}
+			interval = d
+		}
+		opts = append(opts, WithOutlierErrorRate(rate, minRequests, interval))
+	}
+	if c.ProbeInterval != "" {
+		d, err := time.ParseDuration(c.ProbeInterval)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		if d <= 0 {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("non-positive probe interval '%s'", c.ProbeInterval))
+		}
+		opts = append(opts, WithOutlierProbeInterval(d))
+	}
+	if c.RampUp != "" {
+		d, err := time.ParseDuration(c.RampUp)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		opts = append(opts, WithOutlierRampUp(d))
+	}
+	if c.MaxEjectedRatio != nil {
+		opts = append(opts, WithOutlierMaxEjectedRatio(*c.MaxEjectedRatio))
+	}
+
+	return opts, nil
 }
 
 type fromConfigOptionsHolder struct {
@@ -65,6 +131,10 @@ func createByType(t balancerType) (*balancerConfig.Config, error) {
 		return RandomChoice(), nil
 	case typeRoundRobin:
 		return RoundRobin(), nil
+	case typeLeastLatency:
+		return LeastLatency(), nil
+	case typeWeightedByLoad:
+		return WeightedByLoad(), nil
 	default:
 		return nil, xerrors.WithStackTrace(fmt.Errorf("unknown type of balancer: %s", t))
 	}
@@ -92,6 +162,32 @@ func CreateFromConfig(s string) (*balancerConfig.Config, error) {
 		return nil, xerrors.WithStackTrace(err)
 	}
 
+	if len(c.LocationWeights) > 0 {
+		if c.Type != typeWeightedByLoad {
+			return nil, xerrors.WithStackTrace(
+				fmt.Errorf("location weights are not supported by balancer '%s'", c.Type),
+			)
+		}
+		for location, weight := range c.LocationWeights {
+			if weight < 0 {
+				return nil, xerrors.WithStackTrace(
+					fmt.Errorf("negative weight of location '%s' in balancer '%s' config", location, c.Type),
+				)
+			}
+		}
+		b.LocationWeights = c.LocationWeights
+	}
+
+	if c.OutlierDetection != nil {
+		opts, err := c.OutlierDetection.options()
+		if err != nil {
+			return nil, xerrors.WithStackTrace(
+				fmt.Errorf("wrong outlier detection in balancer '%s' config: %w", c.Type, err),
+			)
+		}
+		b = WithOutlierDetection(b, opts...)
+	}
+
 	switch c.Prefer {
 	case preferTypeLocalDC:
 		if c.Fallback {
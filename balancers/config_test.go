@@ -2,6 +2,7 @@ package balancers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -63,6 +64,124 @@ func TestFromConfig(t *testing.T) {
 			}`,
 			res: balancerConfig.Config{},
 		},
+		{
+			name:   "least_latency",
+			config: `least_latency`,
+			res:    balancerConfig.Config{Algorithm: balancerConfig.LeastLatency},
+		},
+		{
+			name: "least_latency/prefer_nearest_dc",
+			config: `{
+				"type": "least_latency",
+				"prefer": "nearest_dc"
+			}`,
+			res: balancerConfig.Config{
+				Algorithm:       balancerConfig.LeastLatency,
+				DetectNearestDC: true,
+				Filter: filterFunc(func(info balancerConfig.Info, e endpoint.Info) bool {
+					// some non nil func
+					return false
+				}),
+			},
+		},
+		{
+			name:   "weighted_by_load",
+			config: `weighted_by_load`,
+			res:    balancerConfig.Config{Algorithm: balancerConfig.WeightedByLoad},
+		},
+		{
+			name: "weighted_by_load/location_weights",
+			config: `{
+				"type": "weighted_by_load",
+				"location_weights": {"AAA": 2, "BBB": 0.5}
+			}`,
+			res: balancerConfig.Config{
+				Algorithm: balancerConfig.WeightedByLoad,
+				LocationWeights: map[string]float64{
+					"AAA": 2,
+					"BBB": 0.5,
+				},
+			},
+		},
+		{
+			name: "weighted_by_load/negative_location_weight",
+			config: `{
+				"type": "weighted_by_load",
+				"location_weights": {"AAA": -1}
+			}`,
+			fail: true,
+		},
+		{
+			name: "random_choice/location_weights",
+			config: `{
+				"type": "random_choice",
+				"location_weights": {"AAA": 2}
+			}`,
+			fail: true,
+		},
+		{
+			name: "outlier_detection/defaults",
+			config: `{
+				"type": "random_choice",
+				"outlier_detection": {}
+			}`,
+			res: balancerConfig.Config{
+				OutlierDetection: &balancerConfig.OutlierDetection{
+					ConsecutiveErrors: 5,
+					ErrorRate:         0.5,
+					MinRequests:       20,
+					Interval:          10 * time.Second,
+					ProbeInterval:     time.Second,
+					RampUp:            30 * time.Second,
+					MaxEjectedRatio:   0.5,
+				},
+			},
+		},
+		{
+			name: "outlier_detection",
+			config: `{
+				"type": "weighted_by_load",
+				"outlier_detection": {
+					"consecutive_errors": 3,
+					"error_rate": 0,
+					"probe_interval": "5s",
+					"ramp_up": "1m",
+					"max_ejected_ratio": 0.3
+				}
+			}`,
+			res: balancerConfig.Config{
+				Algorithm: balancerConfig.WeightedByLoad,
+				OutlierDetection: &balancerConfig.OutlierDetection{
+					ConsecutiveErrors: 3,
+					ErrorRate:         0,
+					MinRequests:       20,
+					Interval:          10 * time.Second,
+					ProbeInterval:     5 * time.Second,
+					RampUp:            time.Minute,
+					MaxEjectedRatio:   0.3,
+				},
+			},
+		},
+		{
+			name: "outlier_detection/wrong_duration",
+			config: `{
+				"type": "random_choice",
+				"outlier_detection": {
+					"probe_interval": "five seconds"
+				}
+			}`,
+			fail: true,
+		},
+		{
+			name: "outlier_detection/zero_probe_interval",
+			config: `{
+				"type": "random_choice",
+				"outlier_detection": {
+					"probe_interval": "0s"
+				}
+			}`,
+			fail: true,
+		},
 		{
 			name: "prefer_local_dc",
 			config: `{
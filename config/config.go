@@ -12,6 +12,7 @@ import (
 	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/meta"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/retry/budget"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
@@ -165,6 +166,13 @@ func WithRetryBudget(b budget.Budget) Option {
 	}
 }
 
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithRetryErrorClassifier(classifier retry.ErrorClassifier) Option {
+	return func(c *Config) {
+		config.SetRetryErrorClassifier(&c.Common, classifier)
+	}
+}
+
 func WithTraceRetry(t *trace.Retry, opts ...trace.RetryComposeOption) Option {
 	return func(c *Config) {
 		config.SetTraceRetry(&c.Common, t, opts...)
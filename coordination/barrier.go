@@ -0,0 +1,355 @@
+package coordination
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+)
+
+// Barrier is a distributed cyclic barrier backed by a semaphore of a coordination node. The Wait method of a barrier
+// blocks until the number of parties call it, then all of them are released and the barrier becomes ready for the
+// next phase.
+//
+// Participants are owners of the semaphore. If the session of a participant expires while it is waiting, its
+// ownership vanishes and the participant is not counted anymore, so the barrier waits for another party instead.
+// The semaphore state survives reconnects of the session.
+//
+// The semaphore is created with the first Wait call and is not deleted automatically. Delete it with the
+// Session.DeleteSemaphore method when the barrier is not needed anymore.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type Barrier struct {
+	session Session
+	name    string
+	parties int
+}
+
+// CountDownLatch is a distributed countdown latch backed by a semaphore of a coordination node. The latch is opened
+// when the CountDown method has been called the count times. Once the latch is opened, it stays open.
+//
+// Every count down is an acquired semaphore token of the participant session. If the session of a participant expires
+// before the latch is opened, its count downs are withdrawn, so the latch never opens because of the participants which
+// have not survived. The semaphore state survives reconnects of the session.
+//
+// The semaphore is created with the first call and is not deleted automatically. Delete it with the
+// Session.DeleteSemaphore method when the latch is not needed anymore.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type CountDownLatch struct {
+	session Session
+	name    string
+	count   uint64
+
+	mutex   sync.Mutex // guards the fields below
+	counted uint64
+	lease   Lease
+}
+
+var latchOpened = []byte("opened")
+
+// NewBarrier creates a new barrier for the parties which uses the semaphore with the given name.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewBarrier(session Session, name string, parties int) *Barrier {
+	return &Barrier{
+		session: session,
+		name:    name,
+		parties: parties,
+	}
+}
+
+// Wait blocks until all the parties have called Wait on this barrier, an error is returned from the server or ctx is
+// canceled. If ctx is canceled, the participant withdraws from the current phase.
+func (b *Barrier) Wait(ctx context.Context) (finalErr error) {
+	if err := ensureSemaphore(ctx, b.session, b.name); err != nil {
+		return err
+	}
+
+	desc, err := b.session.DescribeSemaphore(ctx, b.name)
+	if err != nil {
+		return err
+	}
+	generation, _ := decodeBarrierState(desc.Data)
+
+	lease, err := b.arrive(ctx, generation)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if lease.Context().Err() == nil {
+			if err := lease.Release(); err != nil && finalErr == nil {
+				finalErr = err
+			}
+		}
+	}()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	descriptions, err := b.session.WatchSemaphore(watchCtx, b.name)
+	if err != nil {
+		return err
+	}
+
+	for {
+		var ok bool
+		select {
+		case desc, ok = <-descriptions:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				return ErrSessionClosed
+			}
+		case <-lease.Context().Done():
+			return ErrParticipationLost
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		current, released := decodeBarrierState(desc.Data)
+		if current != generation {
+			if released[b.session.SessionID()] {
+				return nil
+			}
+
+			// The phase has been completed before the participant arrived, wait for the next one.
+			generation = current
+			if _, err = b.arrive(ctx, generation); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		completed, err := b.tryComplete(ctx, desc, generation, released)
+		if err != nil {
+			return err
+		}
+		if completed {
+			// The participant has released itself along with the others.
+			return nil
+		}
+	}
+}
+
+func (b *Barrier) arrive(ctx context.Context, generation uint64) (Lease, error) {
+	return b.session.AcquireSemaphore(ctx, b.name, 1,
+		options.WithAcquireData(binary.BigEndian.AppendUint64(nil, generation)),
+	)
+}
+
+// tryComplete completes the phase if enough parties have arrived and the participant is the completer of the phase:
+// the arrived party with the lowest session id. The completer describes the semaphore once more before the update,
+// so the state is not written over the state of the next phases if the description has been stale.
+func (b *Barrier) tryComplete(
+	ctx context.Context,
+	desc *SemaphoreDescription,
+	generation uint64,
+	released map[uint64]bool,
+) (completed bool, _ error) {
+	if !b.isCompleter(desc, generation) {
+		return false, nil
+	}
+
+	desc, err := b.session.DescribeSemaphore(ctx, b.name, options.WithDescribeOwners(true))
+	if err != nil {
+		return false, err
+	}
+	if current, _ := decodeBarrierState(desc.Data); current != generation {
+		return false, nil
+	}
+	if !b.isCompleter(desc, generation) {
+		return false, nil
+	}
+
+	err = b.session.UpdateSemaphore(ctx, b.name,
+		options.WithUpdateData(encodeBarrierState(generation+1, b.releasedParties(desc, generation, released))),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isCompleter reports whether enough parties have arrived in the generation and the session of the participant has
+// the lowest id of them.
+func (b *Barrier) isCompleter(desc *SemaphoreDescription, generation uint64) bool {
+	arrived := binary.BigEndian.AppendUint64(nil, generation)
+	sessionID := b.session.SessionID()
+
+	var (
+		count int
+		self  bool
+	)
+	for _, owner := range desc.Owners {
+		if !bytes.Equal(owner.Data, arrived) {
+			continue
+		}
+		count++
+		switch {
+		case owner.SessionID == sessionID:
+			self = true
+		case owner.SessionID < sessionID:
+			return false
+		}
+	}
+
+	return self && count >= b.parties
+}
+
+// releasedParties returns the sessions of the parties arrived in the generation and of the participants released in
+// the previous phase, which have not noticed it yet.
+func (b *Barrier) releasedParties(
+	desc *SemaphoreDescription,
+	generation uint64,
+	released map[uint64]bool,
+) (ids []uint64) {
+	arrived := binary.BigEndian.AppendUint64(nil, generation)
+	for _, owner := range desc.Owners {
+		if bytes.Equal(owner.Data, arrived) || released[owner.SessionID] {
+			ids = append(ids, owner.SessionID)
+		}
+	}
+
+	return ids
+}
+
+// encodeBarrierState encodes the current generation of the barrier and the participants released from the previous
+// one.
+func encodeBarrierState(generation uint64, released []uint64) []byte {
+	data := binary.BigEndian.AppendUint64(make([]byte, 0, 8*(len(released)+1)), generation) //nolint:gomnd
+	for _, id := range released {
+		data = binary.BigEndian.AppendUint64(data, id)
+	}
+
+	return data
+}
+
+func decodeBarrierState(data []byte) (generation uint64, released map[uint64]bool) {
+	if len(data) < 8 { //nolint:gomnd
+		return 0, nil
+	}
+
+	generation = binary.BigEndian.Uint64(data)
+	released = make(map[uint64]bool)
+	for data = data[8:]; len(data) >= 8; data = data[8:] {
+		released[binary.BigEndian.Uint64(data)] = true
+	}
+
+	return generation, released
+}
+
+// NewCountDownLatch creates a new latch which is opened after count calls of the CountDown method and uses the
+// semaphore with the given name.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewCountDownLatch(session Session, name string, count uint64) *CountDownLatch {
+	return &CountDownLatch{
+		session: session,
+		name:    name,
+		count:   count,
+	}
+}
+
+// CountDown decrements the count of the latch. The count down is kept by the session until the latch is opened.
+func (l *CountDownLatch) CountDown(ctx context.Context) error {
+	if err := ensureSemaphore(ctx, l.session, l.name); err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.lease != nil && l.lease.Context().Err() != nil {
+		// The session has lost the previous count downs.
+		l.counted, l.lease = 0, nil
+	}
+
+	lease, err := l.session.AcquireSemaphore(ctx, l.name, l.counted+1)
+	if err != nil {
+		return err
+	}
+	l.counted++
+
+	if l.lease == nil {
+		l.lease = lease
+		go l.releaseOnOpen(lease)
+	}
+
+	return nil
+}
+
+// Wait blocks until the latch is opened, an error is returned from the server or ctx is canceled.
+func (l *CountDownLatch) Wait(ctx context.Context) error {
+	if err := ensureSemaphore(ctx, l.session, l.name); err != nil {
+		return err
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	descriptions, err := l.session.WatchSemaphore(watchCtx, l.name)
+	if err != nil {
+		return err
+	}
+
+	for desc := range descriptions {
+		if bytes.Equal(desc.Data, latchOpened) {
+			return nil
+		}
+		if desc.Count >= l.count {
+			// Make the latch stay open even if the participants release their count downs.
+			return l.session.UpdateSemaphore(ctx, l.name, options.WithUpdateData(latchOpened))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return ErrSessionClosed
+}
+
+func (l *CountDownLatch) releaseOnOpen(lease Lease) {
+	if l.Wait(lease.Context()) != nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.lease == lease {
+		l.counted, l.lease = 0, nil
+	}
+	_ = lease.Release()
+}
+
+// ensureSemaphore creates the semaphore with the maximum limit unless it exists.
+func ensureSemaphore(ctx context.Context, session Session, name string) error {
+	desc, err := session.DescribeSemaphore(ctx, name)
+	if err != nil {
+		return err
+	}
+	if desc.Name == name {
+		return nil
+	}
+
+	err = session.CreateSemaphore(ctx, name, MaxSemaphoreLimit)
+	if err == nil {
+		return nil
+	}
+
+	// Someone else may have created the semaphore meanwhile.
+	desc, describeErr := session.DescribeSemaphore(ctx, name)
+	if describeErr == nil && desc.Name == name {
+		return nil
+	}
+
+	return err
+}
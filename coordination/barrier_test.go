@@ -0,0 +1,183 @@
+package coordination
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+func TestBarrier(t *testing.T) {
+	t.Run("Phases", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+
+		const parties = 3
+		barriers := make([]*Barrier, parties)
+		for i := range barriers {
+			barriers[i] = NewBarrier(node.newSession(), "barrier", parties)
+		}
+
+		for phase := 0; phase < 3; phase++ {
+			var wg sync.WaitGroup
+			errs := make(chan error, parties)
+			for _, b := range barriers {
+				wg.Add(1)
+				go func(b *Barrier) {
+					defer wg.Done()
+					errs <- b.Wait(ctx)
+				}(b)
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				require.NoError(t, err)
+			}
+		}
+	})
+	t.Run("WaitForAllParties", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		b1, b2 := NewBarrier(node.newSession(), "barrier", 2), NewBarrier(node.newSession(), "barrier", 2)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, b1.Wait(waitCtx), context.DeadlineExceeded)
+
+		desc, err := node.newSession().DescribeSemaphore(ctx, "barrier")
+		require.NoError(t, err)
+		require.Empty(t, desc.Owners, "the canceled participant must withdraw")
+
+		done := make(chan error, 1)
+		go func() {
+			done <- b1.Wait(ctx)
+		}()
+		require.NoError(t, b2.Wait(ctx))
+		require.NoError(t, <-done)
+	})
+	t.Run("ExpiredParticipant", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		expired := node.newSession()
+		b1, b2 := NewBarrier(expired, "barrier", 2), NewBarrier(node.newSession(), "barrier", 2)
+
+		expiredDone := make(chan error, 1)
+		go func() {
+			expiredDone <- b1.Wait(ctx)
+		}()
+		xtest.SpinWaitCondition(t, &node.mutex, func() bool {
+			sem, ok := node.semaphores["barrier"]
+
+			return ok && len(sem.owners) == 1
+		})
+		expired.lose()
+		require.Error(t, <-expiredDone)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, b2.Wait(waitCtx), context.DeadlineExceeded)
+
+		b3 := NewBarrier(node.newSession(), "barrier", 2)
+		done := make(chan error, 1)
+		go func() {
+			done <- b3.Wait(ctx)
+		}()
+		require.NoError(t, b2.Wait(ctx))
+		require.NoError(t, <-done)
+	})
+	t.Run("StaleDescription", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		s1, s2 := node.newSession(), node.newSession()
+		b1, b2 := NewBarrier(s1, "barrier", 2), NewBarrier(s2, "barrier", 2)
+
+		require.NoError(t, ensureSemaphore(ctx, s1, "barrier"))
+		_, err := b1.arrive(ctx, 0)
+		require.NoError(t, err)
+		_, err = b2.arrive(ctx, 0)
+		require.NoError(t, err)
+
+		stale, err := s1.DescribeSemaphore(ctx, "barrier", options.WithDescribeOwners(true))
+		require.NoError(t, err)
+		require.True(t, b1.isCompleter(stale, 0))
+		require.False(t, b2.isCompleter(stale, 0), "only the party with the lowest session id completes the phase")
+
+		// The phase has been completed and the next phases have started meanwhile.
+		require.NoError(t, s1.UpdateSemaphore(ctx, "barrier", options.WithUpdateData(encodeBarrierState(2, nil))))
+
+		completed, err := b1.tryComplete(ctx, stale, 0, nil)
+		require.NoError(t, err)
+		require.False(t, completed)
+
+		desc, err := s1.DescribeSemaphore(ctx, "barrier")
+		require.NoError(t, err)
+		generation, _ := decodeBarrierState(desc.Data)
+		require.Equal(t, uint64(2), generation, "the stale description does not roll the generation back")
+	})
+}
+
+func TestBarrierState(t *testing.T) {
+	generation, released := decodeBarrierState(nil)
+	require.Zero(t, generation)
+	require.Empty(t, released)
+
+	generation, released = decodeBarrierState(encodeBarrierState(5, []uint64{1, 3}))
+	require.Equal(t, uint64(5), generation)
+	require.Equal(t, map[uint64]bool{1: true, 3: true}, released)
+}
+
+func TestCountDownLatch(t *testing.T) {
+	t.Run("Open", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		s1, s2 := node.newSession(), node.newSession()
+		l1, l2 := NewCountDownLatch(s1, "latch", 3), NewCountDownLatch(s2, "latch", 3)
+
+		opened := make(chan error, 1)
+		go func() {
+			opened <- NewCountDownLatch(node.newSession(), "latch", 3).Wait(ctx)
+		}()
+
+		require.NoError(t, l1.CountDown(ctx))
+		require.NoError(t, l1.CountDown(ctx))
+
+		select {
+		case err := <-opened:
+			t.Fatalf("latch must not be opened yet: %v", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		require.NoError(t, l2.CountDown(ctx))
+		require.NoError(t, <-opened)
+
+		// The participants release their count downs once the latch is opened, but it stays open.
+		xtest.SpinWaitCondition(t, &node.mutex, func() bool {
+			return len(node.semaphores["latch"].owners) == 0
+		})
+		require.NoError(t, NewCountDownLatch(node.newSession(), "latch", 3).Wait(ctx))
+	})
+	t.Run("ExpiredParticipant", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		expired := node.newSession()
+
+		require.NoError(t, NewCountDownLatch(expired, "latch", 2).CountDown(ctx))
+		expired.lose()
+
+		l := NewCountDownLatch(node.newSession(), "latch", 2)
+		require.NoError(t, l.CountDown(ctx))
+
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, l.Wait(waitCtx), context.DeadlineExceeded)
+
+		require.NoError(t, l.CountDown(ctx))
+		require.NoError(t, l.Wait(ctx))
+	})
+}
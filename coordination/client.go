@@ -119,6 +119,22 @@ type Session interface {
 		opts ...options.AcquireSemaphoreOption,
 	) (Lease, error)
 
+	// WatchSemaphore returns a channel which receives the current description of the semaphore and then a new one every
+	// time the semaphore data or owners change (see options.WithWatchData and options.WithWatchOwners). Descriptions
+	// always include the lists of owners and waiters. If the semaphore does not exist, the description has an empty
+	// name, and the client keeps checking the semaphore until it is created.
+	//
+	// The client re-arms the server watch after every change notification and after the underlying gRPC stream is
+	// reconnected. A slow receiver does not block the watch: if the previous description has not been received yet, it
+	// is replaced by the newer one. The channel is closed when ctx is canceled or the session is closed.
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	WatchSemaphore(
+		ctx context.Context,
+		name string,
+		opts ...options.WatchSemaphoreOption,
+	) (<-chan *SemaphoreDescription, error)
+
 	// SessionID returns a server-generated identifier of the session. This value is permanent and unique within the
 	// coordination service node.
 	SessionID() uint64
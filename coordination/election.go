@@ -0,0 +1,229 @@
+package coordination
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+)
+
+const defaultElectionObserveInterval = time.Second
+
+// Election implements leader election on top of an ephemeral semaphore of a coordination node. The candidate which
+// acquires the semaphore exclusively becomes the leader and stays so until it resigns or its session is lost.
+//
+// Election is safe for concurrent use.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type Election struct {
+	session         Session
+	name            string
+	observeInterval time.Duration
+
+	mutex sync.Mutex // guards the field below
+	lease Lease
+}
+
+// ElectionLeader describes the current leader of an election.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type ElectionLeader struct {
+	// SessionID is the id of the session which holds the leadership.
+	SessionID uint64
+
+	// OrderID is a monotonically increasing id of the acquire operation which made the session a leader.
+	OrderID uint64
+
+	// Value is the value the leader campaigned with.
+	Value []byte
+}
+
+// NewElection creates a new election which uses the ephemeral semaphore with the given name in the coordination node
+// of the session. All the candidates and observers of the same election must use the same coordination node and the
+// same name.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewElection(session Session, name string, opts ...options.ElectionOption) *Election {
+	cfg := options.ElectionOptions{
+		ObserveInterval: defaultElectionObserveInterval,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+
+	return &Election{
+		session:         session,
+		name:            name,
+		observeInterval: cfg.ObserveInterval,
+	}
+}
+
+// Campaign blocks until the session becomes the leader of the election, an error is returned from the server or ctx
+// is canceled. The value is attached to the leadership and is visible to other participants through the Leader and
+// Observe methods. If the session is already the leader, Campaign replaces the value and returns immediately.
+//
+// The returned context is canceled as soon as the leadership is lost: when the Resign method is called, the session
+// is closed or the client could not keep the session alive. The leader should stop doing its work once the context is
+// done.
+func (e *Election) Campaign(ctx context.Context, value []byte) (context.Context, error) {
+	lease, err := e.session.AcquireSemaphore(ctx, e.name, Exclusive,
+		options.WithEphemeral(true),
+		options.WithAcquireData(value),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.lease != nil && e.lease.Context().Err() == nil {
+		// The server replaced the value of the existing acquire operation, keep the original lease to make the
+		// leadership context stay the same.
+		return e.lease.Context(), nil
+	}
+
+	e.lease = lease
+
+	return lease.Context(), nil
+}
+
+// Resign gives up the leadership if the session is the leader of the election. It is noop otherwise. Once Resign
+// returns with no error, other candidates are able to become the leader.
+func (e *Election) Resign(ctx context.Context) error {
+	e.mutex.Lock()
+	lease := e.lease
+	e.lease = nil
+	e.mutex.Unlock()
+
+	if lease == nil || lease.Context().Err() != nil {
+		return nil
+	}
+
+	released := make(chan error, 1)
+	go func() {
+		released <- lease.Release()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-released:
+		return err
+	}
+}
+
+// Leader returns the current leader of the election. It returns the ErrNoLeader error if the election has no leader
+// at the moment.
+func (e *Election) Leader(ctx context.Context) (*ElectionLeader, error) {
+	desc, err := e.session.DescribeSemaphore(ctx, e.name, options.WithDescribeOwners(true))
+	if err != nil {
+		return nil, err
+	}
+
+	leader := electionLeader(desc)
+	if leader == nil {
+		return nil, ErrNoLeader
+	}
+
+	return leader, nil
+}
+
+// Observe returns a channel which receives the current leader of the election and then every change of it. A nil
+// value means that the election has no leader at the moment. The channel is closed when ctx is canceled or the
+// session is closed.
+//
+// Observe is backed by a watch of the election semaphore (see Session.WatchSemaphore), so the leader changes are
+// delivered as soon as the server notifies about them. If the watch could not be started, Observe checks the
+// semaphore periodically (see options.WithElectionObserveInterval).
+func (e *Election) Observe(ctx context.Context) <-chan *ElectionLeader {
+	ch := make(chan *ElectionLeader, 1)
+
+	go func() {
+		defer close(ch)
+
+		var (
+			last *ElectionLeader
+			sent bool
+		)
+		notify := func(desc *SemaphoreDescription) bool {
+			leader := electionLeader(desc)
+			if sent && leader.equal(last) {
+				return true
+			}
+
+			select {
+			case ch <- leader:
+				last, sent = leader, true
+
+				return true
+			case <-ctx.Done():
+				return false
+			case <-e.session.Context().Done():
+				return false
+			}
+		}
+
+		descriptions, err := e.session.WatchSemaphore(ctx, e.name, options.WithWatchData(false))
+		if err != nil {
+			e.pollLeader(ctx, notify)
+
+			return
+		}
+
+		for desc := range descriptions {
+			// The descriptions are drained until the watch is stopped, even if the receiver is gone.
+			_ = notify(desc)
+		}
+	}()
+
+	return ch
+}
+
+// pollLeader describes the election semaphore every observe interval until notify returns false, ctx is canceled or
+// the session is closed.
+func (e *Election) pollLeader(ctx context.Context, notify func(desc *SemaphoreDescription) bool) {
+	ticker := time.NewTicker(e.observeInterval)
+	defer ticker.Stop()
+
+	for {
+		desc, err := e.session.DescribeSemaphore(ctx, e.name, options.WithDescribeOwners(true))
+		if err == nil && !notify(desc) {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		case <-e.session.Context().Done():
+			return
+		}
+	}
+}
+
+func electionLeader(desc *SemaphoreDescription) *ElectionLeader {
+	if desc == nil || len(desc.Owners) == 0 {
+		return nil
+	}
+
+	owner := desc.Owners[0]
+
+	return &ElectionLeader{
+		SessionID: owner.SessionID,
+		OrderID:   owner.OrderID,
+		Value:     owner.Data,
+	}
+}
+
+func (l *ElectionLeader) equal(other *ElectionLeader) bool {
+	if l == nil || other == nil {
+		return l == other
+	}
+
+	return l.SessionID == other.SessionID && l.OrderID == other.OrderID && bytes.Equal(l.Value, other.Value)
+}
@@ -0,0 +1,157 @@
+package coordination
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+func TestElection(t *testing.T) {
+	t.Run("CampaignAndResign", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		s1, s2 := node.newSession(), node.newSession()
+		e1, e2 := NewElection(s1, "leader"), NewElection(s2, "leader")
+
+		leadership, err := e1.Campaign(ctx, []byte("first"))
+		require.NoError(t, err)
+		require.NoError(t, leadership.Err())
+
+		leader, err := e2.Leader(ctx)
+		require.NoError(t, err)
+		require.Equal(t, s1.SessionID(), leader.SessionID)
+		require.Equal(t, []byte("first"), leader.Value)
+
+		campaigned := make(chan context.Context)
+		go func() {
+			secondLeadership, campaignErr := e2.Campaign(ctx, []byte("second"))
+			require.NoError(t, campaignErr)
+			campaigned <- secondLeadership
+		}()
+
+		xtest.SpinWaitCondition(t, nil, func() bool {
+			desc, _ := s1.DescribeSemaphore(ctx, "leader")
+
+			return len(desc.Waiters) == 1
+		})
+		select {
+		case <-campaigned:
+			t.Fatal("second candidate must not become the leader while the first one is alive")
+		default:
+		}
+
+		require.NoError(t, e1.Resign(ctx))
+		require.Error(t, leadership.Err())
+
+		secondLeadership := <-campaigned
+		require.NoError(t, secondLeadership.Err())
+
+		leader, err = e1.Leader(ctx)
+		require.NoError(t, err)
+		require.Equal(t, s2.SessionID(), leader.SessionID)
+		require.Equal(t, []byte("second"), leader.Value)
+	})
+	t.Run("CampaignUpdatesValue", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		e := NewElection(node.newSession(), "leader")
+
+		leadership, err := e.Campaign(ctx, []byte("v1"))
+		require.NoError(t, err)
+
+		sameLeadership, err := e.Campaign(ctx, []byte("v2"))
+		require.NoError(t, err)
+		require.Equal(t, leadership, sameLeadership)
+
+		leader, err := e.Leader(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []byte("v2"), leader.Value)
+	})
+	t.Run("CampaignCanceled", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		e1, e2 := NewElection(node.newSession(), "leader"), NewElection(node.newSession(), "leader")
+
+		_, err := e1.Campaign(ctx, nil)
+		require.NoError(t, err)
+
+		campaignCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err = e2.Campaign(campaignCtx, nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("LeadershipLostWithSession", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		s := node.newSession()
+		e := NewElection(s, "leader")
+
+		leadership, err := e.Campaign(ctx, nil)
+		require.NoError(t, err)
+
+		s.lose()
+
+		<-leadership.Done()
+
+		_, err = NewElection(node.newSession(), "leader").Leader(ctx)
+		require.ErrorIs(t, err, ErrNoLeader)
+	})
+	t.Run("ResignWithoutLeadership", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		e := NewElection(newTestNode().newSession(), "leader")
+
+		require.NoError(t, e.Resign(ctx))
+	})
+	for _, tt := range []struct {
+		name     string
+		watchErr error
+	}{
+		{name: "Observe"},
+		{name: "ObservePolling", watchErr: errors.New("watch is not supported")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := xtest.Context(t)
+			node := newTestNode()
+			s1, s2 := node.newSession(), node.newSession()
+			s2.watchErr = tt.watchErr
+			e1 := NewElection(s1, "leader")
+			observer := NewElection(s2, "leader", options.WithElectionObserveInterval(time.Millisecond))
+
+			observeCtx, cancel := context.WithCancel(ctx)
+			leaders := observer.Observe(observeCtx)
+
+			require.Nil(t, <-leaders)
+
+			_, err := e1.Campaign(ctx, []byte("v1"))
+			require.NoError(t, err)
+
+			leader := <-leaders
+			require.Equal(t, s1.SessionID(), leader.SessionID)
+			require.Equal(t, []byte("v1"), leader.Value)
+
+			_, err = e1.Campaign(ctx, []byte("v2"))
+			require.NoError(t, err)
+
+			leader = <-leaders
+			require.Equal(t, []byte("v2"), leader.Value)
+
+			require.NoError(t, e1.Resign(ctx))
+			require.Nil(t, <-leaders)
+
+			cancel()
+			for range leaders {
+			}
+		})
+	}
+	t.Run("ZeroObserveInterval", func(t *testing.T) {
+		e := NewElection(newTestNode().newSession(), "leader", options.WithElectionObserveInterval(0))
+		require.Equal(t, defaultElectionObserveInterval, e.observeInterval)
+	})
+}
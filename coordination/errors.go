@@ -15,4 +15,18 @@ var (
 	// ErrAcquireTimeout indicates that the Session.AcquireSemaphore method could not acquire the semaphore before the
 	// operation timeout (see options.WithAcquireTimeout).
 	ErrAcquireTimeout = errors.New("acquire semaphore timeout")
+
+	// ErrNoLeader indicates that the election has no leader at the moment.
+	ErrNoLeader = errors.New("election has no leader")
+
+	// ErrNotLocked indicates that the unlock method is called for the mutex which is not locked.
+	ErrNotLocked = errors.New("mutex is not locked")
+
+	// ErrLockLost indicates that the semaphore of the mutex was released before the lock has been completed, for
+	// example, because the session was lost.
+	ErrLockLost = errors.New("lock is lost")
+
+	// ErrParticipationLost indicates that the semaphore of the barrier was released while the participant was waiting
+	// on it, for example, because the session was lost.
+	ErrParticipationLost = errors.New("participation is lost")
 )
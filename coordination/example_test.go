@@ -145,3 +145,43 @@ func Example_semaphore() {
 	}
 	fmt.Printf("deleted semaphore my-semaphore\n")
 }
+
+func Example_registry() {
+	ctx := context.TODO()
+	db, err := ydb.Open(ctx, "grpc://localhost:2136/local")
+	if err != nil {
+		fmt.Printf("failed to connect: %v", err)
+
+		return
+	}
+	defer db.Close(ctx) // cleanup resources
+
+	s, err := db.Coordination().Session(ctx, "/local/test")
+	if err != nil {
+		fmt.Printf("failed to create session: %v\n", err)
+
+		return
+	}
+	defer s.Close(ctx)
+
+	registry := coordination.NewRegistry(s, "workers")
+	membership, err := registry.Register(ctx, []byte("worker-1:8080"))
+	if err != nil {
+		fmt.Printf("failed to register: %v\n", err)
+
+		return
+	}
+
+	changes, err := registry.Watch(membership)
+	if err != nil {
+		fmt.Printf("failed to watch the registry: %v\n", err)
+
+		return
+	}
+
+	partitions := []string{"partition-0", "partition-1", "partition-2", "partition-3"}
+	for change := range changes {
+		ring := coordination.NewHashRing(change.Members)
+		fmt.Printf("my partitions: %v\n", ring.Assign(partitions)[s.SessionID()])
+	}
+}
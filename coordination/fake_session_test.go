@@ -0,0 +1,399 @@
+package coordination
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Coordination"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+)
+
+var errTestSemaphoreNotFound = errors.New("test semaphore not found")
+
+// testNode is an in-memory coordination node which serves several testSession objects.
+type testNode struct {
+	mutex         sync.Mutex
+	changed       chan struct{}
+	semaphores    map[string]*testSemaphore
+	lastSessionID uint64
+	lastOrderID   uint64
+}
+
+type testSemaphore struct {
+	limit     uint64
+	ephemeral bool
+	data      []byte
+	owners    []*testSemaphoreSession
+	waiters   []*testSemaphoreSession
+}
+
+type testSemaphoreSession struct {
+	session *testSession
+	count   uint64
+	orderID uint64
+	data    []byte
+	lease   *testLease
+}
+
+type testSession struct {
+	node     *testNode
+	id       uint64
+	ctx      context.Context //nolint:containedctx
+	cancel   context.CancelFunc
+	watchErr error
+}
+
+type testLease struct {
+	session *testSession
+	name    string
+	ctx     context.Context //nolint:containedctx
+	cancel  context.CancelFunc
+}
+
+func newTestNode() *testNode {
+	return &testNode{
+		changed:    make(chan struct{}),
+		semaphores: make(map[string]*testSemaphore),
+	}
+}
+
+func (n *testNode) newSession() *testSession {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.lastSessionID++
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &testSession{
+		node:   n,
+		id:     n.lastSessionID,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// notifyLocked wakes up all the goroutines waiting for the node state change. It must be called with the mutex held.
+func (n *testNode) notifyLocked() {
+	close(n.changed)
+	n.changed = make(chan struct{})
+}
+
+func (n *testNode) removeLocked(name string, sem *testSemaphore, session *testSession) {
+	for i, owner := range sem.owners {
+		if owner.session == session {
+			owner.lease.cancel()
+			sem.owners = append(sem.owners[:i], sem.owners[i+1:]...)
+
+			break
+		}
+	}
+	for i, waiter := range sem.waiters {
+		if waiter.session == session {
+			sem.waiters = append(sem.waiters[:i], sem.waiters[i+1:]...)
+
+			break
+		}
+	}
+	if sem.ephemeral && len(sem.owners) == 0 && len(sem.waiters) == 0 {
+		delete(n.semaphores, name)
+	}
+	n.notifyLocked()
+}
+
+func (n *testNode) describeLocked(name string) *SemaphoreDescription {
+	sem, ok := n.semaphores[name]
+	if !ok {
+		return &SemaphoreDescription{}
+	}
+
+	desc := &SemaphoreDescription{
+		Name:      name,
+		Limit:     sem.limit,
+		Ephemeral: sem.ephemeral,
+		Data:      sem.data,
+	}
+	for _, owner := range sem.owners {
+		desc.Count += owner.count
+		desc.Owners = append(desc.Owners, owner.describe())
+	}
+	for _, waiter := range sem.waiters {
+		desc.Waiters = append(desc.Waiters, waiter.describe())
+	}
+
+	return desc
+}
+
+func (s *testSemaphoreSession) describe() *SemaphoreSession {
+	return &SemaphoreSession{
+		SessionID: s.session.id,
+		Count:     s.count,
+		OrderID:   s.orderID,
+		Data:      s.data,
+	}
+}
+
+func (s *testSemaphore) acquired() uint64 {
+	var count uint64
+	for _, owner := range s.owners {
+		count += owner.count
+	}
+
+	return count
+}
+
+// lose emulates the session loss: the session context is canceled and all its leases are gone.
+func (s *testSession) lose() {
+	s.node.mutex.Lock()
+	defer s.node.mutex.Unlock()
+
+	s.cancel()
+	for name, sem := range s.node.semaphores {
+		s.node.removeLocked(name, sem, s)
+	}
+}
+
+func (s *testSession) Close(context.Context) error {
+	s.lose()
+
+	return nil
+}
+
+func (s *testSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testSession) CreateSemaphore(
+	_ context.Context,
+	name string,
+	limit uint64,
+	opts ...options.CreateSemaphoreOption,
+) error {
+	req := Ydb_Coordination.SessionRequest_CreateSemaphore{}
+	for _, o := range opts {
+		o(&req)
+	}
+
+	s.node.mutex.Lock()
+	defer s.node.mutex.Unlock()
+
+	if _, ok := s.node.semaphores[name]; ok {
+		return errors.New("test semaphore already exists")
+	}
+	s.node.semaphores[name] = &testSemaphore{limit: limit, data: req.GetData()}
+	s.node.notifyLocked()
+
+	return nil
+}
+
+func (s *testSession) UpdateSemaphore(_ context.Context, name string, opts ...options.UpdateSemaphoreOption) error {
+	req := Ydb_Coordination.SessionRequest_UpdateSemaphore{}
+	for _, o := range opts {
+		o(&req)
+	}
+
+	s.node.mutex.Lock()
+	defer s.node.mutex.Unlock()
+
+	sem, ok := s.node.semaphores[name]
+	if !ok {
+		return errTestSemaphoreNotFound
+	}
+	sem.data = req.GetData()
+	s.node.notifyLocked()
+
+	return nil
+}
+
+func (s *testSession) DeleteSemaphore(_ context.Context, name string, _ ...options.DeleteSemaphoreOption) error {
+	s.node.mutex.Lock()
+	defer s.node.mutex.Unlock()
+
+	if _, ok := s.node.semaphores[name]; !ok {
+		return errTestSemaphoreNotFound
+	}
+	delete(s.node.semaphores, name)
+	s.node.notifyLocked()
+
+	return nil
+}
+
+func (s *testSession) DescribeSemaphore(
+	_ context.Context,
+	name string,
+	_ ...options.DescribeSemaphoreOption,
+) (*SemaphoreDescription, error) {
+	if s.ctx.Err() != nil {
+		return nil, ErrSessionClosed
+	}
+
+	s.node.mutex.Lock()
+	defer s.node.mutex.Unlock()
+
+	return s.node.describeLocked(name), nil
+}
+
+//nolint:funlen
+func (s *testSession) AcquireSemaphore(
+	ctx context.Context,
+	name string,
+	count uint64,
+	opts ...options.AcquireSemaphoreOption,
+) (Lease, error) {
+	req := Ydb_Coordination.SessionRequest_AcquireSemaphore{TimeoutMillis: MaxSemaphoreLimit}
+	for _, o := range opts {
+		o(&req)
+	}
+
+	s.node.mutex.Lock()
+	defer s.node.mutex.Unlock()
+
+	if s.ctx.Err() != nil {
+		return nil, ErrSessionClosed
+	}
+
+	sem, ok := s.node.semaphores[name]
+	if !ok {
+		if !req.GetEphemeral() {
+			return nil, errTestSemaphoreNotFound
+		}
+		sem = &testSemaphore{limit: MaxSemaphoreLimit, ephemeral: true}
+		s.node.semaphores[name] = sem
+	}
+
+	for _, owner := range sem.owners {
+		if owner.session == s {
+			owner.count = count
+			owner.data = req.GetData()
+			s.node.notifyLocked()
+
+			return owner.lease, nil
+		}
+	}
+
+	s.node.lastOrderID++
+	waiter := &testSemaphoreSession{
+		session: s,
+		count:   count,
+		orderID: s.node.lastOrderID,
+		data:    req.GetData(),
+	}
+	sem.waiters = append(sem.waiters, waiter)
+	s.node.notifyLocked()
+
+	for {
+		if sem.waiters[0] == waiter && count <= sem.limit-sem.acquired() {
+			sem.waiters = sem.waiters[1:]
+			leaseCtx, cancel := context.WithCancel(s.ctx)
+			waiter.lease = &testLease{session: s, name: name, ctx: leaseCtx, cancel: cancel}
+			sem.owners = append(sem.owners, waiter)
+			s.node.notifyLocked()
+
+			return waiter.lease, nil
+		}
+
+		if req.GetTimeoutMillis() == 0 {
+			s.node.removeLocked(name, sem, s)
+
+			return nil, ErrAcquireTimeout
+		}
+
+		changed := s.node.changed
+		s.node.mutex.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+		case <-s.ctx.Done():
+		}
+		s.node.mutex.Lock()
+
+		if s.ctx.Err() != nil {
+			return nil, ErrSessionClosed
+		}
+		if ctx.Err() != nil {
+			s.node.removeLocked(name, sem, s)
+
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *testSession) WatchSemaphore(
+	ctx context.Context,
+	name string,
+	opts ...options.WatchSemaphoreOption,
+) (<-chan *SemaphoreDescription, error) {
+	cfg := options.WatchSemaphoreOptions{WatchData: true, WatchOwners: true}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	if s.ctx.Err() != nil {
+		return nil, ErrSessionClosed
+	}
+	if s.watchErr != nil {
+		return nil, s.watchErr
+	}
+
+	ch := make(chan *SemaphoreDescription, 1)
+	go func() {
+		defer close(ch)
+
+		var last *SemaphoreDescription
+		for {
+			s.node.mutex.Lock()
+			desc := s.node.describeLocked(name)
+			changed := s.node.changed
+			s.node.mutex.Unlock()
+
+			if last == nil ||
+				cfg.WatchData && !reflect.DeepEqual(desc.Data, last.Data) ||
+				cfg.WatchOwners && !reflect.DeepEqual(desc.Owners, last.Owners) {
+				select {
+				case <-ch:
+				default:
+				}
+				ch <- desc
+				last = desc
+			}
+
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *testSession) SessionID() uint64 {
+	return s.id
+}
+
+func (s *testSession) Reconnect() {}
+
+func (l *testLease) Context() context.Context {
+	return l.ctx
+}
+
+func (l *testLease) Release() error {
+	l.session.node.mutex.Lock()
+	defer l.session.node.mutex.Unlock()
+
+	if sem, ok := l.session.node.semaphores[l.name]; ok {
+		l.session.node.removeLocked(l.name, sem, l.session)
+	}
+	l.cancel()
+
+	return nil
+}
+
+func (l *testLease) Session() Session {
+	return l.session
+}
@@ -0,0 +1,120 @@
+package coordination
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+)
+
+const defaultHashRingReplicas = 128
+
+// HashRing assigns keys (for example, shards or topic partitions) to registry members using consistent hashing. When
+// a member joins or leaves the group, only the keys of that member move to other members.
+//
+// All the members build the same ring from the same list of members, so they agree on the assignment without any
+// extra coordination. Build a new ring on every RegistryChange.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type HashRing struct {
+	points []hashRingPoint
+}
+
+type hashRingPoint struct {
+	hash   uint64
+	member Member
+}
+
+// NewHashRing creates a new hash ring of the members.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewHashRing(members []Member, opts ...options.HashRingOption) *HashRing {
+	cfg := options.HashRingOptions{
+		Replicas: defaultHashRingReplicas,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	if cfg.Replicas < 1 {
+		cfg.Replicas = 1
+	}
+
+	ring := &HashRing{
+		points: make([]hashRingPoint, 0, len(members)*cfg.Replicas),
+	}
+
+	var buf [12]byte
+	for _, member := range members {
+		binary.LittleEndian.PutUint64(buf[:8], member.SessionID)
+		for i := 0; i < cfg.Replicas; i++ {
+			binary.LittleEndian.PutUint32(buf[8:], uint32(i))
+			ring.points = append(ring.points, hashRingPoint{
+				hash:   hashRingHash(buf[:]),
+				member: member,
+			})
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool {
+		if ring.points[i].hash == ring.points[j].hash {
+			return ring.points[i].member.SessionID < ring.points[j].member.SessionID
+		}
+
+		return ring.points[i].hash < ring.points[j].hash
+	})
+
+	return ring
+}
+
+// Owner returns the member the key is assigned to. It returns false if the ring has no members.
+func (r *HashRing) Owner(key string) (Member, bool) {
+	if len(r.points) == 0 {
+		return Member{}, false
+	}
+
+	hash := hashRingHash([]byte(key))
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= hash
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return r.points[i].member, true
+}
+
+// Owns reports whether the key is assigned to the member with the given session id.
+func (r *HashRing) Owns(sessionID uint64, key string) bool {
+	owner, ok := r.Owner(key)
+
+	return ok && owner.SessionID == sessionID
+}
+
+// Assign splits the keys among the members. The result maps the member session id to the keys assigned to it.
+func (r *HashRing) Assign(keys []string) map[uint64][]string {
+	result := make(map[uint64][]string)
+	for _, key := range keys {
+		if owner, ok := r.Owner(key); ok {
+			result[owner.SessionID] = append(result[owner.SessionID], key)
+		}
+	}
+
+	return result
+}
+
+func hashRingHash(data []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+
+	// FNV does not mix short inputs well, finalize the hash to spread the points uniformly.
+	x := h.Sum64()
+	x ^= x >> 30 //nolint:gomnd
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27 //nolint:gomnd
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31 //nolint:gomnd
+
+	return x
+}
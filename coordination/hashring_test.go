@@ -0,0 +1,49 @@
+package coordination
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+)
+
+func TestHashRing(t *testing.T) {
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("partition-%d", i)
+	}
+	members := []Member{{SessionID: 1}, {SessionID: 2}, {SessionID: 3}, {SessionID: 4}}
+
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := NewHashRing(nil).Owner("key")
+		require.False(t, ok)
+		require.Empty(t, NewHashRing(nil).Assign(keys))
+	})
+	t.Run("Deterministic", func(t *testing.T) {
+		reversed := []Member{members[3], members[2], members[1], members[0]}
+
+		require.Equal(t, NewHashRing(members).Assign(keys), NewHashRing(reversed).Assign(keys))
+	})
+	t.Run("Balanced", func(t *testing.T) {
+		assignment := NewHashRing(members).Assign(keys)
+
+		require.Len(t, assignment, len(members))
+		for _, m := range members {
+			require.InDelta(t, len(keys)/len(members), len(assignment[m.SessionID]), 100)
+		}
+	})
+	t.Run("MinimalMovement", func(t *testing.T) {
+		before := NewHashRing(members, options.WithHashRingReplicas(64))
+		after := NewHashRing(members[:3], options.WithHashRingReplicas(64))
+
+		for _, key := range keys {
+			owner, ok := before.Owner(key)
+			require.True(t, ok)
+			if owner.SessionID != 4 {
+				require.True(t, after.Owns(owner.SessionID, key), key)
+			}
+		}
+	})
+}
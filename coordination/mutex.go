@@ -0,0 +1,345 @@
+package coordination
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+)
+
+// Mutex is a distributed mutual exclusion lock backed by an ephemeral semaphore of a coordination node. The semaphore
+// is created with the first lock and deleted with the last unlock.
+//
+// Every successful lock is assigned a fencing token which is greater than the tokens of all the previous locks of the
+// same semaphore. Pass the token along with the writes made under the lock to let the storage reject the writes of
+// stale lock holders.
+//
+// The lock may be lost without calling Unlock if the session is lost. Check the context returned by the Context method
+// to find it out. Use one Mutex or RWMutex object per semaphore name in a session: the server does not distinguish
+// locks of the same session.
+//
+// Mutex is safe for concurrent use.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type Mutex struct {
+	rw *RWMutex
+}
+
+// RWMutex is a distributed reader/writer mutual exclusion lock backed by an ephemeral semaphore of a coordination
+// node. The lock can be held by an arbitrary number of readers or a single writer. Readers acquire the semaphore with
+// the Shared count, writers acquire it with the Exclusive count.
+//
+// Readers of the same RWMutex object share the single acquire operation of the session, so they get the same fencing
+// token. See Mutex for details on fencing tokens and lock loss.
+//
+// RWMutex is safe for concurrent use.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type RWMutex struct {
+	session Session
+	name    string
+
+	mutex     sync.Mutex // guards the fields below
+	changed   chan struct{}
+	writer    bool
+	readers   int
+	acquiring bool
+	lease     Lease
+	token     uint64
+}
+
+var (
+	errMutexLocked  = errors.New("mutex is locked")
+	canceledContext = func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		return ctx
+	}()
+)
+
+// NewMutex creates a new distributed mutex which uses the ephemeral semaphore with the given name.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewMutex(session Session, name string) *Mutex {
+	return &Mutex{
+		rw: NewRWMutex(session, name),
+	}
+}
+
+// Lock blocks until the mutex is locked, an error is returned from the server or ctx is canceled.
+func (m *Mutex) Lock(ctx context.Context) error {
+	return m.rw.Lock(ctx)
+}
+
+// TryLock tries to lock the mutex without waiting and reports whether it succeeded.
+func (m *Mutex) TryLock(ctx context.Context) (bool, error) {
+	return m.rw.TryLock(ctx)
+}
+
+// Unlock unlocks the mutex. It returns the ErrNotLocked error if the mutex is not locked.
+func (m *Mutex) Unlock() error {
+	return m.rw.Unlock()
+}
+
+// FencingToken returns the fencing token of the current lock or zero if the mutex is not locked.
+func (m *Mutex) FencingToken() uint64 {
+	return m.rw.FencingToken()
+}
+
+// Context returns the context which is canceled when the current lock is released or lost. If the mutex is not
+// locked, the returned context is already canceled.
+func (m *Mutex) Context() context.Context {
+	return m.rw.Context()
+}
+
+// NewRWMutex creates a new distributed reader/writer mutex which uses the ephemeral semaphore with the given name.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewRWMutex(session Session, name string) *RWMutex {
+	return &RWMutex{
+		session: session,
+		name:    name,
+		changed: make(chan struct{}),
+	}
+}
+
+// Lock blocks until the mutex is locked for writing, an error is returned from the server or ctx is canceled.
+func (m *RWMutex) Lock(ctx context.Context) error {
+	return m.lock(ctx, true)
+}
+
+// TryLock tries to lock the mutex for writing without waiting and reports whether it succeeded.
+func (m *RWMutex) TryLock(ctx context.Context) (bool, error) {
+	return tryLock(m.lock(ctx, false))
+}
+
+// Unlock unlocks the mutex locked for writing. It returns the ErrNotLocked error if the mutex is not locked for
+// writing.
+func (m *RWMutex) Unlock() error {
+	m.mutex.Lock()
+	if !m.writer || m.acquiring {
+		m.mutex.Unlock()
+
+		return ErrNotLocked
+	}
+	m.acquiring = true
+	m.mutex.Unlock()
+
+	err := m.release()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.writer = false
+	m.acquiring = false
+	m.notifyLocked()
+
+	return err
+}
+
+// RLock blocks until the mutex is locked for reading, an error is returned from the server or ctx is canceled.
+func (m *RWMutex) RLock(ctx context.Context) error {
+	return m.rlock(ctx, true)
+}
+
+// TryRLock tries to lock the mutex for reading without waiting and reports whether it succeeded.
+func (m *RWMutex) TryRLock(ctx context.Context) (bool, error) {
+	return tryLock(m.rlock(ctx, false))
+}
+
+// RUnlock undoes a single RLock call. It returns the ErrNotLocked error if the mutex is not locked for reading.
+func (m *RWMutex) RUnlock() error {
+	m.mutex.Lock()
+	if m.readers == 0 || m.acquiring {
+		m.mutex.Unlock()
+
+		return ErrNotLocked
+	}
+	m.readers--
+	if m.readers > 0 {
+		m.mutex.Unlock()
+
+		return nil
+	}
+	m.acquiring = true
+	m.mutex.Unlock()
+
+	err := m.release()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.acquiring = false
+	m.notifyLocked()
+
+	return err
+}
+
+// FencingToken returns the fencing token of the current lock or zero if the mutex is not locked.
+func (m *RWMutex) FencingToken() uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.lease == nil {
+		return 0
+	}
+
+	return m.token
+}
+
+// Context returns the context which is canceled when the current lock is released or lost. If the mutex is not
+// locked, the returned context is already canceled.
+func (m *RWMutex) Context() context.Context {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.lease == nil {
+		return canceledContext
+	}
+
+	return m.lease.Context()
+}
+
+func (m *RWMutex) lock(ctx context.Context, wait bool) error {
+	err := m.awaitLocked(ctx, wait, func() bool {
+		return !m.writer && !m.acquiring && m.readers == 0
+	})
+	if err != nil {
+		return err
+	}
+	m.writer = true
+	m.acquiring = true
+	m.mutex.Unlock()
+
+	lease, token, err := m.acquire(ctx, Exclusive, wait)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.acquiring = false
+	if err != nil {
+		m.writer = false
+	} else {
+		m.lease, m.token = lease, token
+	}
+	m.notifyLocked()
+
+	return err
+}
+
+func (m *RWMutex) rlock(ctx context.Context, wait bool) error {
+	err := m.awaitLocked(ctx, wait, func() bool {
+		return !m.writer && !m.acquiring
+	})
+	if err != nil {
+		return err
+	}
+	if m.readers > 0 && m.lease.Context().Err() == nil {
+		m.readers++
+		m.mutex.Unlock()
+
+		return nil
+	}
+	m.acquiring = true
+	m.mutex.Unlock()
+
+	lease, token, err := m.acquire(ctx, Shared, wait)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.acquiring = false
+	if err == nil {
+		// The previous shared lock may be lost, the readers which still hold it keep counting.
+		m.readers++
+		m.lease, m.token = lease, token
+	}
+	m.notifyLocked()
+
+	return err
+}
+
+// awaitLocked waits until the local state satisfies the condition and returns with the mutex held. If an error is
+// returned, the mutex is not held.
+func (m *RWMutex) awaitLocked(ctx context.Context, wait bool, cond func() bool) error {
+	for {
+		m.mutex.Lock()
+		if cond() {
+			return nil
+		}
+		changed := m.changed
+		m.mutex.Unlock()
+
+		if !wait {
+			return errMutexLocked
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *RWMutex) notifyLocked() {
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// acquire acquires the semaphore and finds out the fencing token of the acquire operation.
+func (m *RWMutex) acquire(ctx context.Context, count uint64, wait bool) (Lease, uint64, error) {
+	opts := []options.AcquireSemaphoreOption{options.WithEphemeral(true)}
+	if !wait {
+		opts = append(opts, options.WithAcquireTimeout(0))
+	}
+
+	lease, err := m.session.AcquireSemaphore(ctx, m.name, count, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	desc, err := m.session.DescribeSemaphore(ctx, m.name, options.WithDescribeOwners(true))
+	if err != nil {
+		_ = lease.Release()
+
+		return nil, 0, err
+	}
+
+	for _, owner := range desc.Owners {
+		if owner.SessionID == m.session.SessionID() {
+			return lease, owner.OrderID, nil
+		}
+	}
+
+	_ = lease.Release()
+
+	return nil, 0, ErrLockLost
+}
+
+func (m *RWMutex) release() error {
+	m.mutex.Lock()
+	lease := m.lease
+	m.lease, m.token = nil, 0
+	m.mutex.Unlock()
+
+	if lease.Context().Err() != nil {
+		// The lock is already lost, the server has nothing to release.
+		return nil
+	}
+
+	return lease.Release()
+}
+
+func tryLock(err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, errMutexLocked), errors.Is(err, ErrAcquireTimeout):
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,156 @@
+package coordination
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+func TestMutex(t *testing.T) {
+	t.Run("LockUnlock", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		m1, m2 := NewMutex(node.newSession(), "lock"), NewMutex(node.newSession(), "lock")
+
+		require.Zero(t, m1.FencingToken())
+		require.Error(t, m1.Context().Err())
+
+		require.NoError(t, m1.Lock(ctx))
+		token := m1.FencingToken()
+		require.NotZero(t, token)
+		require.NoError(t, m1.Context().Err())
+
+		locked, err := m2.TryLock(ctx)
+		require.NoError(t, err)
+		require.False(t, locked)
+
+		lockCtx := m1.Context()
+		require.NoError(t, m1.Unlock())
+		require.Error(t, lockCtx.Err())
+		require.Zero(t, m1.FencingToken())
+
+		locked, err = m2.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		require.Greater(t, m2.FencingToken(), token)
+		require.NoError(t, m2.Unlock())
+
+		require.Empty(t, node.semaphores, "ephemeral semaphore must be deleted with the last unlock")
+	})
+	t.Run("UnlockNotLocked", func(t *testing.T) {
+		m := NewMutex(newTestNode().newSession(), "lock")
+
+		require.ErrorIs(t, m.Unlock(), ErrNotLocked)
+	})
+	t.Run("LocalExclusion", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		m := NewMutex(newTestNode().newSession(), "lock")
+
+		require.NoError(t, m.Lock(ctx))
+
+		locked, err := m.TryLock(ctx)
+		require.NoError(t, err)
+		require.False(t, locked)
+
+		lockCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, m.Lock(lockCtx), context.DeadlineExceeded)
+
+		require.NoError(t, m.Unlock())
+		require.NoError(t, m.Lock(ctx))
+	})
+	t.Run("WaitForUnlock", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		m1, m2 := NewMutex(node.newSession(), "lock"), NewMutex(node.newSession(), "lock")
+
+		require.NoError(t, m1.Lock(ctx))
+		token := m1.FencingToken()
+
+		locked := make(chan error, 1)
+		go func() {
+			locked <- m2.Lock(ctx)
+		}()
+
+		xtest.SpinWaitCondition(t, &node.mutex, func() bool {
+			return len(node.semaphores["lock"].waiters) == 1
+		})
+		require.NoError(t, m1.Unlock())
+
+		require.NoError(t, <-locked)
+		require.Greater(t, m2.FencingToken(), token)
+	})
+	t.Run("LockLostWithSession", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		s := node.newSession()
+		m := NewMutex(s, "lock")
+
+		require.NoError(t, m.Lock(ctx))
+		lockCtx := m.Context()
+
+		s.lose()
+		<-lockCtx.Done()
+
+		require.NoError(t, NewMutex(node.newSession(), "lock").Lock(ctx))
+		require.NoError(t, m.Unlock())
+	})
+}
+
+func TestRWMutex(t *testing.T) {
+	t.Run("SharedReaders", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		m1, m2 := NewRWMutex(node.newSession(), "lock"), NewRWMutex(node.newSession(), "lock")
+
+		require.NoError(t, m1.RLock(ctx))
+		require.NoError(t, m1.RLock(ctx))
+		require.NoError(t, m2.RLock(ctx))
+		require.NotEqual(t, m1.FencingToken(), m2.FencingToken())
+
+		locked, err := m2.TryLock(ctx)
+		require.NoError(t, err)
+		require.False(t, locked)
+
+		require.NoError(t, m1.RUnlock())
+		require.NoError(t, m1.Context().Err(), "the second reader still holds the lock")
+		require.NoError(t, m1.RUnlock())
+		require.ErrorIs(t, m1.RUnlock(), ErrNotLocked)
+		require.NoError(t, m2.RUnlock())
+
+		locked, err = m2.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+	})
+	t.Run("WriterBlocksReaders", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		writer, reader := NewRWMutex(node.newSession(), "lock"), NewRWMutex(node.newSession(), "lock")
+
+		require.NoError(t, writer.Lock(ctx))
+
+		locked, err := reader.TryRLock(ctx)
+		require.NoError(t, err)
+		require.False(t, locked)
+
+		locked, err = writer.TryRLock(ctx)
+		require.NoError(t, err)
+		require.False(t, locked)
+
+		rlocked := make(chan error, 1)
+		go func() {
+			rlocked <- reader.RLock(ctx)
+		}()
+
+		xtest.SpinWaitCondition(t, &node.mutex, func() bool {
+			return len(node.semaphores["lock"].waiters) == 1
+		})
+		require.NoError(t, writer.Unlock())
+		require.NoError(t, <-rlocked)
+		require.NoError(t, reader.RUnlock())
+	})
+}
@@ -171,3 +171,73 @@ func WithDescribeWaiters(describeWaiters bool) DescribeSemaphoreOption {
 
 // DescribeSemaphoreOption configures how we update a semaphore.
 type DescribeSemaphoreOption func(c *Ydb_Coordination.SessionRequest_DescribeSemaphore)
+
+// WithWatchData returns a WatchSemaphoreOption which specifies whether the watcher gets a new semaphore description
+// when the semaphore data changes.
+//
+// If this is not set, the watcher is notified of the data changes.
+func WithWatchData(watchData bool) WatchSemaphoreOption {
+	return func(c *WatchSemaphoreOptions) {
+		c.WatchData = watchData
+	}
+}
+
+// WithWatchOwners returns a WatchSemaphoreOption which specifies whether the watcher gets a new semaphore description
+// when the semaphore owners (including the data attached to the acquire operations) change.
+//
+// If this is not set, the watcher is notified of the owners changes.
+func WithWatchOwners(watchOwners bool) WatchSemaphoreOption {
+	return func(c *WatchSemaphoreOptions) {
+		c.WatchOwners = watchOwners
+	}
+}
+
+// WithElectionObserveInterval returns an ElectionOption that specifies how often the Election.Observe method checks
+// the election semaphore for leader changes if the watch of the semaphore could not be started.
+//
+// If this is not set, the client uses the default 1 second, non-positive interval is ignored.
+func WithElectionObserveInterval(interval time.Duration) ElectionOption {
+	return func(c *ElectionOptions) {
+		if interval > 0 {
+			c.ObserveInterval = interval
+		}
+	}
+}
+
+// ElectionOption configures how we create a new election.
+type ElectionOption func(c *ElectionOptions)
+
+// ElectionOptions configure an election. ElectionOptions are set by the ElectionOption values passed to the
+// NewElection function.
+type ElectionOptions struct {
+	ObserveInterval time.Duration
+}
+
+// WatchSemaphoreOption configures how we watch a semaphore.
+type WatchSemaphoreOption func(c *WatchSemaphoreOptions)
+
+// WatchSemaphoreOptions configure a WatchSemaphore call. WatchSemaphoreOptions are set by the WatchSemaphoreOption
+// values passed to the WatchSemaphore function.
+type WatchSemaphoreOptions struct {
+	WatchData   bool
+	WatchOwners bool
+}
+
+// WithHashRingReplicas returns a HashRingOption that specifies the number of points each member takes on the hash
+// ring. More points make the distribution of keys more uniform at the cost of memory.
+//
+// If this is not set, the default value 128 is used.
+func WithHashRingReplicas(replicas int) HashRingOption {
+	return func(c *HashRingOptions) {
+		c.Replicas = replicas
+	}
+}
+
+// HashRingOption configures how we create a new hash ring.
+type HashRingOption func(c *HashRingOptions)
+
+// HashRingOptions configure a hash ring. HashRingOptions are set by the HashRingOption values passed to the
+// NewHashRing function.
+type HashRingOptions struct {
+	Replicas int
+}
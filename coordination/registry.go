@@ -0,0 +1,209 @@
+package coordination
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+)
+
+// Registry is a membership registry of a group of services backed by an ephemeral semaphore of a coordination node.
+// Every member of the group is an owner of the semaphore, its payload is the data attached to the acquire operation.
+// The member leaves the group when it unregisters or its session is lost.
+//
+// Registry is safe for concurrent use.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type Registry struct {
+	session Session
+	group   string
+
+	mutex sync.Mutex // guards the field below
+	lease Lease
+}
+
+// Member describes a member of a registry group.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type Member struct {
+	// SessionID is the id of the member session. It is unique within the coordination node.
+	SessionID uint64
+
+	// Data is the payload the member registered with.
+	Data []byte
+}
+
+// RegistryChange describes a change of the registry group.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type RegistryChange struct {
+	// Members is the list of the current members of the group sorted by SessionID.
+	Members []Member
+
+	// Joined is the list of members which joined the group since the previous change.
+	Joined []Member
+
+	// Left is the list of members which left the group since the previous change.
+	Left []Member
+}
+
+// NewRegistry creates a new registry of the group which uses the ephemeral semaphore with the group name.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewRegistry(session Session, group string) *Registry {
+	return &Registry{
+		session: session,
+		group:   group,
+	}
+}
+
+// Register adds the session to the group with the given payload. If the session is already registered, Register
+// replaces the payload.
+//
+// The returned context is canceled as soon as the session leaves the group: when the Unregister method is called,
+// the session is closed or the client could not keep the session alive.
+func (r *Registry) Register(ctx context.Context, data []byte) (context.Context, error) {
+	lease, err := r.session.AcquireSemaphore(ctx, r.group, Shared,
+		options.WithEphemeral(true),
+		options.WithAcquireData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.lease != nil && r.lease.Context().Err() == nil {
+		return r.lease.Context(), nil
+	}
+
+	r.lease = lease
+
+	return lease.Context(), nil
+}
+
+// Unregister removes the session from the group. It is noop if the session is not registered.
+func (r *Registry) Unregister(ctx context.Context) error {
+	r.mutex.Lock()
+	lease := r.lease
+	r.lease = nil
+	r.mutex.Unlock()
+
+	if lease == nil || lease.Context().Err() != nil {
+		return nil
+	}
+
+	released := make(chan error, 1)
+	go func() {
+		released <- lease.Release()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-released:
+		return err
+	}
+}
+
+// Members returns the current members of the group sorted by SessionID.
+func (r *Registry) Members(ctx context.Context) ([]Member, error) {
+	desc, err := r.session.DescribeSemaphore(ctx, r.group, options.WithDescribeOwners(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return registryMembers(desc), nil
+}
+
+// Watch returns a channel which receives the current members of the group and then every change of them, including
+// the payload changes. The channel is closed when ctx is canceled or the session is closed.
+func (r *Registry) Watch(ctx context.Context) (<-chan RegistryChange, error) {
+	descriptions, err := r.session.WatchSemaphore(ctx, r.group, options.WithWatchData(false))
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan RegistryChange, 1)
+
+	go func() {
+		defer close(ch)
+
+		var (
+			last []Member
+			sent bool
+		)
+		for desc := range descriptions {
+			members := registryMembers(desc)
+			if sent && equalMembers(members, last) {
+				continue
+			}
+
+			change := RegistryChange{
+				Members: members,
+				Joined:  subtractMembers(members, last),
+				Left:    subtractMembers(last, members),
+			}
+			select {
+			case ch <- change:
+				last, sent = members, true
+			case <-ctx.Done():
+				// Drain the descriptions until the watch is stopped.
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func registryMembers(desc *SemaphoreDescription) []Member {
+	if desc == nil {
+		return nil
+	}
+
+	members := make([]Member, 0, len(desc.Owners))
+	for _, owner := range desc.Owners {
+		members = append(members, Member{
+			SessionID: owner.SessionID,
+			Data:      owner.Data,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].SessionID < members[j].SessionID
+	})
+
+	return members
+}
+
+func equalMembers(a, b []Member) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].SessionID != b[i].SessionID || !bytes.Equal(a[i].Data, b[i].Data) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// subtractMembers returns the members of a which are not in b.
+func subtractMembers(a, b []Member) []Member {
+	ids := make(map[uint64]struct{}, len(b))
+	for _, m := range b {
+		ids[m.SessionID] = struct{}{}
+	}
+
+	var result []Member
+	for _, m := range a {
+		if _, ok := ids[m.SessionID]; !ok {
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
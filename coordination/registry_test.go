@@ -0,0 +1,85 @@
+package coordination
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("RegisterAndMembers", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		s1, s2 := node.newSession(), node.newSession()
+		r1, r2 := NewRegistry(s1, "workers"), NewRegistry(s2, "workers")
+
+		members, err := r1.Members(ctx)
+		require.NoError(t, err)
+		require.Empty(t, members)
+
+		membership, err := r1.Register(ctx, []byte("host-1"))
+		require.NoError(t, err)
+		_, err = r2.Register(ctx, []byte("host-2"))
+		require.NoError(t, err)
+
+		members, err = r1.Members(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []Member{
+			{SessionID: s1.SessionID(), Data: []byte("host-1")},
+			{SessionID: s2.SessionID(), Data: []byte("host-2")},
+		}, members)
+
+		sameMembership, err := r1.Register(ctx, []byte("host-1-updated"))
+		require.NoError(t, err)
+		require.Equal(t, membership, sameMembership)
+
+		require.NoError(t, r1.Unregister(ctx))
+		require.Error(t, membership.Err())
+		require.NoError(t, r1.Unregister(ctx))
+
+		members, err = r2.Members(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []Member{{SessionID: s2.SessionID(), Data: []byte("host-2")}}, members)
+	})
+	t.Run("Watch", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		node := newTestNode()
+		s1, s2 := node.newSession(), node.newSession()
+		r1, r2 := NewRegistry(s1, "workers"), NewRegistry(s2, "workers")
+
+		watchCtx, cancel := context.WithCancel(ctx)
+		changes, err := r1.Watch(watchCtx)
+		require.NoError(t, err)
+
+		change := <-changes
+		require.Empty(t, change.Members)
+
+		_, err = r1.Register(ctx, []byte("host-1"))
+		require.NoError(t, err)
+
+		change = <-changes
+		require.Equal(t, []Member{{SessionID: s1.SessionID(), Data: []byte("host-1")}}, change.Joined)
+		require.Empty(t, change.Left)
+
+		_, err = r2.Register(ctx, []byte("host-2"))
+		require.NoError(t, err)
+
+		change = <-changes
+		require.Len(t, change.Members, 2)
+		require.Equal(t, []Member{{SessionID: s2.SessionID(), Data: []byte("host-2")}}, change.Joined)
+
+		s2.lose()
+
+		change = <-changes
+		require.Len(t, change.Members, 1)
+		require.Empty(t, change.Joined)
+		require.Equal(t, []Member{{SessionID: s2.SessionID(), Data: []byte("host-2")}}, change.Left)
+
+		cancel()
+		for range changes {
+		}
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"strings"
 	"sync/atomic"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Discovery_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Discovery"
 	"google.golang.org/grpc"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/config"
@@ -40,6 +42,13 @@ type Balancer struct {
 	localDCDetector func(ctx context.Context, endpoints []endpoint.Endpoint) (string, error)
 
 	connectionsState atomic.Pointer[connectionsState]
+
+	// latencies are the stats of the least latency balancing, nil for other algorithms
+	latencies *latencyTracker
+
+	// outliers eject the failing endpoints, nil if the outlier detection is disabled
+	outliers       *outlierDetector
+	probesRepeater repeater.Repeater
 }
 
 func (b *Balancer) clusterDiscovery(ctx context.Context) (err error) {
@@ -140,8 +149,23 @@ func (b *Balancer) applyDiscoveredEndpoints(ctx context.Context, newest []endpoi
 		c.Endpoint().Touch()
 	}
 
+	if b.latencies != nil {
+		b.latencies.retain(connections)
+	}
+
+	if b.outliers != nil {
+		b.outliers.retain(connections)
+	}
+
+	var weights map[string]float64
+	if b.balancerConfig.Algorithm == balancerConfig.WeightedByLoad {
+		weights = loadWeights(newest, b.balancerConfig.LocationWeights)
+	}
+
 	info := balancerConfig.Info{SelfLocation: localDC}
-	state := newConnectionsState(connections, b.balancerConfig.Filter, info, b.balancerConfig.AllowFallback)
+	state := newConnectionsState(connections, b.balancerConfig.Filter, info, b.balancerConfig.AllowFallback,
+		b.latencies, weights, b.outliers,
+	)
 
 	endpointsInfo := make([]endpoint.Info, len(newest))
 	for i, e := range newest {
@@ -164,6 +188,10 @@ func (b *Balancer) Close(ctx context.Context) (err error) {
 		b.discoveryRepeater.Stop()
 	}
 
+	if b.probesRepeater != nil {
+		b.probesRepeater.Stop()
+	}
+
 	return nil
 }
 
@@ -220,6 +248,24 @@ func makeDiscoveryFunc(
 	}
 }
 
+// makeProbeFunc returns the lightweight check of the endpoint. The endpoint is alive if it answers without
+// the transport error
+func makeProbeFunc(driverConfig *config.Config) func(ctx context.Context, cc conn.Conn) error {
+	return func(ctx context.Context, cc conn.Conn) error {
+		ctx, err := driverConfig.Meta().Context(ctx)
+		if err != nil {
+			return xerrors.WithStackTrace(err)
+		}
+
+		_, err = Ydb_Discovery_V1.NewDiscoveryServiceClient(cc).WhoAmI(ctx, &Ydb_Discovery.WhoAmIRequest{})
+		if err != nil && conn.IsBadConn(err, driverConfig.ExcludeGRPCCodesForPessimization()...) {
+			return xerrors.WithStackTrace(err)
+		}
+
+		return nil
+	}
+}
+
 func New(ctx context.Context, driverConfig *config.Config, pool *conn.Pool, opts ...discoveryConfig.Option) (
 	b *Balancer, finalErr error,
 ) {
@@ -252,6 +298,20 @@ func New(ctx context.Context, driverConfig *config.Config, pool *conn.Pool, opts
 		b.balancerConfig = *config
 	}
 
+	if b.balancerConfig.Algorithm == balancerConfig.LeastLatency {
+		b.latencies = newLatencyTracker()
+	}
+
+	if outlierDetection := b.balancerConfig.OutlierDetection; outlierDetection != nil && !b.balancerConfig.SingleConn {
+		b.outliers = newOutlierDetector(*outlierDetection, driverConfig.Trace(), clockwork.NewRealClock(),
+			func(err error) bool {
+				return conn.IsBadConn(err, driverConfig.ExcludeGRPCCodesForPessimization()...)
+			},
+			makeProbeFunc(driverConfig),
+			b.pool.Allow,
+		)
+	}
+
 	if b.balancerConfig.SingleConn {
 		b.applyDiscoveredEndpoints(ctx, []endpoint.Endpoint{
 			endpoint.New(driverConfig.Endpoint()),
@@ -269,6 +329,14 @@ func New(ctx context.Context, driverConfig *config.Config, pool *conn.Pool, opts
 				repeater.WithTrace(b.driverConfig.Trace()),
 			)
 		}
+		// run background probes of the ejected endpoints
+		if b.outliers != nil && b.outliers.config.ProbeInterval > 0 {
+			b.probesRepeater = repeater.New(xcontext.ValueOnly(ctx),
+				b.outliers.config.ProbeInterval, b.outliers.probeEjected,
+				repeater.WithName("outliers probes"),
+				repeater.WithTrace(b.driverConfig.Trace()),
+			)
+		}
 	}
 
 	return b, nil
@@ -311,6 +379,13 @@ func (b *Balancer) wrapCall(ctx context.Context, f func(ctx context.Context, cc
 		return xerrors.WithStackTrace(err)
 	}
 
+	if b.latencies != nil {
+		done := b.latencies.start(cc)
+		defer func() {
+			done(err)
+		}()
+	}
+
 	defer func() {
 		if err == nil {
 			if cc.GetState() == conn.Banned {
@@ -319,6 +394,9 @@ func (b *Balancer) wrapCall(ctx context.Context, f func(ctx context.Context, cc
 		} else if conn.IsBadConn(err, b.driverConfig.ExcludeGRPCCodesForPessimization()...) {
 			b.pool.Ban(ctx, cc, err)
 		}
+		if b.outliers != nil {
+			b.outliers.report(ctx, cc, err)
+		}
 	}()
 
 	if err = f(ctx, cc); err != nil {
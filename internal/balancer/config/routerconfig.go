@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
@@ -9,11 +11,72 @@ import (
 
 // Dedicated package need for prevent cyclo dependencies config -> balancer -> config
 
+// Algorithm defines how the balancer chooses the connection among the allowed endpoints
+type Algorithm uint8
+
+const (
+	// RandomChoice chooses the connection uniformly at random
+	RandomChoice = Algorithm(iota)
+
+	// LeastLatency chooses the better of two random connections by the latency and the in-flight requests
+	LeastLatency
+
+	// WeightedByLoad chooses the connection at random with the weights derived from the load factors of the nodes
+	WeightedByLoad
+)
+
+func (a Algorithm) String() string {
+	switch a {
+	case RandomChoice:
+		return "RandomChoice"
+	case LeastLatency:
+		return "LeastLatency"
+	case WeightedByLoad:
+		return "WeightedByLoad"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", a)
+	}
+}
+
 type Config struct {
 	Filter          Filter
 	AllowFallback   bool
 	SingleConn      bool
 	DetectNearestDC bool
+	Algorithm       Algorithm
+
+	// LocationWeights are the static multipliers of the weights of the nodes by location for the WeightedByLoad
+	// algorithm. The nodes in the locations without weight have multiplier 1
+	LocationWeights map[string]float64
+
+	// OutlierDetection enables the ejection of the failing endpoints, nil disables it
+	OutlierDetection *OutlierDetection
+}
+
+// OutlierDetection defines when the failing endpoints are ejected from the balancing and how they are restored
+type OutlierDetection struct {
+	// ConsecutiveErrors is the count of the consecutive transport errors of the endpoint to eject it, zero disables
+	// the ejection by the consecutive errors
+	ConsecutiveErrors int
+
+	// ErrorRate is the share of the transport errors of the endpoint in Interval to eject it, zero disables
+	// the ejection by the error rate
+	ErrorRate float64
+
+	// MinRequests is the minimal count of the requests to the endpoint in Interval to check ErrorRate
+	MinRequests int
+
+	// Interval is the window of the error rate
+	Interval time.Duration
+
+	// ProbeInterval is the interval of the probes of the ejected endpoints
+	ProbeInterval time.Duration
+
+	// RampUp is the duration of the gradual growth of the traffic to the restored endpoint
+	RampUp time.Duration
+
+	// MaxEjectedRatio is the maximal share of the ejected endpoints
+	MaxEjectedRatio float64
 }
 
 func (c Config) String() string {
@@ -24,7 +87,8 @@ func (c Config) String() string {
 	buffer := xstring.Buffer()
 	defer buffer.Free()
 
-	buffer.WriteString("RandomChoice{")
+	buffer.WriteString(c.Algorithm.String())
+	buffer.WriteByte('{')
 
 	buffer.WriteString("DetectNearestDC=")
 	fmt.Fprintf(buffer, "%t", c.DetectNearestDC)
@@ -32,6 +96,27 @@ func (c Config) String() string {
 	buffer.WriteString(",AllowFallback=")
 	fmt.Fprintf(buffer, "%t", c.AllowFallback)
 
+	if len(c.LocationWeights) > 0 {
+		locations := make([]string, 0, len(c.LocationWeights))
+		for location := range c.LocationWeights {
+			locations = append(locations, location)
+		}
+		sort.Strings(locations)
+
+		buffer.WriteString(",LocationWeights={")
+		for i, location := range locations {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			fmt.Fprintf(buffer, "%s:%g", location, c.LocationWeights[location])
+		}
+		buffer.WriteByte('}')
+	}
+
+	if c.OutlierDetection != nil {
+		fmt.Fprintf(buffer, ",OutlierDetection=%+v", *c.OutlierDetection)
+	}
+
 	if c.Filter != nil {
 		buffer.WriteString(",Filter=")
 		fmt.Fprint(buffer, c.Filter.String())
@@ -16,6 +16,16 @@ type connectionsState struct {
 	fallback []conn.Conn
 	all      []conn.Conn
 
+	// latencies are the stats of the least latency balancing, nil for the random choice
+	latencies *latencyTracker
+
+	// weights are the weights of the connections by address for the weighted by load balancing, nil for the other
+	// algorithms
+	weights map[string]float64
+
+	// outliers eject the failing endpoints, nil if the outlier detection is disabled
+	outliers *outlierDetector
+
 	rand xrand.Rand
 }
 
@@ -24,9 +34,15 @@ func newConnectionsState(
 	filter balancerConfig.Filter,
 	info balancerConfig.Info,
 	allowFallback bool,
+	latencies *latencyTracker,
+	weights map[string]float64,
+	outliers *outlierDetector,
 ) *connectionsState {
 	res := &connectionsState{
 		connByNodeID: connsToNodeIDMap(conns),
+		latencies:    latencies,
+		weights:      weights,
+		outliers:     outliers,
 		rand:         xrand.New(xrand.WithLock()),
 	}
 
@@ -67,7 +83,7 @@ func (s *connectionsState) GetConnection(ctx context.Context) (_ conn.Conn, fail
 	}
 
 	try := func(conns []conn.Conn) conn.Conn {
-		c, tryFailed := s.selectRandomConnection(conns, false)
+		c, tryFailed := s.selectConnection(conns, false)
 		failedCount += tryFailed
 
 		return c
@@ -81,11 +97,44 @@ func (s *connectionsState) GetConnection(ctx context.Context) (_ conn.Conn, fail
 		return c, failedCount
 	}
 
-	c, _ := s.selectRandomConnection(s.all, true)
+	c, _ := s.selectConnection(s.all, true)
 
 	return c, failedCount
 }
 
+func (s *connectionsState) selectConnection(conns []conn.Conn, allowBanned bool) (c conn.Conn, failedConns int) {
+	if s.latencies != nil {
+		return s.selectLeastLatencyConnection(conns, allowBanned)
+	}
+
+	if s.weights != nil {
+		return s.selectWeightedConnection(conns, allowBanned)
+	}
+
+	return s.selectRandomConnection(conns, allowBanned)
+}
+
+// selectLeastLatencyConnection chooses the cheaper of two random connections (power of two choices)
+func (s *connectionsState) selectLeastLatencyConnection(conns []conn.Conn, allowBanned bool) (
+	c conn.Conn, failedConns int,
+) {
+	c, failedConns = s.selectRandomConnection(conns, allowBanned)
+	if c == nil || len(conns) < 2 {
+		return c, failedConns
+	}
+
+	other := conns[s.rand.Int(len(conns))]
+	if other == c || !s.isOkConnection(other, allowBanned) {
+		return c, failedConns
+	}
+
+	if s.latencies.cost(other) < s.latencies.cost(c) {
+		return other, failedConns
+	}
+
+	return c, failedConns
+}
+
 func (s *connectionsState) preferConnection(ctx context.Context) conn.Conn {
 	if nodeID, hasPreferEndpoint := endpoint.ContextNodeID(ctx); hasPreferEndpoint {
 		c := s.connByNodeID[nodeID]
@@ -105,7 +154,7 @@ func (s *connectionsState) selectRandomConnection(conns []conn.Conn, allowBanned
 	}
 
 	// fast path
-	if c := conns[s.rand.Int(connCount)]; isOkConnection(c, allowBanned) {
+	if c := conns[s.rand.Int(connCount)]; s.isOkConnection(c, allowBanned) {
 		return c, 0
 	}
 
@@ -120,7 +169,7 @@ func (s *connectionsState) selectRandomConnection(conns []conn.Conn, allowBanned
 
 	for _, index := range indexes {
 		c := conns[index]
-		if isOkConnection(c, allowBanned) {
+		if s.isOkConnection(c, allowBanned) {
 			return c, 0
 		}
 		failedConns++
@@ -167,6 +216,22 @@ func sortPreferConnections(
 	return prefer, fallback
 }
 
+// isOkConnection checks the state of the connection and the ejection of its endpoint. The ejected endpoints are
+// allowed with the banned ones, the endpoints in ramp up are allowed with the probability of their share
+func (s *connectionsState) isOkConnection(c conn.Conn, bannedIsOk bool) bool {
+	if !isOkConnection(c, bannedIsOk) {
+		return false
+	}
+
+	if bannedIsOk || s.outliers == nil {
+		return true
+	}
+
+	share := s.outliers.share(c)
+
+	return share >= 1 || (share > 0 && s.rand.Float64() < share)
+}
+
 func isOkConnection(c conn.Conn, bannedIsOk bool) bool {
 	switch c.GetState() {
 	case conn.Online, conn.Created, conn.Offline:
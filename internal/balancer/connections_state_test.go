@@ -158,7 +158,7 @@ func TestSortPreferConnections(t *testing.T) {
 }
 
 func TestSelectRandomConnection(t *testing.T) {
-	s := newConnectionsState(nil, nil, balancerConfig.Info{}, false)
+	s := newConnectionsState(nil, nil, balancerConfig.Info{}, false, nil, nil, nil)
 
 	t.Run("Empty", func(t *testing.T) {
 		c, failedCount := s.selectRandomConnection(nil, false)
@@ -250,7 +250,7 @@ func TestNewState(t *testing.T) {
 	}{
 		{
 			name:  "Empty",
-			state: newConnectionsState(nil, nil, balancerConfig.Info{}, false),
+			state: newConnectionsState(nil, nil, balancerConfig.Info{}, false, nil, nil, nil),
 			res: &connectionsState{
 				connByNodeID: nil,
 				prefer:       nil,
@@ -263,7 +263,7 @@ func TestNewState(t *testing.T) {
 			state: newConnectionsState([]conn.Conn{
 				&mock.Conn{AddrField: "1", NodeIDField: 1},
 				&mock.Conn{AddrField: "2", NodeIDField: 2},
-			}, nil, balancerConfig.Info{}, false),
+			}, nil, balancerConfig.Info{}, false, nil, nil, nil),
 			res: &connectionsState{
 				connByNodeID: map[uint32]conn.Conn{
 					1: &mock.Conn{AddrField: "1", NodeIDField: 1},
@@ -289,7 +289,7 @@ func TestNewState(t *testing.T) {
 				&mock.Conn{AddrField: "f2", NodeIDField: 4, LocationField: "f"},
 			}, filterFunc(func(info balancerConfig.Info, e endpoint.Info) bool {
 				return info.SelfLocation == e.Location()
-			}), balancerConfig.Info{SelfLocation: "t"}, false),
+			}), balancerConfig.Info{SelfLocation: "t"}, false, nil, nil, nil),
 			res: &connectionsState{
 				connByNodeID: map[uint32]conn.Conn{
 					1: &mock.Conn{AddrField: "t1", NodeIDField: 1, LocationField: "t"},
@@ -317,7 +317,7 @@ func TestNewState(t *testing.T) {
 				&mock.Conn{AddrField: "f2", NodeIDField: 4, LocationField: "f"},
 			}, filterFunc(func(info balancerConfig.Info, e endpoint.Info) bool {
 				return info.SelfLocation == e.Location()
-			}), balancerConfig.Info{SelfLocation: "t"}, true),
+			}), balancerConfig.Info{SelfLocation: "t"}, true, nil, nil, nil),
 			res: &connectionsState{
 				connByNodeID: map[uint32]conn.Conn{
 					1: &mock.Conn{AddrField: "t1", NodeIDField: 1, LocationField: "t"},
@@ -350,7 +350,7 @@ func TestNewState(t *testing.T) {
 				&mock.Conn{AddrField: "f2", NodeIDField: 4, LocationField: "f"},
 			}, filterFunc(func(info balancerConfig.Info, e endpoint.Info) bool {
 				return info.SelfLocation == e.Location()
-			}), balancerConfig.Info{SelfLocation: "t"}, true),
+			}), balancerConfig.Info{SelfLocation: "t"}, true, nil, nil, nil),
 			res: &connectionsState{
 				connByNodeID: map[uint32]conn.Conn{
 					1: &mock.Conn{AddrField: "t1", NodeIDField: 1, LocationField: "t"},
@@ -387,7 +387,7 @@ func TestNewState(t *testing.T) {
 
 func TestConnection(t *testing.T) {
 	t.Run("Empty", func(t *testing.T) {
-		s := newConnectionsState(nil, nil, balancerConfig.Info{}, false)
+		s := newConnectionsState(nil, nil, balancerConfig.Info{}, false, nil, nil, nil)
 		c, failed := s.GetConnection(context.Background())
 		require.Nil(t, c)
 		require.Equal(t, 0, failed)
@@ -396,7 +396,7 @@ func TestConnection(t *testing.T) {
 		s := newConnectionsState([]conn.Conn{
 			&mock.Conn{AddrField: "1", State: conn.Online},
 			&mock.Conn{AddrField: "2", State: conn.Online},
-		}, nil, balancerConfig.Info{}, false)
+		}, nil, balancerConfig.Info{}, false, nil, nil, nil)
 		c, failed := s.GetConnection(context.Background())
 		require.NotNil(t, c)
 		require.Equal(t, 0, failed)
@@ -405,7 +405,7 @@ func TestConnection(t *testing.T) {
 		s := newConnectionsState([]conn.Conn{
 			&mock.Conn{AddrField: "1", State: conn.Online},
 			&mock.Conn{AddrField: "2", State: conn.Banned},
-		}, nil, balancerConfig.Info{}, false)
+		}, nil, balancerConfig.Info{}, false, nil, nil, nil)
 		c, _ := s.GetConnection(context.Background())
 		require.Equal(t, &mock.Conn{AddrField: "1", State: conn.Online}, c)
 	})
@@ -415,7 +415,7 @@ func TestConnection(t *testing.T) {
 			&mock.Conn{AddrField: "f2", State: conn.Banned, LocationField: "f"},
 		}, filterFunc(func(info balancerConfig.Info, e endpoint.Info) bool {
 			return e.Location() == info.SelfLocation
-		}), balancerConfig.Info{}, true)
+		}), balancerConfig.Info{}, true, nil, nil, nil)
 		preferred := 0
 		fallback := 0
 		for i := 0; i < 100; i++ {
@@ -438,7 +438,7 @@ func TestConnection(t *testing.T) {
 			&mock.Conn{AddrField: "f2", State: conn.Online, LocationField: "f"},
 		}, filterFunc(func(info balancerConfig.Info, e endpoint.Info) bool {
 			return e.Location() == info.SelfLocation
-		}), balancerConfig.Info{SelfLocation: "t"}, true)
+		}), balancerConfig.Info{SelfLocation: "t"}, true, nil, nil, nil)
 		c, failed := s.GetConnection(context.Background())
 		require.Equal(t, &mock.Conn{AddrField: "f2", State: conn.Online, LocationField: "f"}, c)
 		require.Equal(t, 1, failed)
@@ -447,7 +447,7 @@ func TestConnection(t *testing.T) {
 		s := newConnectionsState([]conn.Conn{
 			&mock.Conn{AddrField: "1", State: conn.Online, NodeIDField: 1},
 			&mock.Conn{AddrField: "2", State: conn.Online, NodeIDField: 2},
-		}, nil, balancerConfig.Info{}, false)
+		}, nil, balancerConfig.Info{}, false, nil, nil, nil)
 		c, failed := s.GetConnection(endpoint.WithNodeID(context.Background(), 2))
 		require.Equal(t, &mock.Conn{AddrField: "2", State: conn.Online, NodeIDField: 2}, c)
 		require.Equal(t, 0, failed)
@@ -456,7 +456,7 @@ func TestConnection(t *testing.T) {
 		s := newConnectionsState([]conn.Conn{
 			&mock.Conn{AddrField: "1", State: conn.Online, NodeIDField: 1},
 			&mock.Conn{AddrField: "2", State: conn.Unknown, NodeIDField: 2},
-		}, nil, balancerConfig.Info{}, false)
+		}, nil, balancerConfig.Info{}, false, nil, nil, nil)
 		c, failed := s.GetConnection(endpoint.WithNodeID(context.Background(), 2))
 		require.Equal(t, &mock.Conn{AddrField: "1", State: conn.Online, NodeIDField: 1}, c)
 		require.Equal(t, 0, failed)
@@ -0,0 +1,112 @@
+package balancer
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+)
+
+// leastLatencyEWMAWeight is the weight of the latest sample in the moving average of the latency
+const leastLatencyEWMAWeight = 0.2
+
+type (
+	// latencyTracker keeps the moving average of the latency and the count of in-flight requests of the
+	// connections. The stats are kept by the address of the endpoint, so they survive the rediscovery.
+	latencyTracker struct {
+		mu    sync.RWMutex
+		stats map[string]*connLatency
+	}
+	connLatency struct {
+		inFlight atomic.Int64
+
+		mu   sync.Mutex
+		ewma float64 // nanoseconds, zero until the first sample
+	}
+)
+
+func newLatencyTracker() *latencyTracker {
+	return &latencyTracker{
+		stats: make(map[string]*connLatency),
+	}
+}
+
+func (t *latencyTracker) get(c conn.Conn) *connLatency {
+	address := c.Endpoint().Address()
+
+	t.mu.RLock()
+	stats, has := t.stats[address]
+	t.mu.RUnlock()
+	if has {
+		return stats
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if stats, has = t.stats[address]; !has {
+		stats = &connLatency{}
+		t.stats[address] = stats
+	}
+
+	return stats
+}
+
+// start counts the request to the connection in flight until the returned done is called. Only the latency of
+// the successful requests is observed: the failed requests may end fast and make the failing connection look cheap,
+// the failing connections are banned by the pool instead.
+func (t *latencyTracker) start(c conn.Conn) (done func(err error)) {
+	stats := t.get(c)
+	stats.inFlight.Add(1)
+	start := time.Now()
+
+	return func(err error) {
+		stats.inFlight.Add(-1)
+		if err == nil {
+			stats.observe(time.Since(start))
+		}
+	}
+}
+
+// cost estimates the latency of the next request to the connection with the requests in flight. The connections
+// without samples are cheap, so they are tried soon.
+func (t *latencyTracker) cost(c conn.Conn) float64 {
+	stats := t.get(c)
+
+	stats.mu.Lock()
+	ewma := stats.ewma
+	stats.mu.Unlock()
+
+	return (ewma + 1) * float64(stats.inFlight.Load()+1)
+}
+
+// retain drops the stats of the endpoints which are not in conns anymore
+func (t *latencyTracker) retain(conns []conn.Conn) {
+	addresses := make(map[string]struct{}, len(conns))
+	for _, c := range conns {
+		addresses[c.Endpoint().Address()] = struct{}{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for address := range t.stats {
+		if _, has := addresses[address]; !has {
+			delete(t.stats, address)
+		}
+	}
+}
+
+func (stats *connLatency) observe(latency time.Duration) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	if stats.ewma == 0 {
+		stats.ewma = float64(latency)
+
+		return
+	}
+
+	stats.ewma += leastLatencyEWMAWeight * (float64(latency) - stats.ewma)
+}
@@ -0,0 +1,95 @@
+package balancer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/mock"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xrand"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	t.Run("EWMA", func(t *testing.T) {
+		stats := &connLatency{}
+		stats.observe(100 * time.Millisecond)
+		require.InDelta(t, float64(100*time.Millisecond), stats.ewma, 1)
+
+		stats.observe(200 * time.Millisecond)
+		require.InDelta(t, float64(120*time.Millisecond), stats.ewma, 1)
+	})
+	t.Run("Cost", func(t *testing.T) {
+		tracker := newLatencyTracker()
+		fast := &mock.Conn{AddrField: "fast", State: conn.Online}
+		slow := &mock.Conn{AddrField: "slow", State: conn.Online}
+		tracker.get(fast).observe(time.Millisecond)
+		tracker.get(slow).observe(100 * time.Millisecond)
+		require.Less(t, tracker.cost(fast), tracker.cost(slow))
+
+		for i := 0; i < 200; i++ {
+			tracker.get(fast).inFlight.Add(1)
+		}
+		require.Greater(t, tracker.cost(fast), tracker.cost(slow), "the requests in flight make the connection expensive")
+
+		done := tracker.start(slow)
+		require.EqualValues(t, 1, tracker.get(slow).inFlight.Load())
+		done(nil)
+		require.EqualValues(t, 0, tracker.get(slow).inFlight.Load())
+		require.Less(t, tracker.get(slow).ewma, float64(100*time.Millisecond), "the fast answer is observed")
+	})
+	t.Run("FailedCalls", func(t *testing.T) {
+		tracker := newLatencyTracker()
+		failing := &mock.Conn{AddrField: "failing", State: conn.Online}
+		tracker.get(failing).observe(100 * time.Millisecond)
+
+		done := tracker.start(failing)
+		done(errors.New("failed"))
+		require.EqualValues(t, 0, tracker.get(failing).inFlight.Load())
+		require.InDelta(t, float64(100*time.Millisecond), tracker.get(failing).ewma, 1,
+			"the failed calls do not make the connection look cheap",
+		)
+	})
+	t.Run("Retain", func(t *testing.T) {
+		tracker := newLatencyTracker()
+		a := &mock.Conn{AddrField: "a"}
+		b := &mock.Conn{AddrField: "b"}
+		tracker.get(a).observe(time.Millisecond)
+		tracker.get(b).observe(time.Millisecond)
+
+		tracker.retain([]conn.Conn{b})
+		require.Len(t, tracker.stats, 1)
+		require.Contains(t, tracker.stats, "b")
+	})
+}
+
+func TestSelectLeastLatencyConnection(t *testing.T) {
+	tracker := newLatencyTracker()
+	fast := &mock.Conn{AddrField: "fast", State: conn.Online}
+	slow := &mock.Conn{AddrField: "slow", State: conn.Online}
+	tracker.get(fast).observe(time.Millisecond)
+	tracker.get(slow).observe(100 * time.Millisecond)
+
+	s := newConnectionsState([]conn.Conn{fast, slow}, nil, balancerConfig.Info{}, false, tracker, nil, nil)
+	s.rand = xrand.New(xrand.WithSeed(0))
+
+	picked := map[conn.Conn]int{}
+	for i := 0; i < 1000; i++ {
+		c, failed := s.GetConnection(context.Background())
+		require.Zero(t, failed)
+		picked[c]++
+	}
+	// the slow connection is picked only if both random choices are the slow one
+	require.Greater(t, picked[fast], 650)
+	require.Greater(t, picked[slow], 150)
+
+	slow.State = conn.Banned
+	for i := 0; i < 10; i++ {
+		c, _ := s.GetConnection(context.Background())
+		require.Equal(t, fast, c)
+	}
+}
@@ -0,0 +1,276 @@
+package balancer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stack"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// outlierMinRampUpShare is the share of the traffic of the endpoint right after the restoring
+const outlierMinRampUpShare = 0.1
+
+type (
+	// outlierDetector ejects the failing endpoints from the balancing, probes the ejected endpoints and
+	// the endpoints banned by the pool in background and restores the recovered endpoints with the gradual growth
+	// of the traffic. The stats are kept by the address of the endpoint, so they survive the rediscovery.
+	outlierDetector struct {
+		config    balancerConfig.OutlierDetection
+		clock     clockwork.Clock
+		trace     *trace.Driver
+		isBadConn func(err error) bool
+		probe     func(ctx context.Context, cc conn.Conn) error
+		allow     func(ctx context.Context, cc conn.Conn)
+
+		mu      sync.RWMutex
+		total   int
+		ejected int
+		stats   map[string]*outlierStats
+	}
+	outlierStats struct {
+		cc conn.Conn
+
+		consecutiveErrors int
+		windowStart       time.Time
+		requests          int
+		errors            int
+
+		ejected    bool
+		restoredAt time.Time // the start of the ramp up, zero if the endpoint is not ramped up
+	}
+)
+
+func newOutlierDetector(
+	config balancerConfig.OutlierDetection,
+	t *trace.Driver,
+	clock clockwork.Clock,
+	isBadConn func(err error) bool,
+	probe func(ctx context.Context, cc conn.Conn) error,
+	allow func(ctx context.Context, cc conn.Conn),
+) *outlierDetector {
+	return &outlierDetector{
+		config:    config,
+		clock:     clock,
+		trace:     t,
+		isBadConn: isBadConn,
+		probe:     probe,
+		allow:     allow,
+		stats:     make(map[string]*outlierStats),
+	}
+}
+
+// share returns the share of the traffic allowed to the connection: zero for the ejected endpoints, the growing
+// share for the endpoints in ramp up and one for the others
+func (d *outlierDetector) share(c conn.Conn) float64 {
+	if d == nil {
+		return 1
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	stats, has := d.stats[c.Endpoint().Address()]
+	if !has {
+		return 1
+	}
+
+	if stats.ejected {
+		return 0
+	}
+
+	if stats.restoredAt.IsZero() || d.config.RampUp <= 0 {
+		return 1
+	}
+
+	share := float64(d.clock.Since(stats.restoredAt)) / float64(d.config.RampUp)
+	if share >= 1 {
+		return 1
+	}
+
+	return max(share, outlierMinRampUpShare)
+}
+
+// report accounts the result of the call to the connection and ejects the endpoint if it is an outlier. The calls
+// canceled by the caller, such as the lost hedged requests, do not tell anything about the endpoint.
+func (d *outlierDetector) report(ctx context.Context, c conn.Conn, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	bad := err != nil && d.isBadConn(err)
+
+	ejected, ok := func() (int, bool) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		address := c.Endpoint().Address()
+		stats, has := d.stats[address]
+		if !has {
+			stats = &outlierStats{
+				cc:          c,
+				windowStart: d.clock.Now(),
+			}
+			d.stats[address] = stats
+		}
+
+		if stats.ejected {
+			// the ejected endpoints are used only if there are no other endpoints, they are restored by the probes
+			return 0, false
+		}
+
+		if d.config.Interval > 0 && d.clock.Since(stats.windowStart) >= d.config.Interval {
+			stats.windowStart = d.clock.Now()
+			stats.requests, stats.errors = 0, 0
+		}
+
+		stats.requests++
+		if bad {
+			stats.errors++
+			stats.consecutiveErrors++
+		} else {
+			stats.consecutiveErrors = 0
+		}
+
+		if !bad || !d.isOutlier(stats) || float64(d.ejected+1) > float64(d.total)*d.config.MaxEjectedRatio {
+			return 0, false
+		}
+
+		stats.cc = c
+		stats.ejected = true
+		stats.restoredAt = time.Time{}
+		stats.consecutiveErrors, stats.requests, stats.errors = 0, 0, 0
+		d.ejected++
+
+		return d.ejected, true
+	}()
+	if ok {
+		trace.DriverOnBalancerEject(d.trace, ctx,
+			stack.FunctionID("github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer.(*outlierDetector).report"),
+			c.Endpoint().Copy(), err, ejected,
+		)
+	}
+}
+
+func (d *outlierDetector) isOutlier(stats *outlierStats) bool {
+	if d.config.ConsecutiveErrors > 0 && stats.consecutiveErrors >= d.config.ConsecutiveErrors {
+		return true
+	}
+
+	return d.config.ErrorRate > 0 && stats.requests >= d.config.MinRequests &&
+		float64(stats.errors) >= float64(stats.requests)*d.config.ErrorRate
+}
+
+// probeEjected probes the ejected endpoints and the endpoints banned by the pool and restores the recovered ones
+func (d *outlierDetector) probeEjected(ctx context.Context) error {
+	d.mu.RLock()
+	ejected := make([]conn.Conn, 0, d.ejected)
+	for _, stats := range d.stats {
+		if stats.ejected || stats.cc.GetState() == conn.Banned {
+			ejected = append(ejected, stats.cc)
+		}
+	}
+	d.mu.RUnlock()
+
+	for _, c := range ejected {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if d.probeEndpoint(ctx, c) == nil {
+			d.restore(ctx, c)
+		}
+	}
+
+	return nil
+}
+
+func (d *outlierDetector) probeEndpoint(ctx context.Context, c conn.Conn) (err error) {
+	onDone := trace.DriverOnBalancerProbe(d.trace, &ctx,
+		stack.FunctionID("github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer.(*outlierDetector).probeEndpoint"),
+		c.Endpoint().Copy(),
+	)
+	defer func() {
+		onDone(err)
+	}()
+
+	if d.config.ProbeInterval > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = xcontext.WithTimeout(ctx, d.config.ProbeInterval)
+		defer cancel()
+	}
+
+	return d.probe(ctx, c)
+}
+
+// restore returns the endpoint to the balancing, the endpoint banned by the pool is allowed
+func (d *outlierDetector) restore(ctx context.Context, c conn.Conn) {
+	banned := c.GetState() == conn.Banned
+
+	ejected, ok := func() (int, bool) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		stats, has := d.stats[c.Endpoint().Address()]
+		if !has || (!stats.ejected && !banned) {
+			return 0, false
+		}
+
+		if stats.ejected {
+			stats.ejected = false
+			d.ejected--
+		}
+		stats.restoredAt = d.clock.Now()
+		stats.windowStart = stats.restoredAt
+		stats.consecutiveErrors, stats.requests, stats.errors = 0, 0, 0
+
+		return d.ejected, true
+	}()
+	if banned {
+		d.allow(ctx, c)
+	}
+	if ok {
+		trace.DriverOnBalancerRestore(d.trace, ctx,
+			stack.FunctionID("github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer.(*outlierDetector).restore"),
+			c.Endpoint().Copy(), ejected,
+		)
+	}
+}
+
+// retain keeps the stats of the endpoints of conns and drops the stats of the endpoints which are not in conns
+// anymore
+func (d *outlierDetector) retain(conns []conn.Conn) {
+	addresses := make(map[string]struct{}, len(conns))
+	for _, c := range conns {
+		addresses[c.Endpoint().Address()] = struct{}{}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.total = len(conns)
+	for _, c := range conns {
+		if stats, has := d.stats[c.Endpoint().Address()]; has {
+			stats.cc = c
+		} else {
+			d.stats[c.Endpoint().Address()] = &outlierStats{
+				cc:          c,
+				windowStart: d.clock.Now(),
+			}
+		}
+	}
+	for address, stats := range d.stats {
+		if _, has := addresses[address]; !has {
+			if stats.ejected {
+				d.ejected--
+			}
+			delete(d.stats, address)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package balancer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/mock"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xrand"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+var errBadConn = errors.New("bad conn")
+
+func newTestOutlierDetector(
+	config balancerConfig.OutlierDetection, t *trace.Driver, clock clockwork.Clock, probeErr *error,
+	conns ...conn.Conn,
+) *outlierDetector {
+	d := newOutlierDetector(config, t, clock,
+		func(err error) bool {
+			return errors.Is(err, errBadConn)
+		},
+		func(ctx context.Context, cc conn.Conn) error {
+			return *probeErr
+		},
+		func(ctx context.Context, cc conn.Conn) {
+			cc.Unban(ctx)
+		},
+	)
+	d.retain(conns)
+
+	return d
+}
+
+func TestOutlierDetector(t *testing.T) {
+	config := balancerConfig.OutlierDetection{
+		ConsecutiveErrors: 3,
+		ErrorRate:         0.5,
+		MinRequests:       10,
+		Interval:          10 * time.Second,
+		ProbeInterval:     time.Second,
+		RampUp:            10 * time.Second,
+		MaxEjectedRatio:   0.5,
+	}
+	t.Run("ConsecutiveErrors", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		a := &mock.Conn{AddrField: "a", State: conn.Online}
+		b := &mock.Conn{AddrField: "b", State: conn.Online}
+		var ejected []trace.DriverBalancerEjectInfo
+		var probeErr error
+		d := newTestOutlierDetector(config, &trace.Driver{
+			OnBalancerEject: func(info trace.DriverBalancerEjectInfo) {
+				ejected = append(ejected, info)
+			},
+		}, clockwork.NewFakeClock(), &probeErr, a, b)
+
+		d.report(ctx, a, errBadConn)
+		d.report(ctx, a, errBadConn)
+		d.report(ctx, a, errors.New("operation error"))
+		d.report(ctx, a, errBadConn)
+		d.report(ctx, a, errBadConn)
+		require.EqualValues(t, 1, d.share(a), "the consecutive errors are reset by the good answer")
+
+		d.report(ctx, a, errBadConn)
+		require.Zero(t, d.share(a))
+		require.Len(t, ejected, 1)
+		require.Equal(t, "a", ejected[0].Endpoint.Address())
+		require.ErrorIs(t, ejected[0].Cause, errBadConn)
+		require.Equal(t, 1, ejected[0].Ejected)
+
+		for i := 0; i < 3; i++ {
+			d.report(ctx, b, errBadConn)
+		}
+		require.EqualValues(t, 1, d.share(b), "the half of the endpoints is ejected already")
+	})
+	t.Run("ErrorRate", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		a := &mock.Conn{AddrField: "a", State: conn.Online}
+		b := &mock.Conn{AddrField: "b", State: conn.Online}
+		clock := clockwork.NewFakeClock()
+		var probeErr error
+		d := newTestOutlierDetector(config, &trace.Driver{}, clock, &probeErr, a, b)
+
+		for i := 0; i < 4; i++ {
+			d.report(ctx, a, nil)
+			d.report(ctx, a, errBadConn)
+		}
+		require.EqualValues(t, 1, d.share(a), "not enough requests")
+
+		clock.Advance(config.Interval)
+		d.report(ctx, a, errBadConn)
+		require.EqualValues(t, 1, d.share(a), "the window of the error rate is reset")
+
+		for i := 0; i < 5; i++ {
+			d.report(ctx, a, nil)
+			d.report(ctx, a, errBadConn)
+		}
+		require.Zero(t, d.share(a))
+	})
+	t.Run("ProbeAndRampUp", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		a := &mock.Conn{AddrField: "a", State: conn.Online}
+		b := &mock.Conn{AddrField: "b", State: conn.Online}
+		clock := clockwork.NewFakeClock()
+		var (
+			probed   []string
+			restored []trace.DriverBalancerRestoreInfo
+		)
+		probeErr := errBadConn
+		d := newTestOutlierDetector(config, &trace.Driver{
+			OnBalancerProbe: func(info trace.DriverBalancerProbeStartInfo) func(trace.DriverBalancerProbeDoneInfo) {
+				probed = append(probed, info.Endpoint.Address())
+
+				return nil
+			},
+			OnBalancerRestore: func(info trace.DriverBalancerRestoreInfo) {
+				restored = append(restored, info)
+			},
+		}, clock, &probeErr, a, b)
+
+		for i := 0; i < 3; i++ {
+			d.report(ctx, a, errBadConn)
+		}
+		require.Zero(t, d.share(a))
+
+		require.NoError(t, d.probeEjected(ctx))
+		require.Equal(t, []string{"a"}, probed)
+		require.Zero(t, d.share(a), "the failed probe keeps the endpoint ejected")
+
+		probeErr = nil
+		require.NoError(t, d.probeEjected(ctx))
+		require.Equal(t, []string{"a", "a"}, probed)
+		require.Len(t, restored, 1)
+		require.Equal(t, 0, restored[0].Ejected)
+
+		require.InDelta(t, outlierMinRampUpShare, d.share(a), 1e-9)
+		clock.Advance(config.RampUp / 2)
+		require.InDelta(t, 0.5, d.share(a), 1e-9)
+		clock.Advance(config.RampUp / 2)
+		require.EqualValues(t, 1, d.share(a))
+
+		require.NoError(t, d.probeEjected(ctx))
+		require.Len(t, probed, 2, "there are no ejected endpoints")
+	})
+	t.Run("CanceledCalls", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(xtest.Context(t))
+		cancel()
+		a := &mock.Conn{AddrField: "a", State: conn.Online}
+		b := &mock.Conn{AddrField: "b", State: conn.Online}
+		var probeErr error
+		d := newTestOutlierDetector(config, &trace.Driver{}, clockwork.NewFakeClock(), &probeErr, a, b)
+
+		for i := 0; i < 3; i++ {
+			d.report(ctx, a, errBadConn)
+		}
+		require.EqualValues(t, 1, d.share(a), "the calls canceled by the caller are not counted")
+	})
+	t.Run("ProbeBanned", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		a := &mock.Conn{AddrField: "a", State: conn.Banned}
+		b := &mock.Conn{AddrField: "b", State: conn.Online}
+		var (
+			probed   []string
+			restored []trace.DriverBalancerRestoreInfo
+		)
+		probeErr := errBadConn
+		d := newTestOutlierDetector(config, &trace.Driver{
+			OnBalancerProbe: func(info trace.DriverBalancerProbeStartInfo) func(trace.DriverBalancerProbeDoneInfo) {
+				probed = append(probed, info.Endpoint.Address())
+
+				return nil
+			},
+			OnBalancerRestore: func(info trace.DriverBalancerRestoreInfo) {
+				restored = append(restored, info)
+			},
+		}, clockwork.NewFakeClock(), &probeErr, a, b)
+
+		require.NoError(t, d.probeEjected(ctx))
+		require.Equal(t, []string{"a"}, probed, "the endpoint banned by the pool is probed")
+		require.Equal(t, conn.Banned, a.GetState())
+
+		probeErr = nil
+		require.NoError(t, d.probeEjected(ctx))
+		require.Equal(t, conn.Online, a.GetState(), "the recovered endpoint is allowed in the pool")
+		require.Len(t, restored, 1)
+		require.InDelta(t, outlierMinRampUpShare, d.share(a), 1e-9)
+	})
+	t.Run("Retain", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		a := &mock.Conn{AddrField: "a", State: conn.Online}
+		b := &mock.Conn{AddrField: "b", State: conn.Online}
+		var probeErr error
+		d := newTestOutlierDetector(config, &trace.Driver{}, clockwork.NewFakeClock(), &probeErr, a, b)
+
+		for i := 0; i < 3; i++ {
+			d.report(ctx, a, errBadConn)
+		}
+		require.Equal(t, 1, d.ejected)
+
+		d.retain([]conn.Conn{b})
+		require.Equal(t, 0, d.ejected)
+		require.NotContains(t, d.stats, "a")
+	})
+}
+
+func TestSelectConnectionWithOutliers(t *testing.T) {
+	ctx := xtest.Context(t)
+	a := &mock.Conn{AddrField: "a", State: conn.Online}
+	b := &mock.Conn{AddrField: "b", State: conn.Online}
+	var probeErr error
+	d := newTestOutlierDetector(balancerConfig.OutlierDetection{
+		ConsecutiveErrors: 1,
+		MaxEjectedRatio:   1,
+	}, &trace.Driver{}, clockwork.NewFakeClock(), &probeErr, a, b)
+	d.report(ctx, a, errBadConn)
+
+	for _, s := range []*connectionsState{
+		newConnectionsState([]conn.Conn{a, b}, nil, balancerConfig.Info{}, false, nil, nil, d),
+		newConnectionsState([]conn.Conn{a, b}, nil, balancerConfig.Info{}, false, newLatencyTracker(), nil, d),
+		newConnectionsState([]conn.Conn{a, b}, nil, balancerConfig.Info{}, false, nil,
+			map[string]float64{"a": 1, "b": 1}, d,
+		),
+	} {
+		s.rand = xrand.New(xrand.WithSeed(0))
+		for i := 0; i < 100; i++ {
+			c, _ := s.GetConnection(ctx)
+			require.Equal(t, b, c, "the ejected endpoint is not chosen")
+		}
+	}
+
+	d.report(ctx, b, errBadConn)
+	s := newConnectionsState([]conn.Conn{a, b}, nil, balancerConfig.Info{}, false, nil, nil, d)
+	c, _ := s.GetConnection(ctx)
+	require.NotNil(t, c, "the ejected endpoints are used if there are no other endpoints")
+}
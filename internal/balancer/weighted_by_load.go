@@ -0,0 +1,89 @@
+package balancer
+
+import (
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+)
+
+// weightedByLoadMinWeight keeps a little traffic on the fully loaded nodes, so they are not starved
+const weightedByLoadMinWeight = 0.01
+
+// loadWeights returns the weights of the endpoints by address. The weights are derived from the load factors of
+// the discovered endpoints, because the connections of the pool keep the endpoints of the first discovery
+func loadWeights(endpoints []endpoint.Endpoint, locationWeights map[string]float64) map[string]float64 {
+	weights := make(map[string]float64, len(endpoints))
+	for _, e := range endpoints {
+		weight := loadWeight(e.LoadFactor())
+		if locationWeight, has := locationWeights[e.Location()]; has {
+			weight *= locationWeight
+		}
+		weights[e.Address()] = weight
+	}
+
+	return weights
+}
+
+func loadWeight(loadFactor float32) float64 {
+	weight := 1 - float64(loadFactor)
+	if weight < weightedByLoadMinWeight {
+		return weightedByLoadMinWeight
+	}
+
+	return min(weight, 1)
+}
+
+// selectWeightedConnection chooses the connection at random proportionally to its weight. The connections with
+// zero weight are chosen only if all of the allowed connections have zero weight
+func (s *connectionsState) selectWeightedConnection(conns []conn.Conn, allowBanned bool) (
+	c conn.Conn, failedConns int,
+) {
+	var total float64
+	for _, c := range conns {
+		if !isOkConnection(c, allowBanned) {
+			failedConns++
+
+			continue
+		}
+		total += s.weight(c)
+	}
+
+	if total <= 0 {
+		c, _ = s.selectRandomConnection(conns, allowBanned)
+
+		return c, failedConns
+	}
+
+	point := s.rand.Float64() * total
+	for _, c := range conns {
+		if !isOkConnection(c, allowBanned) {
+			continue
+		}
+		weight := s.weight(c)
+		if weight <= 0 {
+			continue
+		}
+		if point < weight {
+			return c, failedConns
+		}
+		point -= weight
+	}
+
+	// the last allowed connection takes the rounding error of the point
+	for i := len(conns) - 1; i >= 0; i-- {
+		if isOkConnection(conns[i], allowBanned) && s.weight(conns[i]) > 0 {
+			return conns[i], failedConns
+		}
+	}
+
+	return nil, failedConns
+}
+
+// weight returns the weight of the connection reduced by the share of the traffic allowed by the outlier detection
+func (s *connectionsState) weight(c conn.Conn) float64 {
+	weight := s.weights[c.Endpoint().Address()]
+	if s.outliers != nil {
+		weight *= s.outliers.share(c)
+	}
+
+	return weight
+}
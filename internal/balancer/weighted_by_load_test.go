@@ -0,0 +1,62 @@
+package balancer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/mock"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xrand"
+)
+
+func TestLoadWeights(t *testing.T) {
+	weights := loadWeights([]endpoint.Endpoint{
+		endpoint.New("a", endpoint.WithLocation("A"), endpoint.WithLoadFactor(0.25)),
+		endpoint.New("b", endpoint.WithLocation("B"), endpoint.WithLoadFactor(0.5)),
+		endpoint.New("c", endpoint.WithLocation("C"), endpoint.WithLoadFactor(1.5)),
+		endpoint.New("d", endpoint.WithLocation("A"), endpoint.WithLoadFactor(-1)),
+	}, map[string]float64{
+		"A": 2,
+	})
+	require.InDeltaMapValues(t, map[string]float64{
+		"a": 1.5,
+		"b": 0.5,
+		"c": weightedByLoadMinWeight,
+		"d": 2,
+	}, weights, 1e-9)
+}
+
+func TestSelectWeightedConnection(t *testing.T) {
+	cold := &mock.Conn{AddrField: "cold", State: conn.Online}
+	hot := &mock.Conn{AddrField: "hot", State: conn.Online}
+	off := &mock.Conn{AddrField: "off", State: conn.Online}
+
+	s := newConnectionsState([]conn.Conn{cold, hot, off}, nil, balancerConfig.Info{}, false, nil,
+		map[string]float64{
+			"cold": 0.9,
+			"hot":  0.1,
+			"off":  0,
+		}, nil,
+	)
+	s.rand = xrand.New(xrand.WithSeed(0))
+
+	picked := map[conn.Conn]int{}
+	for i := 0; i < 1000; i++ {
+		c, failed := s.GetConnection(context.Background())
+		require.Zero(t, failed)
+		picked[c]++
+	}
+	require.Greater(t, picked[cold], 850)
+	require.Greater(t, picked[hot], 50)
+	require.Zero(t, picked[off], "the connection with zero weight is not chosen")
+
+	cold.State = conn.Banned
+	hot.State = conn.Banned
+	c, failed := s.GetConnection(context.Background())
+	require.Equal(t, 2, failed)
+	require.Equal(t, off, c, "all of the allowed connections have zero weight")
+}
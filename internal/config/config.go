@@ -3,6 +3,7 @@ package config
 import (
 	"time"
 
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/retry/budget"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
@@ -15,6 +16,7 @@ type Common struct {
 	disableAutoRetry     bool
 	traceRetry           trace.Retry
 	retryBudget          budget.Budget
+	retryErrorClassifier retry.ErrorClassifier
 
 	panicCallback func(e interface{})
 }
@@ -60,6 +62,12 @@ func (c *Common) RetryBudget() budget.Budget {
 	return c.retryBudget
 }
 
+// RetryErrorClassifier returns the classifier of the errors for the retryers of the clients
+// If nil - only the built-in rules are used
+func (c *Common) RetryErrorClassifier() retry.ErrorClassifier {
+	return c.retryErrorClassifier
+}
+
 // SetOperationTimeout define the maximum amount of time a YDB server will process
 // an operation. After timeout exceeds YDB will try to cancel operation and
 // regardless of the cancellation appropriate error will be returned to
@@ -97,3 +105,7 @@ func SetTraceRetry(c *Common, t *trace.Retry, opts ...trace.RetryComposeOption)
 func SetRetryBudget(c *Common, b budget.Budget) {
 	c.retryBudget = b
 }
+
+func SetRetryErrorClassifier(c *Common, classifier retry.ErrorClassifier) {
+	c.retryErrorClassifier = classifier
+}
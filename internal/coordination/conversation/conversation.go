@@ -120,6 +120,7 @@ type Conversation struct {
 	message           func() *Ydb_Coordination.SessionRequest
 	responseFilter    ResponseFilter
 	acknowledgeFilter ResponseFilter
+	acknowledgeFunc   func(response *Ydb_Coordination.SessionResponse)
 	cancelMessage     func(req *Ydb_Coordination.SessionRequest) *Ydb_Coordination.SessionRequest
 	cancelFilter      ResponseFilter
 	conflictKey       string
@@ -158,6 +159,15 @@ func WithAcknowledgeFilter(filter ResponseFilter) Option {
 	}
 }
 
+// WithAcknowledgeHandler returns an Option that specifies the function that is called for every intermediate response
+// message detected by the acknowledge filter (see WithAcknowledgeFilter). The handler is called while the controller
+// holds its lock, so it must not block or call the controller methods.
+func WithAcknowledgeHandler(handler func(response *Ydb_Coordination.SessionResponse)) Option {
+	return func(c *Conversation) {
+		c.acknowledgeFunc = handler
+	}
+}
+
 // WithCancelMessage returns an Option that specifies the message and filter functions that are used to cancel the
 // conversation which has been already sent. This message is sent in the background when the caller cancels the context
 // of the Controller.Await function. The response is never received by the caller and is only used to end the
@@ -343,6 +353,9 @@ func (c *Controller) OnRecv(resp *Ydb_Coordination.SessionResponse) bool {
 			handled = true
 		case req.acknowledgeFilter != nil && req.acknowledgeFilter(req.requestSent, resp):
 			if !req.canceled {
+				if req.acknowledgeFunc != nil {
+					req.acknowledgeFunc(resp)
+				}
 				if req.conflictKey != "" {
 					delete(c.conflicts, req.conflictKey)
 					notify = true
@@ -44,6 +44,9 @@ type (
 		lastGoodResponseTime time.Time
 		cancelStream         context.CancelFunc
 
+		watchesMutex sync.Mutex // guards the field below
+		watches      map[string]*semaphoreWatch
+
 		onCreate []func(s *session)
 		onClose  []func(s *session)
 	}
@@ -71,6 +74,7 @@ func createSession(
 		cancel:            cancel,
 		sessionClosedChan: make(chan struct{}),
 		controller:        conversation.NewController(),
+		watches:           make(map[string]*semaphoreWatch),
 	}
 
 	for _, opt := range opts {
@@ -468,6 +472,11 @@ func (s *session) receiveLoop( //nolint:funlen
 			s.updateLastGoodResponseTime()
 		case *Ydb_Coordination.SessionResponse_Pong:
 			// Ignore pongs since we do not ping the server.
+		case *Ydb_Coordination.SessionResponse_DescribeSemaphoreChanged_:
+			// The server may notify about changes of a watch which has been already canceled or replaced, do not
+			// reconnect if the notification is not from any known conversation.
+			s.controller.OnRecv(message)
+			s.updateLastGoodResponseTime()
 		default:
 			if !s.controller.OnRecv(message) {
 				// Reconnect if the message is not from any known conversation.
@@ -717,6 +726,10 @@ func (s *session) DescribeSemaphore(
 	}
 
 	resp, err := s.controller.Await(ctx, req)
+
+	// The describe request without the watch flags replaces the server watch of the semaphore.
+	s.rearmSemaphoreWatch(name)
+
 	if err != nil {
 		return nil, err
 	}
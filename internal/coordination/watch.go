@@ -0,0 +1,319 @@
+package coordination
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Coordination"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination"
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/coordination/conversation"
+)
+
+// semaphoreWatch is the only server watch of a semaphore in the session. The server keeps at most one watch per
+// semaphore for a session, so all the watchers of the same semaphore share it.
+type semaphoreWatch struct {
+	session *session
+	name    string
+	ctx     context.Context //nolint:containedctx
+	cancel  context.CancelFunc
+	rearm   chan struct{}
+
+	mutex    sync.Mutex // guards the fields below
+	watchers map[*semaphoreWatcher]struct{}
+	last     *coordination.SemaphoreDescription
+}
+
+type semaphoreWatcher struct {
+	ch          chan *coordination.SemaphoreDescription
+	watchData   bool
+	watchOwners bool
+}
+
+func (s *session) WatchSemaphore(
+	ctx context.Context,
+	name string,
+	opts ...options.WatchSemaphoreOption,
+) (<-chan *coordination.SemaphoreDescription, error) {
+	cfg := options.WatchSemaphoreOptions{
+		WatchData:   true,
+		WatchOwners: true,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+
+	if s.ctx.Err() != nil {
+		return nil, coordination.ErrSessionClosed
+	}
+
+	watcher := &semaphoreWatcher{
+		ch:          make(chan *coordination.SemaphoreDescription, 1),
+		watchData:   cfg.WatchData,
+		watchOwners: cfg.WatchOwners,
+	}
+	watch := s.subscribeSemaphoreWatch(name, watcher)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-watch.ctx.Done():
+		}
+		s.unsubscribeSemaphoreWatch(watch, watcher)
+	}()
+
+	return watcher.ch, nil
+}
+
+func (s *session) subscribeSemaphoreWatch(name string, watcher *semaphoreWatcher) *semaphoreWatch {
+	s.watchesMutex.Lock()
+	defer s.watchesMutex.Unlock()
+
+	watch, ok := s.watches[name]
+	if !ok {
+		ctx, cancel := context.WithCancel(s.ctx)
+		watch = &semaphoreWatch{
+			session:  s,
+			name:     name,
+			ctx:      ctx,
+			cancel:   cancel,
+			rearm:    make(chan struct{}, 1),
+			watchers: make(map[*semaphoreWatcher]struct{}),
+		}
+		s.watches[name] = watch
+
+		go watch.run()
+	}
+
+	watch.mutex.Lock()
+	defer watch.mutex.Unlock()
+
+	watch.watchers[watcher] = struct{}{}
+	if watch.last != nil {
+		watcher.send(watch.last)
+	}
+
+	return watch
+}
+
+func (s *session) unsubscribeSemaphoreWatch(watch *semaphoreWatch, watcher *semaphoreWatcher) {
+	s.watchesMutex.Lock()
+	defer s.watchesMutex.Unlock()
+
+	watch.mutex.Lock()
+	defer watch.mutex.Unlock()
+
+	delete(watch.watchers, watcher)
+	close(watcher.ch)
+
+	if len(watch.watchers) == 0 {
+		watch.cancel()
+		if s.watches[watch.name] == watch {
+			delete(s.watches, watch.name)
+		}
+	}
+}
+
+// rearmSemaphoreWatch makes the watch of the semaphore, if any, re-issue the describe request with the watch flags.
+// Describing the semaphore without the watch flags replaces the server watch, so it must be called after every plain
+// describe of the semaphore.
+func (s *session) rearmSemaphoreWatch(name string) {
+	s.watchesMutex.Lock()
+	defer s.watchesMutex.Unlock()
+
+	if watch, ok := s.watches[name]; ok {
+		select {
+		case watch.rearm <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *session) removeSemaphoreWatch(watch *semaphoreWatch) {
+	s.watchesMutex.Lock()
+	defer s.watchesMutex.Unlock()
+
+	watch.cancel()
+	if s.watches[watch.name] == watch {
+		delete(s.watches, watch.name)
+	}
+}
+
+// run keeps the server watch armed until there are no watchers left or the session is closed. The watch is re-armed
+// after every change notification and after every plain describe of the semaphore in the session. If the underlying
+// stream is reconnected, the controller replays the describe request which re-arms the watch in the new stream.
+func (w *semaphoreWatch) run() {
+	defer w.session.removeSemaphoreWatch(w)
+
+	dataChanged, ownersChanged := true, true
+	for {
+		changedData, changedOwners := dataChanged, ownersChanged
+		resp, rearmed, err := w.describe(func(desc *coordination.SemaphoreDescription) {
+			w.notify(desc, changedData, changedOwners)
+			// The request may be replayed after reconnect, all the changes made meanwhile are unknown.
+			changedData, changedOwners = true, true
+		})
+		if rearmed {
+			// The server watch has been replaced by a plain describe, the changes made meanwhile are unknown.
+			dataChanged, ownersChanged = true, true
+
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		if changed := resp.GetDescribeSemaphoreChanged(); changed != nil {
+			dataChanged, ownersChanged = changed.GetDataChanged(), changed.GetOwnersChanged()
+
+			continue
+		}
+		dataChanged, ownersChanged = true, true
+
+		// The server has not added the watch, usually because the semaphore does not exist. Check it again later.
+		w.notify(convertSemaphoreDescription(resp.GetDescribeSemaphoreResult().GetSemaphoreDescription()), true, true)
+		select {
+		case <-time.After(w.session.sessionReconnectDelay):
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// describe describes the semaphore with the server watch until the watch is triggered or a re-arm is requested. In
+// the latter case the describe request is canceled and rearmed is true.
+func (w *semaphoreWatch) describe(onDescribe func(desc *coordination.SemaphoreDescription)) (
+	resp *Ydb_Coordination.SessionResponse, rearmed bool, err error,
+) {
+	// The plain describes completed so far are handled by the server before the new describe request.
+	select {
+	case <-w.rearm:
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(w.ctx)
+	defer cancel()
+
+	canceled := make(chan struct{})
+	go func() {
+		select {
+		case <-w.rearm:
+			close(canceled)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	resp, err = w.session.describeSemaphoreWithWatch(ctx, w.name, onDescribe)
+	if err != nil && w.ctx.Err() == nil {
+		select {
+		case <-canceled:
+			return nil, true, nil
+		default:
+		}
+	}
+
+	return resp, false, err
+}
+
+func (w *semaphoreWatch) notify(desc *coordination.SemaphoreDescription, dataChanged, ownersChanged bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if reflect.DeepEqual(desc, w.last) {
+		return
+	}
+	w.last = desc
+
+	for watcher := range w.watchers {
+		if (dataChanged && watcher.watchData) || (ownersChanged && watcher.watchOwners) {
+			watcher.send(desc)
+		}
+	}
+}
+
+// send replaces the description which has not been received by the watcher yet, if any. It must be called with the
+// watch mutex held.
+func (w *semaphoreWatcher) send(desc *coordination.SemaphoreDescription) {
+	select {
+	case <-w.ch:
+	default:
+	}
+	w.ch <- desc
+}
+
+// describeSemaphoreWithWatch describes the semaphore and adds the server watch for it. The onDescribe function is
+// called with the semaphore description every time the server replies to the describe request. The returned response
+// is either the change notification or the describe result if the server has not added the watch.
+func (s *session) describeSemaphoreWithWatch(
+	ctx context.Context,
+	name string,
+	onDescribe func(desc *coordination.SemaphoreDescription),
+) (*Ydb_Coordination.SessionResponse, error) {
+	req := conversation.NewConversation(
+		func() *Ydb_Coordination.SessionRequest {
+			return &Ydb_Coordination.SessionRequest{
+				Request: &Ydb_Coordination.SessionRequest_DescribeSemaphore_{
+					DescribeSemaphore: &Ydb_Coordination.SessionRequest_DescribeSemaphore{
+						ReqId:          newReqID(),
+						Name:           name,
+						IncludeOwners:  true,
+						IncludeWaiters: true,
+						WatchData:      true,
+						WatchOwners:    true,
+					},
+				},
+			}
+		},
+		conversation.WithResponseFilter(func(
+			request *Ydb_Coordination.SessionRequest,
+			response *Ydb_Coordination.SessionResponse,
+		) bool {
+			reqID := request.GetDescribeSemaphore().GetReqId()
+
+			return response.GetDescribeSemaphoreChanged().GetReqId() == reqID ||
+				response.GetDescribeSemaphoreResult().GetReqId() == reqID &&
+					!response.GetDescribeSemaphoreResult().GetWatchAdded()
+		}),
+		conversation.WithAcknowledgeFilter(func(
+			request *Ydb_Coordination.SessionRequest,
+			response *Ydb_Coordination.SessionResponse,
+		) bool {
+			return response.GetDescribeSemaphoreResult().GetReqId() == request.GetDescribeSemaphore().GetReqId()
+		}),
+		conversation.WithAcknowledgeHandler(func(response *Ydb_Coordination.SessionResponse) {
+			onDescribe(convertSemaphoreDescription(response.GetDescribeSemaphoreResult().GetSemaphoreDescription()))
+		}),
+		conversation.WithCancelMessage(
+			func(request *Ydb_Coordination.SessionRequest) *Ydb_Coordination.SessionRequest {
+				// Describing the semaphore without watch flags replaces the watch on the server.
+				return &Ydb_Coordination.SessionRequest{
+					Request: &Ydb_Coordination.SessionRequest_DescribeSemaphore_{
+						DescribeSemaphore: &Ydb_Coordination.SessionRequest_DescribeSemaphore{
+							ReqId: newReqID(),
+							Name:  name,
+						},
+					},
+				}
+			},
+			func(
+				request *Ydb_Coordination.SessionRequest,
+				response *Ydb_Coordination.SessionResponse,
+			) bool {
+				return response.GetDescribeSemaphoreResult().GetReqId() == request.GetDescribeSemaphore().GetReqId()
+			},
+		),
+		conversation.WithConflictKey(name),
+		conversation.WithIdempotence(true),
+	)
+	if err := s.controller.PushBack(req); err != nil {
+		return nil, err
+	}
+
+	return s.controller.Await(ctx, req)
+}
@@ -0,0 +1,374 @@
+package coordination
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Coordination_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Coordination"
+	"google.golang.org/grpc"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination"
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+type testSessionClient struct {
+	Ydb_Coordination_V1.CoordinationServiceClient
+
+	streams chan *testSessionStream
+}
+
+func (c *testSessionClient) Session(
+	ctx context.Context,
+	_ ...grpc.CallOption,
+) (Ydb_Coordination_V1.CoordinationService_SessionClient, error) {
+	stream := &testSessionStream{
+		ctx:       ctx,
+		requests:  make(chan *Ydb_Coordination.SessionRequest, 100),
+		responses: make(chan *Ydb_Coordination.SessionResponse, 100),
+	}
+	c.streams <- stream
+
+	return stream, nil
+}
+
+type testSessionStream struct {
+	grpc.ClientStream
+
+	ctx       context.Context //nolint:containedctx
+	requests  chan *Ydb_Coordination.SessionRequest
+	responses chan *Ydb_Coordination.SessionResponse
+}
+
+func (s *testSessionStream) Send(req *Ydb_Coordination.SessionRequest) error {
+	select {
+	case s.requests <- req:
+		return nil
+	case <-s.ctx.Done():
+		return io.EOF
+	}
+}
+
+func (s *testSessionStream) Recv() (*Ydb_Coordination.SessionResponse, error) {
+	select {
+	case resp := <-s.responses:
+		return resp, nil
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+func (s *testSessionStream) CloseSend() error {
+	return nil
+}
+
+func (s *testSessionStream) expectRequest(t *testing.T) *Ydb_Coordination.SessionRequest {
+	t.Helper()
+
+	select {
+	case req := <-s.requests:
+		return req
+	case <-time.After(time.Second):
+		t.Fatal("no request from the session")
+	}
+
+	return nil
+}
+
+func (s *testSessionStream) start(t *testing.T, sessionID uint64) {
+	t.Helper()
+
+	req := s.expectRequest(t)
+	require.NotNil(t, req.GetSessionStart())
+	s.responses <- &Ydb_Coordination.SessionResponse{
+		Response: &Ydb_Coordination.SessionResponse_SessionStarted_{
+			SessionStarted: &Ydb_Coordination.SessionResponse_SessionStarted{SessionId: sessionID},
+		},
+	}
+}
+
+func (s *testSessionStream) expectDescribe(
+	t *testing.T,
+	name string,
+) *Ydb_Coordination.SessionRequest_DescribeSemaphore {
+	t.Helper()
+
+	req := s.expectRequest(t).GetDescribeSemaphore()
+	require.NotNil(t, req)
+	require.Equal(t, name, req.GetName())
+
+	return req
+}
+
+func (s *testSessionStream) describeResult(reqID uint64, watchAdded bool, desc *Ydb_Coordination.SemaphoreDescription) {
+	status := Ydb.StatusIds_SUCCESS
+	if desc == nil {
+		status = Ydb.StatusIds_NOT_FOUND
+	}
+
+	s.responses <- &Ydb_Coordination.SessionResponse{
+		Response: &Ydb_Coordination.SessionResponse_DescribeSemaphoreResult_{
+			DescribeSemaphoreResult: &Ydb_Coordination.SessionResponse_DescribeSemaphoreResult{
+				ReqId:                reqID,
+				Status:               status,
+				SemaphoreDescription: desc,
+				WatchAdded:           watchAdded,
+			},
+		},
+	}
+}
+
+func (s *testSessionStream) describeChanged(reqID uint64, dataChanged, ownersChanged bool) {
+	s.responses <- &Ydb_Coordination.SessionResponse{
+		Response: &Ydb_Coordination.SessionResponse_DescribeSemaphoreChanged_{
+			DescribeSemaphoreChanged: &Ydb_Coordination.SessionResponse_DescribeSemaphoreChanged{
+				ReqId:         reqID,
+				DataChanged:   dataChanged,
+				OwnersChanged: ownersChanged,
+			},
+		},
+	}
+}
+
+func newTestWatchSession(t *testing.T) (*session, *testSessionStream, *testSessionClient) {
+	t.Helper()
+
+	ctx := xtest.Context(t)
+	client := &testSessionClient{streams: make(chan *testSessionStream, 1)}
+
+	type result struct {
+		s   *session
+		err error
+	}
+	created := make(chan result, 1)
+	go func() {
+		s, err := createSession(ctx, client, "/local/node", applyToSession(&trace.Coordination{},
+			options.WithSessionReconnectDelay(time.Millisecond),
+		))
+		created <- result{s, err}
+	}()
+
+	stream := <-client.streams
+	stream.start(t, 42)
+
+	r := <-created
+	require.NoError(t, r.err)
+	t.Cleanup(func() {
+		r.s.cancel()
+	})
+
+	return r.s, stream, client
+}
+
+func expectDescription(t *testing.T, ch <-chan *coordination.SemaphoreDescription) *coordination.SemaphoreDescription {
+	t.Helper()
+
+	select {
+	case desc, ok := <-ch:
+		require.True(t, ok)
+
+		return desc
+	case <-time.After(time.Second):
+		t.Fatal("no semaphore description from the watch")
+	}
+
+	return nil
+}
+
+func TestWatchSemaphore(t *testing.T) {
+	t.Run("RearmAfterChange", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		s, stream, _ := newTestWatchSession(t)
+
+		descriptions, err := s.WatchSemaphore(ctx, "sem")
+		require.NoError(t, err)
+
+		req := stream.expectDescribe(t, "sem")
+		require.True(t, req.GetWatchData())
+		require.True(t, req.GetWatchOwners())
+		require.True(t, req.GetIncludeOwners())
+		require.True(t, req.GetIncludeWaiters())
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v1")})
+		require.Equal(t, []byte("v1"), expectDescription(t, descriptions).Data)
+
+		stream.describeChanged(req.GetReqId(), true, false)
+
+		req = stream.expectDescribe(t, "sem")
+		require.True(t, req.GetWatchData())
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v2")})
+		require.Equal(t, []byte("v2"), expectDescription(t, descriptions).Data)
+	})
+	t.Run("RearmAfterReconnect", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		s, stream, client := newTestWatchSession(t)
+
+		descriptions, err := s.WatchSemaphore(ctx, "sem")
+		require.NoError(t, err)
+
+		req := stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v1")})
+		require.Equal(t, []byte("v1"), expectDescription(t, descriptions).Data)
+
+		s.Reconnect()
+
+		stream = <-client.streams
+		stream.start(t, 42)
+
+		req = stream.expectDescribe(t, "sem")
+		require.True(t, req.GetWatchData())
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v2")})
+		require.Equal(t, []byte("v2"), expectDescription(t, descriptions).Data)
+	})
+	t.Run("RearmAfterDescribe", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		s, stream, _ := newTestWatchSession(t)
+
+		descriptions, err := s.WatchSemaphore(ctx, "sem")
+		require.NoError(t, err)
+
+		req := stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v1")})
+		require.Equal(t, []byte("v1"), expectDescription(t, descriptions).Data)
+
+		described := make(chan error, 1)
+		go func() {
+			_, err := s.DescribeSemaphore(ctx, "sem", options.WithDescribeOwners(true))
+			described <- err
+		}()
+
+		plainReq := stream.expectDescribe(t, "sem")
+		require.False(t, plainReq.GetWatchData())
+		require.False(t, plainReq.GetWatchOwners())
+		stream.describeResult(plainReq.GetReqId(), false, &Ydb_Coordination.SemaphoreDescription{Name: "sem"})
+		require.NoError(t, <-described)
+
+		// The plain describe has replaced the server watch, the client cancels the old watch and re-arms it.
+		cancelReq := stream.expectDescribe(t, "sem")
+		require.False(t, cancelReq.GetWatchData())
+		stream.describeResult(cancelReq.GetReqId(), false, &Ydb_Coordination.SemaphoreDescription{Name: "sem"})
+
+		req = stream.expectDescribe(t, "sem")
+		require.True(t, req.GetWatchData())
+		require.True(t, req.GetWatchOwners())
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v2")})
+		require.Equal(t, []byte("v2"), expectDescription(t, descriptions).Data)
+
+		stream.describeChanged(req.GetReqId(), true, false)
+		req = stream.expectDescribe(t, "sem")
+		require.True(t, req.GetWatchData())
+	})
+	t.Run("FilterChanges", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		s, stream, _ := newTestWatchSession(t)
+
+		descriptions, err := s.WatchSemaphore(ctx, "sem", options.WithWatchData(false))
+		require.NoError(t, err)
+
+		req := stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v1")})
+		require.Equal(t, []byte("v1"), expectDescription(t, descriptions).Data)
+
+		stream.describeChanged(req.GetReqId(), true, false)
+		req = stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v2")})
+
+		stream.describeChanged(req.GetReqId(), false, true)
+		req = stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{
+			Name:   "sem",
+			Data:   []byte("v2"),
+			Owners: []*Ydb_Coordination.SemaphoreSession{{SessionId: 42, Count: 1}},
+		})
+
+		desc := expectDescription(t, descriptions)
+		require.Len(t, desc.Owners, 1)
+		require.Equal(t, uint64(42), desc.Owners[0].SessionID)
+	})
+	t.Run("SemaphoreNotFound", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		s, stream, _ := newTestWatchSession(t)
+
+		descriptions, err := s.WatchSemaphore(ctx, "sem")
+		require.NoError(t, err)
+
+		req := stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), false, nil)
+		require.Empty(t, expectDescription(t, descriptions).Name)
+
+		req = stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem"})
+		require.Equal(t, "sem", expectDescription(t, descriptions).Name)
+	})
+	t.Run("SharedWatch", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		s, stream, _ := newTestWatchSession(t)
+
+		first, err := s.WatchSemaphore(ctx, "sem")
+		require.NoError(t, err)
+
+		req := stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem", Data: []byte("v1")})
+		require.Equal(t, []byte("v1"), expectDescription(t, first).Data)
+
+		second, err := s.WatchSemaphore(ctx, "sem")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), expectDescription(t, second).Data)
+
+		select {
+		case req := <-stream.requests:
+			t.Fatalf("unexpected request: %v", req)
+		default:
+		}
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		s, stream, _ := newTestWatchSession(t)
+
+		watchCtx, cancel := context.WithCancel(ctx)
+		descriptions, err := s.WatchSemaphore(watchCtx, "sem")
+		require.NoError(t, err)
+
+		req := stream.expectDescribe(t, "sem")
+		stream.describeResult(req.GetReqId(), true, &Ydb_Coordination.SemaphoreDescription{Name: "sem"})
+		expectDescription(t, descriptions)
+
+		cancel()
+
+		cancelReq := stream.expectDescribe(t, "sem")
+		require.False(t, cancelReq.GetWatchData())
+		require.False(t, cancelReq.GetWatchOwners())
+		stream.describeChanged(req.GetReqId(), false, false)
+		stream.describeResult(cancelReq.GetReqId(), false, &Ydb_Coordination.SemaphoreDescription{Name: "sem"})
+
+		_, ok := <-descriptions
+		require.False(t, ok)
+
+		// A late notification of the canceled watch does not break the session.
+		stream.describeChanged(req.GetReqId(), true, true)
+		_, err = s.WatchSemaphore(ctx, "sem")
+		require.NoError(t, err)
+		req = stream.expectDescribe(t, "sem")
+		require.True(t, req.GetWatchData())
+	})
+	t.Run("SessionClosed", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		s, _, _ := newTestWatchSession(t)
+
+		descriptions, err := s.WatchSemaphore(ctx, "sem")
+		require.NoError(t, err)
+
+		s.cancel()
+
+		for range descriptions {
+		}
+
+		_, err = s.WatchSemaphore(ctx, "sem")
+		require.ErrorIs(t, err, coordination.ErrSessionClosed)
+	})
+}
@@ -3,7 +3,8 @@ package endpoint
 import "context"
 
 type (
-	ctxEndpointKey struct{}
+	ctxEndpointKey     struct{}
+	ctxAvoidNodeIDsKey struct{}
 )
 
 func WithNodeID(ctx context.Context, nodeID uint32) context.Context {
@@ -17,3 +18,14 @@ func ContextNodeID(ctx context.Context) (nodeID uint32, ok bool) {
 
 	return 0, false
 }
+
+// WithAvoidNodeIDs returns a copy of parent context with the nodes which should not be used if possible
+func WithAvoidNodeIDs(ctx context.Context, nodeIDs ...uint32) context.Context {
+	return context.WithValue(ctx, ctxAvoidNodeIDsKey{}, nodeIDs)
+}
+
+func ContextAvoidNodeIDs(ctx context.Context) (nodeIDs []uint32) {
+	nodeIDs, _ = ctx.Value(ctxAvoidNodeIDsKey{}).([]uint32)
+
+	return nodeIDs
+}
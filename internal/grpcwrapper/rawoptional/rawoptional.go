@@ -55,6 +55,21 @@ func (v *Duration) ToDuration() *time.Duration {
 	return &v.Value
 }
 
+type Int32 struct {
+	Value    int32
+	HasValue bool
+}
+
+func (v *Int32) ToProto() *int32 {
+	if !v.HasValue {
+		return nil
+	}
+
+	val := v.Value
+
+	return &val
+}
+
 type Int64 struct {
 	Value    int64
 	HasValue bool
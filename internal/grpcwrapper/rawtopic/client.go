@@ -30,6 +30,16 @@ func (c *Client) AlterTopic(ctx context.Context, req *AlterTopicRequest) (res Al
 	return res, err
 }
 
+func (c *Client) CommitOffset(ctx context.Context, req *CommitOffsetRequest) (res CommitOffsetResult, err error) {
+	resp, err := c.service.CommitOffset(ctx, req.ToProto())
+	if err != nil {
+		return res, xerrors.WithStackTrace(fmt.Errorf("ydb: commit offset grpc failed: %w", err))
+	}
+	err = res.FromProto(resp)
+
+	return res, err
+}
+
 func (c *Client) CreateTopic(
 	ctx context.Context,
 	req *CreateTopicRequest,
@@ -0,0 +1,33 @@
+package rawtopic
+
+import (
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Topic"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawydb"
+)
+
+type CommitOffsetRequest struct {
+	OperationParams rawydb.OperationParams
+	Path            string
+	PartitionID     int64
+	Consumer        string
+	Offset          int64
+}
+
+func (req *CommitOffsetRequest) ToProto() *Ydb_Topic.CommitOffsetRequest {
+	return &Ydb_Topic.CommitOffsetRequest{
+		OperationParams: req.OperationParams.ToProto(),
+		Path:            req.Path,
+		PartitionId:     req.PartitionID,
+		Consumer:        req.Consumer,
+		Offset:          req.Offset,
+	}
+}
+
+type CommitOffsetResult struct {
+	Operation rawydb.Operation
+}
+
+func (r *CommitOffsetResult) FromProto(proto *Ydb_Topic.CommitOffsetResponse) error {
+	return r.Operation.FromProtoWithStatusCheck(proto.GetOperation())
+}
@@ -47,8 +47,10 @@ const (
 )
 
 type PartitioningSettings struct {
-	MinActivePartitions int64
-	PartitionCountLimit int64
+	MinActivePartitions      int64
+	MaxActivePartitions      int64
+	PartitionCountLimit      int64
+	AutoPartitioningSettings AutoPartitioningSettings
 }
 
 func (s *PartitioningSettings) FromProto(proto *Ydb_Topic.PartitioningSettings) error {
@@ -57,26 +59,141 @@ func (s *PartitioningSettings) FromProto(proto *Ydb_Topic.PartitioningSettings)
 	}
 
 	s.MinActivePartitions = proto.GetMinActivePartitions()
+	s.MaxActivePartitions = proto.GetMaxActivePartitions()
 	s.PartitionCountLimit = proto.GetPartitionCountLimit() //nolint:staticcheck
+	s.AutoPartitioningSettings.FromProto(proto.GetAutoPartitioningSettings())
 
 	return nil
 }
 
 func (s *PartitioningSettings) ToProto() *Ydb_Topic.PartitioningSettings {
 	return &Ydb_Topic.PartitioningSettings{
-		MinActivePartitions: s.MinActivePartitions,
-		PartitionCountLimit: s.PartitionCountLimit,
+		MinActivePartitions:      s.MinActivePartitions,
+		MaxActivePartitions:      s.MaxActivePartitions,
+		PartitionCountLimit:      s.PartitionCountLimit,
+		AutoPartitioningSettings: s.AutoPartitioningSettings.ToProto(),
+	}
+}
+
+type AutoPartitioningStrategy int32
+
+const (
+	AutoPartitioningStrategyUnspecified    = AutoPartitioningStrategy(Ydb_Topic.AutoPartitioningStrategy_AUTO_PARTITIONING_STRATEGY_UNSPECIFIED)       //nolint:lll
+	AutoPartitioningStrategyDisabled       = AutoPartitioningStrategy(Ydb_Topic.AutoPartitioningStrategy_AUTO_PARTITIONING_STRATEGY_DISABLED)          //nolint:lll
+	AutoPartitioningStrategyScaleUp        = AutoPartitioningStrategy(Ydb_Topic.AutoPartitioningStrategy_AUTO_PARTITIONING_STRATEGY_SCALE_UP)          //nolint:lll
+	AutoPartitioningStrategyScaleUpAndDown = AutoPartitioningStrategy(Ydb_Topic.AutoPartitioningStrategy_AUTO_PARTITIONING_STRATEGY_SCALE_UP_AND_DOWN) //nolint:lll
+	AutoPartitioningStrategyPaused         = AutoPartitioningStrategy(Ydb_Topic.AutoPartitioningStrategy_AUTO_PARTITIONING_STRATEGY_PAUSED)            //nolint:lll
+)
+
+func (s AutoPartitioningStrategy) ToProto() Ydb_Topic.AutoPartitioningStrategy {
+	return Ydb_Topic.AutoPartitioningStrategy(s)
+}
+
+type OptionalAutoPartitioningStrategy struct {
+	Value    AutoPartitioningStrategy
+	HasValue bool
+}
+
+func (s *OptionalAutoPartitioningStrategy) ToProto() *Ydb_Topic.AutoPartitioningStrategy {
+	if !s.HasValue {
+		return nil
+	}
+
+	val := s.Value.ToProto()
+
+	return &val
+}
+
+type AutoPartitioningSettings struct {
+	Strategy            AutoPartitioningStrategy
+	PartitionWriteSpeed AutoPartitioningWriteSpeedStrategy
+}
+
+func (s *AutoPartitioningSettings) FromProto(proto *Ydb_Topic.AutoPartitioningSettings) {
+	if proto == nil {
+		*s = AutoPartitioningSettings{}
+
+		return
+	}
+
+	s.Strategy = AutoPartitioningStrategy(proto.GetStrategy())
+	s.PartitionWriteSpeed.FromProto(proto.GetPartitionWriteSpeed())
+}
+
+func (s *AutoPartitioningSettings) ToProto() *Ydb_Topic.AutoPartitioningSettings {
+	if *s == (AutoPartitioningSettings{}) {
+		return nil
+	}
+
+	return &Ydb_Topic.AutoPartitioningSettings{
+		Strategy:            s.Strategy.ToProto(),
+		PartitionWriteSpeed: s.PartitionWriteSpeed.ToProto(),
+	}
+}
+
+type AutoPartitioningWriteSpeedStrategy struct {
+	StabilizationWindow    rawoptional.Duration
+	UpUtilizationPercent   int32
+	DownUtilizationPercent int32
+}
+
+func (s *AutoPartitioningWriteSpeedStrategy) FromProto(proto *Ydb_Topic.AutoPartitioningWriteSpeedStrategy) {
+	s.StabilizationWindow.MustFromProto(proto.GetStabilizationWindow())
+	s.UpUtilizationPercent = proto.GetUpUtilizationPercent()
+	s.DownUtilizationPercent = proto.GetDownUtilizationPercent()
+}
+
+func (s *AutoPartitioningWriteSpeedStrategy) ToProto() *Ydb_Topic.AutoPartitioningWriteSpeedStrategy {
+	if *s == (AutoPartitioningWriteSpeedStrategy{}) {
+		return nil
+	}
+
+	return &Ydb_Topic.AutoPartitioningWriteSpeedStrategy{
+		StabilizationWindow:    s.StabilizationWindow.ToProto(),
+		UpUtilizationPercent:   s.UpUtilizationPercent,
+		DownUtilizationPercent: s.DownUtilizationPercent,
 	}
 }
 
 type AlterPartitioningSettings struct {
-	SetMinActivePartitions rawoptional.Int64
-	SetPartitionCountLimit rawoptional.Int64
+	SetMinActivePartitions        rawoptional.Int64
+	SetMaxActivePartitions        rawoptional.Int64
+	SetPartitionCountLimit        rawoptional.Int64
+	AlterAutoPartitioningSettings AlterAutoPartitioningSettings
 }
 
 func (s *AlterPartitioningSettings) ToProto() *Ydb_Topic.AlterPartitioningSettings {
 	return &Ydb_Topic.AlterPartitioningSettings{
-		SetMinActivePartitions: s.SetMinActivePartitions.ToProto(),
-		SetPartitionCountLimit: s.SetPartitionCountLimit.ToProto(),
+		SetMinActivePartitions:        s.SetMinActivePartitions.ToProto(),
+		SetMaxActivePartitions:        s.SetMaxActivePartitions.ToProto(),
+		SetPartitionCountLimit:        s.SetPartitionCountLimit.ToProto(),
+		AlterAutoPartitioningSettings: s.AlterAutoPartitioningSettings.ToProto(),
+	}
+}
+
+type AlterAutoPartitioningSettings struct {
+	SetStrategy               OptionalAutoPartitioningStrategy
+	SetStabilizationWindow    rawoptional.Duration
+	SetUpUtilizationPercent   rawoptional.Int32
+	SetDownUtilizationPercent rawoptional.Int32
+}
+
+func (s *AlterAutoPartitioningSettings) ToProto() *Ydb_Topic.AlterAutoPartitioningSettings {
+	if *s == (AlterAutoPartitioningSettings{}) {
+		return nil
 	}
+
+	res := &Ydb_Topic.AlterAutoPartitioningSettings{
+		SetStrategy: s.SetStrategy.ToProto(),
+	}
+
+	if s.SetStabilizationWindow.HasValue || s.SetUpUtilizationPercent.HasValue || s.SetDownUtilizationPercent.HasValue {
+		res.SetPartitionWriteSpeed = &Ydb_Topic.AlterAutoPartitioningWriteSpeedStrategy{
+			SetStabilizationWindow:    s.SetStabilizationWindow.ToProto(),
+			SetUpUtilizationPercent:   s.SetUpUtilizationPercent.ToProto(),
+			SetDownUtilizationPercent: s.SetDownUtilizationPercent.ToProto(),
+		}
+	}
+
+	return res
 }
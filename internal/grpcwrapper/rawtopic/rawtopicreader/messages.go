@@ -20,6 +20,7 @@ var (
 	errUnexpectedProtoNilStartPartitionSessionRequest = xerrors.Wrap(errors.New("ydb: unexpected proto nil start partition session request"))                      //nolint:lll
 	errUnexpectedNilPartitionSession                  = xerrors.Wrap(errors.New("ydb: unexpected proto nil partition session in start partition session request")) //nolint:lll
 	errUnexpectedGrpcNilStopPartitionSessionRequest   = xerrors.Wrap(errors.New("ydb: unexpected grpc nil stop partition session request"))                        //nolint:lll
+	errUnexpectedGrpcNilEndPartitionSession           = xerrors.Wrap(errors.New("ydb: unexpected grpc nil end partition session"))                                 //nolint:lll
 )
 
 type PartitionSessionID int64
@@ -93,11 +94,15 @@ type InitRequest struct {
 	TopicsReadSettings []TopicReadSettings
 
 	Consumer string
+
+	// AutoPartitioningSupport mean reader handle EndPartitionSession messages
+	AutoPartitioningSupport bool
 }
 
 func (r *InitRequest) toProto() *Ydb_Topic.StreamReadMessage_InitRequest {
 	p := &Ydb_Topic.StreamReadMessage_InitRequest{
-		Consumer: r.Consumer,
+		Consumer:                r.Consumer,
+		AutoPartitioningSupport: r.AutoPartitioningSupport,
 	}
 
 	p.TopicsReadSettings = make([]*Ydb_Topic.StreamReadMessage_InitRequest_TopicReadSettings, len(r.TopicsReadSettings))
@@ -466,6 +471,33 @@ func (r *StopPartitionSessionRequest) fromProto(proto *Ydb_Topic.StreamReadMessa
 	return nil
 }
 
+//
+// EndPartitionSession
+//
+
+// EndPartitionSession is signal from server, about all messages of the partition was read
+// the partition session will not receive new messages, because the partition was splitted or merged
+type EndPartitionSession struct {
+	serverMessageImpl
+
+	rawtopiccommon.ServerMessageMetadata
+
+	PartitionSessionID   PartitionSessionID
+	AdjacentPartitionIDs []int64
+	ChildPartitionIDs    []int64
+}
+
+func (r *EndPartitionSession) fromProto(proto *Ydb_Topic.StreamReadMessage_EndPartitionSession) error {
+	if proto == nil {
+		return xerrors.WithStackTrace(errUnexpectedGrpcNilEndPartitionSession)
+	}
+	r.PartitionSessionID.FromInt64(proto.GetPartitionSessionId())
+	r.AdjacentPartitionIDs = proto.GetAdjacentPartitionIds()
+	r.ChildPartitionIDs = proto.GetChildPartitionIds()
+
+	return nil
+}
+
 type StopPartitionSessionResponse struct {
 	clientMessageImpl
 
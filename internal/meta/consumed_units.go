@@ -0,0 +1,24 @@
+package meta
+
+import (
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ConsumedUnits returns the amount of request units reported by the server in the metadata
+func ConsumedUnits(md metadata.MD) (consumedUnits uint64) {
+	for header, values := range md {
+		if header != HeaderConsumedUnits {
+			continue
+		}
+		for _, v := range values {
+			v, err := strconv.ParseUint(v, 10, 64)
+			if err == nil {
+				consumedUnits += v
+			}
+		}
+	}
+
+	return consumedUnits
+}
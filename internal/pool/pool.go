@@ -3,6 +3,7 @@ package pool
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -502,6 +503,24 @@ func (p *Pool[PT, T]) peekFirstIdleByNodeID(nodeID uint32) (item PT, touched tim
 	return item, info.lastUsage
 }
 
+// p.mu must be held.
+func (p *Pool[PT, T]) peekFirstIdleNotOnNodeIDs(nodeIDs []uint32) (item PT, touched time.Time) {
+	el := p.idle.Front()
+	for el != nil && slices.Contains(nodeIDs, el.Value.NodeID()) {
+		el = el.Next()
+	}
+	if el == nil {
+		return
+	}
+	item = el.Value
+	info, has := p.index[item]
+	if !has || el != info.idle {
+		panic(fmt.Sprintf("inconsistent index: (%v, %+v, %+v)", has, el, info.idle))
+	}
+
+	return item, info.lastUsage
+}
+
 // removes first item from idle to use only in outgoing functions that make item busy.
 // p.mu must be held.
 func (p *Pool[PT, T]) removeFirstIdle() PT {
@@ -526,6 +545,19 @@ func (p *Pool[PT, T]) removeIdleByNodeID(nodeID uint32) PT {
 	return idle
 }
 
+// removes first item which is not on the avoided nodes from idle to use only in outgoing functions that make
+// item busy.
+// p.mu must be held.
+func (p *Pool[PT, T]) removeIdleNotOnNodeIDs(nodeIDs []uint32) PT {
+	idle, _ := p.peekFirstIdleNotOnNodeIDs(nodeIDs)
+	if idle != nil {
+		info := p.removeIdle(idle)
+		p.index[idle] = info
+	}
+
+	return idle
+}
+
 // p.mu must be held.
 func (p *Pool[PT, T]) notifyAboutIdle(idle PT) (notified bool) {
 	for el := p.waitQ.Front(); el != nil; el = p.waitQ.Front() {
@@ -624,6 +656,7 @@ func (p *Pool[PT, T]) getItem(ctx context.Context) (item PT, finalErr error) { /
 	}
 
 	preferredNodeID, hasPreferredNodeID := endpoint.ContextNodeID(ctx)
+	avoidNodeIDs := endpoint.ContextAvoidNodeIDs(ctx)
 
 	for ; attempt < maxAttempts; attempt++ {
 		select {
@@ -645,6 +678,12 @@ func (p *Pool[PT, T]) getItem(ctx context.Context) (item PT, finalErr error) { /
 				}
 			}
 
+			if len(avoidNodeIDs) > 0 {
+				if item := p.removeIdleNotOnNodeIDs(avoidNodeIDs); item != nil {
+					return item
+				}
+			}
+
 			return p.removeFirstIdle()
 		}); item != nil {
 			if item.IsAlive() {
@@ -297,6 +297,39 @@ func TestPool(t *testing.T) { //nolint:gocyclo
 
 			require.EqualValues(t, 1, newSessionCalled)
 		})
+		t.Run("AvoidNodeIDs", func(t *testing.T) {
+			nextNodeID := uint32(0)
+			p := New[*testItem, testItem](rootCtx,
+				WithTrace[*testItem, testItem](defaultTrace),
+				WithCreateItemFunc(func(ctx context.Context) (*testItem, error) {
+					nextNodeID++
+					nodeID := nextNodeID
+
+					return &testItem{
+						onNodeID: func() uint32 {
+							return nodeID
+						},
+					}, nil
+				}),
+			)
+
+			item1 := mustGetItem(t, p)
+			item2 := mustGetItem(t, p)
+			require.EqualValues(t, 1, item1.NodeID())
+			require.EqualValues(t, 2, item2.NodeID())
+			mustPutItem(t, p, item1)
+			mustPutItem(t, p, item2)
+
+			item, err := p.getItem(endpoint.WithAvoidNodeIDs(context.Background(), 1))
+			require.NoError(t, err)
+			require.EqualValues(t, 2, item.NodeID())
+			mustPutItem(t, p, item)
+
+			item, err = p.getItem(endpoint.WithAvoidNodeIDs(context.Background(), 1, 2))
+			require.NoError(t, err)
+			require.NotNil(t, item, "the avoided nodes are used if there is no other idle item")
+			mustPutItem(t, p, item)
+		})
 		t.Run("WithLimit", func(t *testing.T) {
 			p := New[*testItem, testItem](rootCtx, WithLimit[*testItem, testItem](1),
 				WithTrace[*testItem, testItem](defaultTrace),
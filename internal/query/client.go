@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
@@ -45,6 +46,7 @@ type (
 		config *config.Config
 		client Ydb_Query_V1.QueryServiceClient
 		pool   sessionPool
+		hedger *hedger
 
 		done chan struct{}
 	}
@@ -187,6 +189,13 @@ func (c *Client) ExecuteScript(
 	return op, nil
 }
 
+// executeOptions prepends the options of the client to the options of the call, so the call can override them
+func (c *Client) executeOptions(opts []options.Execute) []options.Execute {
+	return append([]options.Execute{
+		options.WithRetryErrorClassifier(c.config.RetryErrorClassifier()),
+	}, opts...)
+}
+
 func (c *Client) Close(ctx context.Context) error {
 	close(c.done)
 
@@ -205,10 +214,15 @@ func do(
 ) (finalErr error) {
 	err := pool.With(ctx, func(ctx context.Context, s *Session) error {
 		s.SetStatus(session.StatusInUse)
+		onSessionNodeID(ctx, s.NodeID())
 
 		err := op(ctx, s)
 		if err != nil {
-			s.SetStatus(session.StatusError)
+			if isSessionValid(ctx, err) {
+				s.SetStatus(session.StatusIdle)
+			} else {
+				s.SetStatus(session.StatusError)
+			}
 
 			return xerrors.WithStackTrace(err)
 		}
@@ -224,6 +238,22 @@ func do(
 	return nil
 }
 
+// isSessionValid reports whether the session can be reused after the error of the operation. The session is kept only
+// on the explicit verdict of the error classifier or of the ydb error. Other errors (errors of the application,
+// canceled context, partially read results) break the session.
+func isSessionValid(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var ydbErr xerrors.Error
+	if !xerrors.As(xcontext.ClassifyError(ctx, err), &ydbErr) {
+		return false
+	}
+
+	return ydbErr.IsRetryObjectValid()
+}
+
 func (c *Client) Do(ctx context.Context, op query.Operation, opts ...options.DoOption) (finalErr error) {
 	ctx, cancel := xcontext.WithDone(ctx, c.done)
 	defer cancel()
@@ -244,6 +274,7 @@ func (c *Client) Do(ctx context.Context, op query.Operation, opts ...options.DoO
 			return op(ctx, s)
 		},
 		append([]retry.Option{
+			retry.WithErrorClassifier(c.config.RetryErrorClassifier()),
 			retry.WithTrace(&trace.Retry{
 				OnRetry: func(info trace.RetryLoopStartInfo) func(trace.RetryLoopDoneInfo) {
 					return func(info trace.RetryLoopDoneInfo) {
@@ -271,9 +302,8 @@ func doTx(
 		}
 
 		defer func() {
-			_ = tx.Rollback(ctx)
-
-			if opErr != nil {
+			if err := tx.Rollback(ctx); err != nil && opErr != nil {
+				// the transaction may stay alive on the server, so the session must not be reused
 				s.SetStatus(session.StatusError)
 			}
 		}()
@@ -328,7 +358,10 @@ func (c *Client) QueryRow(ctx context.Context, q string, opts ...options.Execute
 		onDone(finalErr)
 	}()
 
-	row, err := clientQueryRow(ctx, c.pool, q, options.ExecuteSettings(opts...), withTrace(c.config.Trace()))
+	settings := options.ExecuteSettings(c.executeOptions(opts)...)
+	row, err := hedge(ctx, c.hedger, settings, func(ctx context.Context) (query.Row, error) {
+		return clientQueryRow(ctx, c.pool, q, settings, withTrace(c.config.Trace()))
+	})
 	if err != nil {
 		return nil, xerrors.WithStackTrace(err)
 	}
@@ -370,7 +403,10 @@ func (c *Client) Exec(ctx context.Context, q string, opts ...options.Execute) (f
 		onDone(finalErr)
 	}()
 
-	err := clientExec(ctx, c.pool, q, opts...)
+	opts = c.executeOptions(opts)
+	_, err := hedge(ctx, c.hedger, options.ExecuteSettings(opts...), func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, clientExec(ctx, c.pool, q, opts...)
+	})
 	if err != nil {
 		return xerrors.WithStackTrace(err)
 	}
@@ -423,7 +459,10 @@ func (c *Client) Query(ctx context.Context, q string, opts ...options.Execute) (
 		onDone(err)
 	}()
 
-	r, err = clientQuery(ctx, c.pool, q, opts...)
+	opts = c.executeOptions(opts)
+	r, err = hedge(ctx, c.hedger, options.ExecuteSettings(opts...), func(ctx context.Context) (query.Result, error) {
+		return clientQuery(ctx, c.pool, q, opts...)
+	})
 	if err != nil {
 		return nil, xerrors.WithStackTrace(err)
 	}
@@ -469,7 +508,10 @@ func (c *Client) QueryResultSet(
 		onDone(finalErr)
 	}()
 
-	rs, err := clientQueryResultSet(ctx, c.pool, q, options.ExecuteSettings(opts...), withTrace(c.config.Trace()))
+	settings := options.ExecuteSettings(c.executeOptions(opts)...)
+	rs, err := hedge(ctx, c.hedger, settings, func(ctx context.Context) (result.ClosableResultSet, error) {
+		return clientQueryResultSet(ctx, c.pool, q, settings, withTrace(c.config.Trace()))
+	})
 	if err != nil {
 		return nil, xerrors.WithStackTrace(err)
 	}
@@ -496,6 +538,7 @@ func (c *Client) DoTx(ctx context.Context, op query.TxOperation, opts ...options
 		settings.TxSettings(),
 		append(
 			[]retry.Option{
+				retry.WithErrorClassifier(c.config.RetryErrorClassifier()),
 				retry.WithTrace(&trace.Retry{
 					OnRetry: func(info trace.RetryLoopStartInfo) func(trace.RetryLoopDoneInfo) {
 						return func(info trace.RetryLoopDoneInfo) {
@@ -554,9 +597,10 @@ func New(ctx context.Context, cc grpc.ClientConnInterface, cfg *config.Config) *
 
 	client := Ydb_Query_V1.NewQueryServiceClient(cc)
 
-	return &Client{
+	c := &Client{
 		config: cfg,
 		client: client,
+		hedger: newHedger(cfg.Trace(), clockwork.NewRealClock()),
 		done:   make(chan struct{}),
 		pool: pool.New(ctx,
 			pool.WithLimit[*Session, Session](cfg.PoolLimit()),
@@ -592,6 +636,12 @@ func New(ctx context.Context, cc grpc.ClientConnInterface, cfg *config.Config) *
 			}),
 		),
 	}
+
+	if newLimiter := cfg.RateLimiter(); newLimiter != nil {
+		c.pool = newRateLimitedPool(c.pool, newLimiter(), cfg.RateLimiterCost())
+	}
+
+	return c
 }
 
 func poolTrace(t *trace.Query) *pool.Trace {
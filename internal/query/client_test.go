@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,7 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
 	"github.com/ydb-platform/ydb-go-sdk/v3/query"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
@@ -171,6 +173,96 @@ func TestClient(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, 10, counter)
 		})
+		t.Run("ErrorClassifier", func(t *testing.T) {
+			errKeepSession := errors.New("keep session")
+			errDeleteSession := errors.New("delete session")
+			classifier := retry.WithErrorClassifier(func(err error) retry.Decision {
+				return retry.Decision{
+					Classified:    true,
+					Retryable:     true,
+					Backoff:       retry.TypeNoBackoff,
+					DeleteSession: errors.Is(err, errDeleteSession),
+				}
+			})
+			for _, tt := range []struct {
+				name     string
+				err      error
+				sessions int
+			}{
+				{
+					name:     "KeepSession",
+					err:      errKeepSession,
+					sessions: 1,
+				},
+				{
+					name:     "DeleteSession",
+					err:      errDeleteSession,
+					sessions: 2,
+				},
+			} {
+				t.Run(tt.name, func(t *testing.T) {
+					sessions := 0
+					counter := 0
+					err := do(ctx, testPool(ctx, func(ctx context.Context) (*Session, error) {
+						sessions++
+
+						return newTestSession(strconv.Itoa(sessions)), nil
+					}), func(ctx context.Context, s *Session) error {
+						counter++
+						if counter < 2 {
+							return tt.err
+						}
+
+						return nil
+					}, classifier)
+					require.NoError(t, err)
+					require.Equal(t, 2, counter)
+					require.Equal(t, tt.sessions, sessions)
+				})
+			}
+		})
+		t.Run("BreakSession", func(t *testing.T) {
+			keepSession := retry.WithErrorClassifier(func(err error) retry.Decision {
+				return retry.Decision{Classified: true}
+			})
+			for _, tt := range []struct {
+				name string
+				op   func(cancel context.CancelFunc) error
+				opts []retry.Option
+			}{
+				{
+					name: "PlainError",
+					op: func(context.CancelFunc) error {
+						return errors.New("test")
+					},
+				},
+				{
+					name: "ContextCanceled",
+					op: func(cancel context.CancelFunc) error {
+						cancel()
+
+						return context.Canceled
+					},
+					opts: []retry.Option{keepSession},
+				},
+			} {
+				t.Run(tt.name, func(t *testing.T) {
+					ctx, cancel := context.WithCancel(ctx)
+					defer cancel()
+
+					var used *Session
+					err := do(ctx, testPool(ctx, func(ctx context.Context) (*Session, error) {
+						return newTestSession("1"), nil
+					}), func(ctx context.Context, s *Session) error {
+						used = s
+
+						return tt.op(cancel)
+					}, tt.opts...)
+					require.Error(t, err)
+					require.Equal(t, session.StatusError.String(), used.Status())
+				})
+			}
+		})
 	})
 	t.Run("DoTx", func(t *testing.T) {
 		t.Run("HappyWay", func(t *testing.T) {
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"time"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/config"
@@ -26,9 +27,28 @@ type Config struct {
 
 	lazyTx bool
 
+	rateLimiter     func() RateLimiter
+	rateLimiterCost CostFunc
+
 	trace *trace.Query
 }
 
+// RateLimiter limits the operations of the query client
+type RateLimiter interface {
+	// Wait blocks until n request units are taken
+	Wait(ctx context.Context, n uint64) error
+
+	// Report records the consumption of n request units which have not been taken beforehand
+	Report(n uint64)
+
+	Close(ctx context.Context) error
+}
+
+// CostFunc returns the amount of request units to take before an operation of the query client. The
+// lastConsumedUnits argument is the amount of request units consumed by the last completed operation
+// according to the server or zero if it is unknown yet.
+type CostFunc func(lastConsumedUnits uint64) uint64
+
 func New(opts ...Option) *Config {
 	c := defaults()
 	for _, opt := range opts {
@@ -86,3 +106,14 @@ func (c *Config) SessionIdleTimeToLive() time.Duration {
 func (c *Config) LazyTx() bool {
 	return c.lazyTx
 }
+
+// RateLimiter returns the constructor of the rate limiter of the client operations or nil if operations are not
+// limited
+func (c *Config) RateLimiter() func() RateLimiter {
+	return c.rateLimiter
+}
+
+// RateLimiterCost returns the cost function of the client operations
+func (c *Config) RateLimiterCost() CostFunc {
+	return c.rateLimiterCost
+}
@@ -77,3 +77,13 @@ func WithLazyTx(lazyTx bool) Option {
 		c.lazyTx = lazyTx
 	}
 }
+
+// WithRateLimiter makes the client take the quota of the rate limiter before every operation. The newLimiter
+// function is called once on client creation, the limiter is closed with the client. If cost is nil, every
+// operation takes a single request unit.
+func WithRateLimiter(newLimiter func() RateLimiter, cost CostFunc) Option {
+	return func(c *Config) {
+		c.rateLimiter = newLimiter
+		c.rateLimiterCost = cost
+	}
+}
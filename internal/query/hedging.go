@@ -0,0 +1,206 @@
+package query
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/query/tx"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stack"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+const (
+	hedgingLatencyWindow     = 128
+	hedgingMinLatencySamples = 16
+	hedgingLatencyPercentile = 0.95
+)
+
+type (
+	hedgingSettings interface {
+		Idempotent() bool
+		Hedging() (delay time.Duration, maxAttempts int)
+		TxControl() *tx.Control
+	}
+
+	// hedger keeps the recent latencies of the hedged queries of the client to derive the hedging delay
+	hedger struct {
+		clock clockwork.Clock
+		trace *trace.Query
+
+		mu        sync.Mutex
+		latencies [hedgingLatencyWindow]time.Duration
+		samples   int
+	}
+
+	ctxOnSessionNodeIDKey struct{}
+)
+
+func newHedger(t *trace.Query, clock clockwork.Clock) *hedger {
+	return &hedger{
+		clock: clock,
+		trace: t,
+	}
+}
+
+func (h *hedger) addLatency(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.latencies[h.samples%hedgingLatencyWindow] = latency
+	h.samples++
+}
+
+// delay returns the percentile of the recent latencies if it is less than maxDelay
+func (h *hedger) delay(maxDelay time.Duration) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.samples < hedgingMinLatencySamples {
+		return maxDelay
+	}
+
+	latencies := slices.Clone(h.latencies[:min(h.samples, hedgingLatencyWindow)])
+	slices.Sort(latencies)
+
+	if d := latencies[int(float64(len(latencies)-1)*hedgingLatencyPercentile)]; d < maxDelay {
+		return d
+	}
+
+	return maxDelay
+}
+
+// withOnSessionNodeID returns a copy of parent context with the callback on the node of the session of the query
+func withOnSessionNodeID(ctx context.Context, onNodeID func(nodeID uint32)) context.Context {
+	return context.WithValue(ctx, ctxOnSessionNodeIDKey{}, onNodeID)
+}
+
+func onSessionNodeID(ctx context.Context, nodeID uint32) {
+	if onNodeID, ok := ctx.Value(ctxOnSessionNodeIDKey{}).(func(nodeID uint32)); ok {
+		onNodeID(nodeID)
+	}
+}
+
+func isHedgingAllowed(settings hedgingSettings) bool {
+	delay, maxAttempts := settings.Hedging()
+
+	return delay > 0 && maxAttempts > 1 && settings.Idempotent() && settings.TxControl().IsReadOnly()
+}
+
+// hedge calls op and sends hedged copies of it if op does not answer in time. The copies prefer the sessions
+// on the other nodes. The first successful answer wins and the other copies are canceled. The sessions of the
+// canceled copies are not returned to the pool because the server may still execute the query on them, so op must
+// not return values tied to its ctx (the results are materialized before return).
+func hedge[T any](ctx context.Context, h *hedger, settings hedgingSettings, //nolint:funlen
+	op func(ctx context.Context) (T, error),
+) (zero T, _ error) {
+	if h == nil || !isHedgingAllowed(settings) {
+		return op(ctx)
+	}
+
+	maxDelay, maxAttempts := settings.Hedging()
+
+	ctx, cancel := xcontext.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		attempt int
+		value   T
+		err     error
+	}
+
+	var (
+		start   = h.clock.Now()
+		delay   = h.delay(maxDelay)
+		results = make(chan result, maxAttempts)
+		onDones = make([]func(won bool, _ error), maxAttempts+1)
+
+		mu      sync.Mutex
+		nodeIDs []uint32
+	)
+
+	launch := func(attempt int) {
+		attemptCtx := withOnSessionNodeID(ctx, func(nodeID uint32) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			nodeIDs = append(nodeIDs, nodeID)
+		})
+		mu.Lock()
+		if len(nodeIDs) > 0 {
+			attemptCtx = endpoint.WithAvoidNodeIDs(attemptCtx, slices.Clone(nodeIDs)...)
+		}
+		mu.Unlock()
+
+		go func() {
+			value, err := op(attemptCtx)
+			results <- result{
+				attempt: attempt,
+				value:   value,
+				err:     err,
+			}
+		}()
+	}
+
+	onDones[1] = func(bool, error) {}
+	launch(1)
+
+	timer := h.clock.NewTimer(delay)
+	defer timer.Stop()
+
+	var (
+		launched = 1
+		inFlight = 1
+		firstErr error
+	)
+	for {
+		var hedgeCh <-chan time.Time
+		if launched < maxAttempts {
+			hedgeCh = timer.Chan()
+		}
+
+		select {
+		case <-hedgeCh:
+			launched++
+			inFlight++
+			onDones[launched] = trace.QueryOnHedge(h.trace, &ctx,
+				stack.FunctionID("github.com/ydb-platform/ydb-go-sdk/v3/internal/query.hedge"),
+				launched, delay,
+			)
+			launch(launched)
+			timer.Reset(delay)
+		case r := <-results:
+			inFlight--
+			if r.err != nil {
+				onDones[r.attempt](false, r.err)
+				if firstErr == nil {
+					firstErr = r.err
+				}
+				if inFlight > 0 {
+					continue
+				}
+
+				return zero, xerrors.WithStackTrace(firstErr)
+			}
+
+			h.addLatency(h.clock.Since(start))
+			onDones[r.attempt](true, nil)
+
+			// the losers are canceled on return and traced on exit
+			go func(inFlight int) {
+				for ; inFlight > 0; inFlight-- {
+					r := <-results
+					onDones[r.attempt](false, r.err)
+				}
+			}(inFlight)
+
+			return r.value, nil
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/query/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/query/tx"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func TestHedge(t *testing.T) {
+	hedgedSettings := func(maxAttempts int) hedgingSettings {
+		return options.ExecuteSettings(
+			options.WithIdempotent(),
+			options.WithTxControl(tx.SnapshotReadOnlyTxControl()),
+			options.WithHedging(time.Second, maxAttempts),
+		)
+	}
+	t.Run("NotAllowed", func(t *testing.T) {
+		for _, tt := range []struct {
+			name     string
+			settings hedgingSettings
+		}{
+			{
+				name: "NotIdempotent",
+				settings: options.ExecuteSettings(
+					options.WithTxControl(tx.SnapshotReadOnlyTxControl()),
+					options.WithHedging(time.Second, 2),
+				),
+			},
+			{
+				name: "IdempotenceOverridden",
+				settings: options.ExecuteSettings(
+					options.WithIdempotent(),
+					options.RetryOptionsOption{retry.WithIdempotent(false)},
+					options.WithTxControl(tx.SnapshotReadOnlyTxControl()),
+					options.WithHedging(time.Second, 2),
+				),
+			},
+			{
+				name: "ReadWrite",
+				settings: options.ExecuteSettings(
+					options.WithIdempotent(),
+					options.WithTxControl(tx.SerializableReadWriteTxControl(tx.CommitTx())),
+					options.WithHedging(time.Second, 2),
+				),
+			},
+			{
+				name: "NoCommit",
+				settings: options.ExecuteSettings(
+					options.WithIdempotent(),
+					options.WithTxControl(tx.NewControl(tx.BeginTx(tx.WithSnapshotReadOnly()))),
+					options.WithHedging(time.Second, 2),
+				),
+			},
+			{
+				name: "NoHedging",
+				settings: options.ExecuteSettings(
+					options.WithIdempotent(),
+					options.WithTxControl(tx.SnapshotReadOnlyTxControl()),
+				),
+			},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				require.False(t, isHedgingAllowed(tt.settings))
+			})
+		}
+		require.True(t, isHedgingAllowed(hedgedSettings(2)))
+		require.True(t, isHedgingAllowed(options.ExecuteSettings(
+			options.RetryOptionsOption{retry.WithIdempotent(true)},
+			options.WithTxControl(tx.SnapshotReadOnlyTxControl()),
+			options.WithHedging(time.Second, 2),
+		)), "the idempotence is detected by the retry options")
+	})
+	t.Run("SlowAttempt", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		clock := clockwork.NewFakeClock()
+		var (
+			mu       sync.Mutex
+			attempts int
+			avoided  [][]uint32
+			hedges   []trace.QueryHedgeDoneInfo
+		)
+		h := newHedger(&trace.Query{
+			OnHedge: func(info trace.QueryHedgeStartInfo) func(trace.QueryHedgeDoneInfo) {
+				require.Equal(t, 2, info.Attempt)
+				require.Equal(t, time.Second, info.Delay)
+
+				return func(info trace.QueryHedgeDoneInfo) {
+					hedges = append(hedges, info)
+				}
+			},
+		}, clock)
+
+		type result struct {
+			v   int
+			err error
+		}
+		var firstCanceled sync.WaitGroup
+		firstCanceled.Add(1)
+		results := make(chan result, 1)
+		go func() {
+			v, err := hedge(ctx, h, hedgedSettings(2), func(ctx context.Context) (int, error) {
+				mu.Lock()
+				attempts++
+				attempt := attempts
+				avoided = append(avoided, endpoint.ContextAvoidNodeIDs(ctx))
+				onSessionNodeID(ctx, uint32(attempt))
+				mu.Unlock()
+
+				if attempt == 1 {
+					<-ctx.Done()
+					firstCanceled.Done()
+
+					return 0, ctx.Err()
+				}
+
+				return attempt, nil
+			})
+			results <- result{v: v, err: err}
+		}()
+		xtest.SpinWaitCondition(t, &mu, func() bool {
+			if attempts > 0 {
+				clock.Advance(time.Second)
+			}
+
+			return attempts == 2
+		})
+
+		r := <-results
+		v, err := r.v, r.err
+		require.NoError(t, err)
+		require.Equal(t, 2, v)
+		firstCanceled.Wait()
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, 2, attempts)
+		require.Equal(t, [][]uint32{nil, {1}}, avoided, "the copy avoids the node of the first attempt")
+		require.Equal(t, []trace.QueryHedgeDoneInfo{{Won: true}}, hedges)
+	})
+	t.Run("AllAttemptsFailed", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		testErr := errors.New("test")
+		attempts := 0
+		_, err := hedge(ctx, newHedger(&trace.Query{}, clockwork.NewFakeClock()), hedgedSettings(2),
+			func(ctx context.Context) (int, error) {
+				attempts++
+
+				return 0, testErr
+			},
+		)
+		require.ErrorIs(t, err, testErr)
+		require.Equal(t, 1, attempts, "the failed query is not hedged")
+	})
+	t.Run("PercentileDelay", func(t *testing.T) {
+		h := newHedger(&trace.Query{}, clockwork.NewFakeClock())
+		for i := 1; i < hedgingMinLatencySamples; i++ {
+			h.addLatency(time.Duration(i) * time.Millisecond)
+		}
+		require.Equal(t, time.Second, h.delay(time.Second), "not enough samples")
+
+		for i := 0; i < hedgingLatencyWindow; i++ {
+			h.addLatency(time.Duration(i%100+1) * time.Millisecond)
+		}
+		require.Equal(t, 93*time.Millisecond, h.delay(time.Second))
+		require.Equal(t, 50*time.Millisecond, h.delay(50*time.Millisecond))
+	})
+}
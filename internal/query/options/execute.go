@@ -1,6 +1,8 @@
 package options
 
 import (
+	"time"
+
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
 	"google.golang.org/grpc"
 
@@ -18,6 +20,7 @@ var (
 	_ Execute = syntaxOption(0)
 	_ Execute = statsModeOption{}
 	_ Execute = execModeOption(0)
+	_ Execute = hedgingOption{}
 )
 
 type (
@@ -36,6 +39,8 @@ type (
 		callOptions            []grpc.CallOption
 		txControl              *tx.Control
 		retryOptions           []retry.Option
+		hedgingDelay           time.Duration
+		hedgingMaxAttempts     int
 		responsePartLimitBytes int64
 	}
 
@@ -63,6 +68,10 @@ type (
 	}
 	execModeOption         = ExecMode
 	responsePartLimitBytes int64
+	hedgingOption          struct {
+		delay       time.Duration
+		maxAttempts int
+	}
 )
 
 func (poolID resourcePool) applyExecuteOption(s *executeSettings) {
@@ -180,6 +189,25 @@ func (s *executeSettings) ResponsePartLimitSizeBytes() int64 {
 	return s.responsePartLimitBytes
 }
 
+// Idempotent reports whether the retry options mark the query as idempotent, the last idempotent option wins
+func (s *executeSettings) Idempotent() (idempotent bool) {
+	for _, opt := range s.retryOptions {
+		switch opt {
+		case retry.WithIdempotent(true):
+			idempotent = true
+		case retry.WithIdempotent(false):
+			idempotent = false
+		}
+	}
+
+	return idempotent
+}
+
+// Hedging returns the max delay before a hedged copy of the query is sent and the max count of copies in flight
+func (s *executeSettings) Hedging() (delay time.Duration, maxAttempts int) {
+	return s.hedgingDelay, s.hedgingMaxAttempts
+}
+
 func WithParameters(params params.Parameters) parametersOption {
 	return parametersOption{
 		params: params,
@@ -208,6 +236,18 @@ func WithExecMode(mode ExecMode) execModeOption {
 	return mode
 }
 
+func (opt hedgingOption) applyExecuteOption(s *executeSettings) {
+	s.hedgingDelay = opt.delay
+	s.hedgingMaxAttempts = opt.maxAttempts
+}
+
+func WithHedging(delay time.Duration, maxAttempts int) hedgingOption {
+	return hedgingOption{
+		delay:       delay,
+		maxAttempts: maxAttempts,
+	}
+}
+
 func WithResponsePartLimitSizeBytes(size int64) responsePartLimitBytes {
 	return responsePartLimitBytes(size)
 }
@@ -100,6 +100,10 @@ func WithRetryBudget(b budget.Budget) RetryOptionsOption {
 	return []retry.Option{retry.WithBudget(b)}
 }
 
+func WithRetryErrorClassifier(classifier retry.ErrorClassifier) RetryOptionsOption {
+	return []retry.Option{retry.WithErrorClassifier(classifier)}
+}
+
 func ParseDoOpts(t *trace.Query, opts ...DoOption) (s *doSettings) {
 	s = &doSettings{
 		trace: t,
@@ -0,0 +1,77 @@
+package query
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/meta"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/query/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+)
+
+var _ sessionPool = (*rateLimitedPool)(nil)
+
+// rateLimitedPool takes the quota of the rate limiter before every operation of the client. The request units
+// which the server reports in the trailers over the taken amount are reported to the limiter afterwards.
+type rateLimitedPool struct {
+	sessionPool
+
+	limiter           config.RateLimiter
+	cost              config.CostFunc
+	lastConsumedUnits atomic.Uint64
+}
+
+func newRateLimitedPool(pool sessionPool, limiter config.RateLimiter, cost config.CostFunc) *rateLimitedPool {
+	if cost == nil {
+		cost = func(uint64) uint64 {
+			return 1
+		}
+	}
+
+	return &rateLimitedPool{
+		sessionPool: pool,
+		limiter:     limiter,
+		cost:        cost,
+	}
+}
+
+func (p *rateLimitedPool) With(
+	ctx context.Context,
+	f func(ctx context.Context, s *Session) error,
+	opts ...retry.Option,
+) error {
+	cost := p.cost(p.lastConsumedUnits.Load())
+	if cost > 0 {
+		if err := p.limiter.Wait(ctx, cost); err != nil {
+			return xerrors.WithStackTrace(err)
+		}
+	}
+
+	var consumedUnits atomic.Uint64
+	ctx = meta.WithTrailerCallback(ctx, func(md metadata.MD) {
+		consumedUnits.Add(meta.ConsumedUnits(md))
+	})
+	defer func() {
+		consumed := consumedUnits.Load()
+		if consumed == 0 {
+			// The server has not reported the consumption.
+			return
+		}
+		p.lastConsumedUnits.Store(consumed)
+		if consumed > cost {
+			p.limiter.Report(consumed - cost)
+		}
+	}()
+
+	return p.sessionPool.With(ctx, f, opts...)
+}
+
+func (p *rateLimitedPool) Close(ctx context.Context) error {
+	poolErr := p.sessionPool.Close(ctx)
+	limiterErr := p.limiter.Close(ctx)
+
+	return xerrors.WithStackTrace(xerrors.Join(poolErr, limiterErr))
+}
@@ -0,0 +1,118 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/meta"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/pool"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+)
+
+type testRateLimiter struct {
+	err      error
+	taken    []uint64
+	reported []uint64
+	closed   bool
+}
+
+func (l *testRateLimiter) Wait(ctx context.Context, n uint64) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.taken = append(l.taken, n)
+
+	return nil
+}
+
+func (l *testRateLimiter) Report(n uint64) {
+	l.reported = append(l.reported, n)
+}
+
+func (l *testRateLimiter) Close(ctx context.Context) error {
+	l.closed = true
+
+	return nil
+}
+
+// testConsumingPool calls the operation and reports the consumed units in the trailers
+type testConsumingPool struct {
+	consumedUnits uint64
+	calls         int
+}
+
+func (p *testConsumingPool) Close(ctx context.Context) error {
+	return nil
+}
+
+func (p *testConsumingPool) Stats() pool.Stats {
+	return pool.Stats{}
+}
+
+func (p *testConsumingPool) With(
+	ctx context.Context,
+	f func(ctx context.Context, s *Session) error,
+	opts ...retry.Option,
+) error {
+	p.calls++
+	defer meta.CallTrailerCallback(ctx, metadata.Pairs(
+		meta.HeaderConsumedUnits, strconv.FormatUint(p.consumedUnits, 10),
+	))
+
+	return f(ctx, nil)
+}
+
+func TestRateLimitedPool(t *testing.T) {
+	noop := func(ctx context.Context, s *Session) error {
+		return nil
+	}
+	t.Run("DefaultCost", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		limiter := &testRateLimiter{}
+		p := newRateLimitedPool(&testConsumingPool{consumedUnits: 5}, limiter, nil)
+
+		require.NoError(t, p.With(ctx, noop))
+		require.NoError(t, p.With(ctx, noop))
+		require.Equal(t, []uint64{1, 1}, limiter.taken)
+		require.Equal(t, []uint64{4, 4}, limiter.reported, "the consumption over the taken amount is reported")
+	})
+	t.Run("CostFromConsumedUnits", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		limiter := &testRateLimiter{}
+		p := newRateLimitedPool(&testConsumingPool{consumedUnits: 5}, limiter, func(lastConsumedUnits uint64) uint64 {
+			if lastConsumedUnits == 0 {
+				return 10
+			}
+
+			return lastConsumedUnits
+		})
+
+		require.NoError(t, p.With(ctx, noop))
+		require.NoError(t, p.With(ctx, noop))
+		require.Equal(t, []uint64{10, 5}, limiter.taken)
+		require.Empty(t, limiter.reported)
+	})
+	t.Run("LimiterError", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		testErr := errors.New("test")
+		sessions := &testConsumingPool{}
+		p := newRateLimitedPool(sessions, &testRateLimiter{err: testErr}, nil)
+
+		require.ErrorIs(t, p.With(ctx, noop), testErr)
+		require.Zero(t, sessions.calls)
+	})
+	t.Run("Close", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		limiter := &testRateLimiter{}
+		p := newRateLimitedPool(&testConsumingPool{}, limiter, nil)
+
+		require.NoError(t, p.Close(ctx))
+		require.True(t, limiter.closed)
+	})
+}
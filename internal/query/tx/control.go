@@ -43,6 +43,25 @@ func (ctrl *Control) Selector() Selector {
 	return ctrl.selector
 }
 
+// IsReadOnly reports whether the control begins a read-only transaction and commits it with the same query
+func (ctrl *Control) IsReadOnly() bool {
+	if ctrl == nil || ctrl.selector == nil || !ctrl.Commit {
+		return false
+	}
+
+	a := allocator.New()
+	defer a.Free()
+
+	switch ctrl.ToYDB(a).GetBeginTx().GetTxMode().(type) {
+	case *Ydb_Query.TransactionSettings_SnapshotReadOnly,
+		*Ydb_Query.TransactionSettings_StaleReadOnly,
+		*Ydb_Query.TransactionSettings_OnlineReadOnly:
+		return true
+	default:
+		return false
+	}
+}
+
 var (
 	_ ControlOption = beginTxOptions{}
 	_ Selector      = beginTxOptions{}
@@ -0,0 +1,78 @@
+package options
+
+import (
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+const (
+	DefaultLimiterMinBatch       = 1
+	DefaultLimiterMaxBatch       = 1000
+	DefaultLimiterReportInterval = time.Second
+	DefaultLimiterRetryDelay     = 100 * time.Millisecond
+)
+
+type LimiterConfig struct {
+	MinBatch       uint64
+	MaxBatch       uint64
+	ReportInterval time.Duration
+	RetryDelay     time.Duration
+	Clock          clockwork.Clock
+}
+
+type LimiterOption func(c *LimiterConfig)
+
+func WithLimiterBatch(minBatch, maxBatch uint64) LimiterOption {
+	return func(c *LimiterConfig) {
+		c.MinBatch = minBatch
+		c.MaxBatch = maxBatch
+	}
+}
+
+func WithLimiterReportInterval(interval time.Duration) LimiterOption {
+	return func(c *LimiterConfig) {
+		c.ReportInterval = interval
+	}
+}
+
+func WithLimiterRetryDelay(delay time.Duration) LimiterOption {
+	return func(c *LimiterConfig) {
+		c.RetryDelay = delay
+	}
+}
+
+func WithLimiterClock(clock clockwork.Clock) LimiterOption {
+	return func(c *LimiterConfig) {
+		c.Clock = clock
+	}
+}
+
+func NewLimiterConfig(opts ...LimiterOption) LimiterConfig {
+	c := LimiterConfig{
+		MinBatch:       DefaultLimiterMinBatch,
+		MaxBatch:       DefaultLimiterMaxBatch,
+		ReportInterval: DefaultLimiterReportInterval,
+		RetryDelay:     DefaultLimiterRetryDelay,
+		Clock:          clockwork.NewRealClock(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	if c.MinBatch == 0 {
+		c.MinBatch = 1
+	}
+	if c.MaxBatch < c.MinBatch {
+		c.MaxBatch = c.MinBatch
+	}
+	if c.ReportInterval <= 0 {
+		c.ReportInterval = DefaultLimiterReportInterval
+	}
+	if c.RetryDelay <= 0 {
+		c.RetryDelay = DefaultLimiterRetryDelay
+	}
+
+	return c
+}
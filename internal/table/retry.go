@@ -66,7 +66,7 @@ func retryBackoff(
 ) error {
 	return pool.With(ctx, func(ctx context.Context, s *session) error {
 		if err := op(ctx, s); err != nil {
-			s.checkError(err)
+			s.checkError(xcontext.ClassifyError(ctx, err))
 
 			return xerrors.WithStackTrace(err)
 		}
@@ -84,6 +84,7 @@ func (c *Client) retryOptions(opts ...table.Option) *table.Options {
 		RetryOptions: []retry.Option{
 			retry.WithTrace(c.config.TraceRetry()),
 			retry.WithBudget(c.config.RetryBudget()),
+			retry.WithErrorClassifier(c.config.RetryErrorClassifier()),
 		},
 	}
 	for _, opt := range opts {
@@ -0,0 +1,20 @@
+package topic
+
+import "time"
+
+type ResetConsumerTargetType int
+
+const (
+	ResetConsumerTargetUnknown ResetConsumerTargetType = iota
+	ResetConsumerTargetEarliest
+	ResetConsumerTargetLatest
+	ResetConsumerTargetTimestamp
+)
+
+// ResetConsumerTarget is position in partitions, which will be set as committed offset of consumer
+type ResetConsumerTarget struct {
+	Type ResetConsumerTargetType
+
+	// Timestamp is used for ResetConsumerTargetTimestamp type only
+	Timestamp time.Time
+}
@@ -3,6 +3,8 @@ package topicclientinternal
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Topic_V1"
 	"google.golang.org/grpc"
@@ -16,6 +18,7 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/topic/topicreaderinternal"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/topic/topicwriterinternal"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/tx"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topiclistener"
@@ -26,7 +29,15 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
-var errUnsupportedTransactionType = xerrors.Wrap(errors.New("ydb: unsuppotred transaction type. Use transaction from Driver().Query().DoTx(...)")) //nolint:lll
+var (
+	errUnsupportedTransactionType = xerrors.Wrap(errors.New("ydb: unsuppotred transaction type. Use transaction from Driver().Query().DoTx(...)")) //nolint:lll
+	errUnknownResetConsumerTarget = xerrors.Wrap(errors.New("ydb: unknown reset consumer target"))
+	errFindOffsetTimeout          = xerrors.Wrap(errors.New("ydb: timeout of find offset by timestamp"))
+)
+
+// findOffsetReadTimeout bounds the read of the first message written after the timestamp, because the messages
+// may be deleted by the retention after the description of the partition
+const findOffsetReadTimeout = 10 * time.Second
 
 type Client struct {
 	cfg                    topic.Config
@@ -102,6 +113,39 @@ func (c *Client) Alter(ctx context.Context, path string, opts ...topicoptions.Al
 	return call(ctx)
 }
 
+// CommitOffset set committed offset of the consumer for the partition without active reader
+func (c *Client) CommitOffset(
+	ctx context.Context,
+	path string,
+	partitionID int64,
+	consumer string,
+	offset int64,
+) error {
+	req := &rawtopic.CommitOffsetRequest{
+		OperationParams: c.defaultOperationParams,
+		Path:            path,
+		PartitionID:     partitionID,
+		Consumer:        consumer,
+		Offset:          offset,
+	}
+
+	call := func(ctx context.Context) error {
+		_, commitErr := c.rawClient.CommitOffset(ctx, req)
+
+		return commitErr
+	}
+
+	if c.cfg.AutoRetry() {
+		return retry.Retry(ctx, call,
+			retry.WithIdempotent(true),
+			retry.WithTrace(c.cfg.TraceRetry()),
+			retry.WithBudget(c.cfg.RetryBudget()),
+		)
+	}
+
+	return call(ctx)
+}
+
 // Create new topic
 func (c *Client) Create(
 	ctx context.Context,
@@ -258,6 +302,164 @@ func (c *Client) Drop(ctx context.Context, path string, opts ...topicoptions.Dro
 	return call(ctx)
 }
 
+// ResetConsumer set committed offsets of the consumer for all partitions of the topic to the target position.
+// Offsets of all partitions calculated before commit any of them.
+func (c *Client) ResetConsumer(
+	ctx context.Context,
+	path string,
+	consumer string,
+	target topicoptions.ResetConsumerTarget,
+) error {
+	description, err := c.DescribeTopicConsumer(ctx, path, consumer, topicoptions.IncludeConsumerStats())
+	if err != nil {
+		return err
+	}
+
+	offsets := make([]int64, len(description.Partitions))
+	for i := range description.Partitions {
+		offsets[i], err = c.resetConsumerOffset(ctx, path, consumer, &description.Partitions[i], target)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range description.Partitions {
+		err = c.CommitOffset(ctx, path, description.Partitions[i].PartitionID, consumer, offsets[i])
+		if err != nil {
+			return xerrors.WithStackTrace(fmt.Errorf(
+				"ydb: failed to reset consumer %q offset for partition %v: %w",
+				consumer,
+				description.Partitions[i].PartitionID,
+				err,
+			))
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) resetConsumerOffset(
+	ctx context.Context,
+	path string,
+	consumer string,
+	partition *topictypes.DescribeConsumerPartitionInfo,
+	target topicoptions.ResetConsumerTarget,
+) (int64, error) {
+	partitionOffsets := partition.PartitionStats.PartitionsOffset
+
+	switch target.Type {
+	case topic.ResetConsumerTargetEarliest:
+		return partitionOffsets.Start, nil
+	case topic.ResetConsumerTargetLatest:
+		return partitionOffsets.End, nil
+	case topic.ResetConsumerTargetTimestamp:
+		lastWriteTime := partition.PartitionStats.LastWriteTime
+		if partitionOffsets.Start == partitionOffsets.End ||
+			(lastWriteTime != nil && lastWriteTime.Before(target.Timestamp)) {
+			// no messages, written after the timestamp
+			return partitionOffsets.End, nil
+		}
+
+		return c.findFirstOffsetWrittenFrom(ctx, path, consumer, partition.PartitionID, target.Timestamp,
+			partitionOffsets.End,
+		)
+	default:
+		return 0, xerrors.WithStackTrace(fmt.Errorf("%w: %v", errUnknownResetConsumerTarget, target.Type))
+	}
+}
+
+// findFirstOffsetWrittenFrom read first message of the partition, written at the time or later.
+// Returns end offset if there are no such messages anymore
+func (c *Client) findFirstOffsetWrittenFrom(
+	ctx context.Context,
+	path string,
+	consumer string,
+	partitionID int64,
+	from time.Time,
+	endOffset int64,
+) (_ int64, resErr error) {
+	reader, err := c.StartReader("", topicoptions.ReadSelectors{
+		{
+			Path:       path,
+			Partitions: []int64{partitionID},
+			ReadFrom:   from,
+		},
+	}, topicoptions.WithReaderWithoutConsumer(false))
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if closeErr := reader.Close(ctx); closeErr != nil && resErr == nil {
+			resErr = closeErr
+		}
+	}()
+
+	describeStartOffset := func(ctx context.Context) (int64, error) {
+		description, err := c.DescribeTopicConsumer(ctx, path, consumer, topicoptions.IncludeConsumerStats())
+		if err != nil {
+			return 0, err
+		}
+		for i := range description.Partitions {
+			if description.Partitions[i].PartitionID == partitionID {
+				return description.Partitions[i].PartitionStats.PartitionsOffset.Start, nil
+			}
+		}
+
+		return 0, xerrors.WithStackTrace(fmt.Errorf("ydb: partition %v not found in topic %q", partitionID, path))
+	}
+
+	return readFirstOffset(ctx, partitionID, endOffset, findOffsetReadTimeout, reader.ReadMessage, describeStartOffset)
+}
+
+// readFirstOffset returns offset of the first read message.
+// If the read timed out, end offset returned only if the start offset of the partition reached it:
+// the messages written after the timestamp were deleted by the retention
+func readFirstOffset(
+	ctx context.Context,
+	partitionID int64,
+	endOffset int64,
+	timeout time.Duration,
+	read func(ctx context.Context) (*topicreader.Message, error),
+	describeStartOffset func(ctx context.Context) (int64, error),
+) (int64, error) {
+	readCtx, cancel := xcontext.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	mess, err := read(readCtx)
+	if err == nil {
+		return mess.Offset, nil
+	}
+
+	if ctx.Err() != nil || readCtx.Err() == nil {
+		return 0, xerrors.WithStackTrace(fmt.Errorf(
+			"ydb: failed to find offset by timestamp for partition %v: %w",
+			partitionID,
+			err,
+		))
+	}
+
+	startOffset, describeErr := describeStartOffset(ctx)
+	if describeErr != nil {
+		return 0, xerrors.WithStackTrace(fmt.Errorf(
+			"ydb: failed to describe partition %v after read timeout: %w",
+			partitionID,
+			describeErr,
+		))
+	}
+	if startOffset >= endOffset {
+		return endOffset, nil
+	}
+
+	return 0, xerrors.WithStackTrace(fmt.Errorf(
+		"%w: partition %v, start offset %v, end offset %v: %w",
+		errFindOffsetTimeout,
+		partitionID,
+		startOffset,
+		endOffset,
+		err,
+	))
+}
+
 // StartListener starts read listen topic with the handler
 // it is fast non block call, connection starts in background
 func (c *Client) StartListener(
@@ -0,0 +1,139 @@
+package topicclientinternal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/topic"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicoptions"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicreader"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topictypes"
+)
+
+func TestResetConsumerOffset(t *testing.T) {
+	ctx := xtest.Context(t)
+	lastWriteTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	partition := func(start, end int64) *topictypes.DescribeConsumerPartitionInfo {
+		p := &topictypes.DescribeConsumerPartitionInfo{PartitionID: 1}
+		p.PartitionStats.PartitionsOffset.Start = start
+		p.PartitionStats.PartitionsOffset.End = end
+		p.PartitionStats.LastWriteTime = &lastWriteTime
+
+		return p
+	}
+	c := &Client{}
+
+	for _, tt := range []struct {
+		name      string
+		partition *topictypes.DescribeConsumerPartitionInfo
+		target    topicoptions.ResetConsumerTarget
+		offset    int64
+	}{
+		{
+			name:      "Earliest",
+			partition: partition(10, 20),
+			target:    topicoptions.ToEarliest(),
+			offset:    10,
+		},
+		{
+			name:      "Latest",
+			partition: partition(10, 20),
+			target:    topicoptions.ToLatest(),
+			offset:    20,
+		},
+		{
+			name:      "TimestampEmptyPartition",
+			partition: partition(20, 20),
+			target:    topicoptions.ToTimestamp(lastWriteTime.Add(-time.Hour)),
+			offset:    20,
+		},
+		{
+			name:      "TimestampAfterLastWrite",
+			partition: partition(10, 20),
+			target:    topicoptions.ToTimestamp(lastWriteTime.Add(time.Second)),
+			offset:    20,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := c.resetConsumerOffset(ctx, "topic", "consumer", tt.partition, tt.target)
+			require.NoError(t, err)
+			require.Equal(t, tt.offset, offset)
+		})
+	}
+
+	_, err := c.resetConsumerOffset(ctx, "topic", "consumer", partition(10, 20), topicoptions.ResetConsumerTarget{
+		Type: topic.ResetConsumerTargetType(100),
+	})
+	require.ErrorIs(t, err, errUnknownResetConsumerTarget)
+}
+
+func TestReadFirstOffset(t *testing.T) {
+	describeStartOffset := func(startOffset int64) func(ctx context.Context) (int64, error) {
+		return func(ctx context.Context) (int64, error) {
+			return startOffset, nil
+		}
+	}
+	readTimeout := func(ctx context.Context) (*topicreader.Message, error) {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	t.Run("Message", func(t *testing.T) {
+		offset, err := readFirstOffset(xtest.Context(t), 1, 20, time.Minute,
+			func(ctx context.Context) (*topicreader.Message, error) {
+				mess := &topicreader.Message{}
+				mess.Offset = 15
+
+				return mess, nil
+			},
+			func(ctx context.Context) (int64, error) {
+				t.Fatal("the partition must not be described")
+
+				return 0, nil
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, int64(15), offset)
+	})
+	t.Run("MessagesDeleted", func(t *testing.T) {
+		offset, err := readFirstOffset(xtest.Context(t), 1, 20, time.Millisecond, readTimeout, describeStartOffset(20))
+		require.NoError(t, err)
+		require.Equal(t, int64(20), offset, "the read is bounded by the timeout")
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		_, err := readFirstOffset(xtest.Context(t), 1, 20, time.Millisecond, readTimeout, describeStartOffset(5))
+		require.ErrorIs(t, err, errFindOffsetTimeout)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("DescribeError", func(t *testing.T) {
+		testErr := errors.New("test")
+		_, err := readFirstOffset(xtest.Context(t), 1, 20, time.Millisecond, readTimeout,
+			func(ctx context.Context) (int64, error) {
+				return 0, testErr
+			},
+		)
+		require.ErrorIs(t, err, testErr)
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(xtest.Context(t))
+		cancel()
+		_, err := readFirstOffset(ctx, 1, 20, time.Minute, readTimeout, describeStartOffset(20))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("Error", func(t *testing.T) {
+		testErr := errors.New("test")
+		_, err := readFirstOffset(xtest.Context(t), 1, 20, time.Minute,
+			func(ctx context.Context) (*topicreader.Message, error) {
+				return nil, testErr
+			},
+			describeStartOffset(20),
+		)
+		require.ErrorIs(t, err, testErr)
+	})
+}
@@ -14,6 +14,7 @@ type StreamListenerConfig struct {
 	Selectors              []*topicreadercommon.PublicReadSelector
 	Consumer               string
 	ConnectWithoutConsumer bool
+	Middlewares            []topicreadercommon.PublicReaderMiddleware
 	readerID               int64
 }
 
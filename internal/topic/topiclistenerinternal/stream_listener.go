@@ -29,6 +29,8 @@ type streamListener struct {
 	handler     EventHandler
 	sessionID   string
 
+	messageHandler topicreadercommon.PublicReaderMessageHandler
+
 	background       background.Worker
 	sessions         *topicreadercommon.PartitionSessionStorage
 	sessionIDCounter *atomic.Int64
@@ -139,6 +141,7 @@ func (l *streamListener) initVars(sessionIDCounter *atomic.Int64) {
 	if l.cfg == nil {
 		l.cfg = &StreamListenerConfig{}
 	}
+	l.messageHandler = topicreadercommon.ChainReaderMiddlewares(l.cfg.Middlewares)
 }
 
 //nolint:funlen
@@ -399,6 +402,22 @@ func (l *streamListener) onReadResponse(m *rawtopicreader.ReadResponse) error {
 	}
 
 	for _, batch := range batches {
+		if l.messageHandler != nil {
+			if err = topicreadercommon.ApplyMessageHandler(batch.Context(), l.messageHandler, batch); err != nil {
+				return err
+			}
+			if len(batch.Messages) == 0 {
+				// all messages skipped by middlewares, confirm them without call handler
+				if !l.cfg.ConnectWithoutConsumer {
+					if err = l.sendCommit(batch); err != nil {
+						return err
+					}
+				}
+
+				continue
+			}
+		}
+
 		if err = l.handler.OnReadMessages(batch.Context(), NewPublicReadMessages(
 			topicreadercommon.BatchGetPartitionSession(batch).ToPublic(),
 			batch,
@@ -250,6 +250,56 @@ func TestCommitBatch(t *testing.T) {
 		require.Equal(t, 1, commitCounter)
 	})
 
+	t.Run("CommitSkippedByMiddlewares", func(t *testing.T) {
+		e := fixenv.New(t)
+
+		const (
+			startOffset = 86
+			endOffset   = 88
+		)
+		PartitionSession(e).SetLastReceivedMessageOffset(startOffset - 1)
+		StreamListener(e).messageHandler = func(ctx context.Context, msg *topicreadercommon.PublicMessage) (bool, error) {
+			return false, nil
+		}
+
+		// handler must not be called for batch without messages
+		EventHandlerMock(e).EXPECT().OnReadMessages(gomock.Any(), gomock.Any()).Times(0)
+		StreamMock(e).EXPECT().Send(&rawtopicreader.CommitOffsetRequest{
+			CommitOffsets: []rawtopicreader.PartitionCommitOffset{
+				{
+					PartitionSessionID: PartitionSession(e).StreamPartitionSessionID,
+					Offsets: []rawtopiccommon.OffsetRange{
+						{
+							Start: startOffset,
+							End:   endOffset,
+						},
+					},
+				},
+			},
+		}).Return(nil)
+
+		StreamListener(e).onReceiveServerMessage(sf.Context(e), &rawtopicreader.ReadResponse{
+			ServerMessageMetadata: rawtopiccommon.ServerMessageMetadata{
+				Status: rawydb.StatusSuccess,
+			},
+			BytesSize: 10,
+			PartitionData: []rawtopicreader.PartitionData{
+				{
+					PartitionSessionID: PartitionSession(e).StreamPartitionSessionID,
+					Batches: []rawtopicreader.Batch{
+						{
+							Codec: rawtopiccommon.CodecRaw,
+							MessageData: []rawtopicreader.MessageData{
+								{Offset: startOffset},
+								{Offset: endOffset - 1},
+							},
+						},
+					},
+				},
+			},
+		})
+	})
+
 	t.Run("CommitWithAck", func(t *testing.T) {
 		e := fixenv.New(t)
 
@@ -23,6 +23,7 @@ func NewDecoderMap() DecoderMap {
 			rawtopiccommon.CodecGzip: func(input io.Reader) (io.Reader, error) {
 				return gzip.NewReader(input)
 			},
+			rawtopiccommon.CodecZstd: NewZstdDecoderFunc(),
 		},
 	}
 }
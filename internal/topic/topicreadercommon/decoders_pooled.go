@@ -0,0 +1,85 @@
+package topicreadercommon
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// NewZstdDecoderFunc create zstd decoders with reuse instances through pool.
+// Decoder returns to the pool after read all content.
+func NewZstdDecoderFunc() PublicCreateDecoderFunc {
+	var pool sync.Pool
+
+	return func(input io.Reader) (io.Reader, error) {
+		decoder, ok := pool.Get().(*zstd.Decoder)
+		if ok {
+			if err := decoder.Reset(input); err != nil {
+				return nil, xerrors.WithStackTrace(err)
+			}
+		} else {
+			var err error
+			decoder, err = zstd.NewReader(input, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				return nil, xerrors.WithStackTrace(err)
+			}
+		}
+
+		return &pooledDecoder{
+			decoder: decoder,
+			release: func() {
+				_ = decoder.Reset(nil)
+				pool.Put(decoder)
+			},
+		}, nil
+	}
+}
+
+// NewLz4DecoderFunc create lz4 frame decoders with reuse instances through pool.
+// Decoder returns to the pool after read all content.
+func NewLz4DecoderFunc() PublicCreateDecoderFunc {
+	var pool sync.Pool
+
+	return func(input io.Reader) (io.Reader, error) {
+		decoder, ok := pool.Get().(*lz4.Reader)
+		if ok {
+			decoder.Reset(input)
+		} else {
+			decoder = lz4.NewReader(input)
+		}
+
+		return &pooledDecoder{
+			decoder: decoder,
+			release: func() {
+				decoder.Reset(nil)
+				pool.Put(decoder)
+			},
+		}, nil
+	}
+}
+
+// pooledDecoder return decoder to the pool after first read error, include io.EOF
+type pooledDecoder struct {
+	decoder io.Reader
+	release func()
+	err     error
+}
+
+func (d *pooledDecoder) Read(p []byte) (n int, err error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+
+	n, err = d.decoder.Read(p)
+	if err != nil {
+		d.err = err
+		d.decoder = nil
+		d.release()
+	}
+
+	return n, err
+}
@@ -75,6 +75,16 @@ func (m *PublicMessage) Read(p []byte) (n int, err error) {
 	return m.data.Read(p)
 }
 
+// ReplaceData replace content of the message, for example by decrypted content in reader middleware.
+// It also reset UncompressedSize to len(data) and allow read the message again.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *PublicMessage) ReplaceData(data []byte) {
+	m.data = newOneTimeReader(bytes.NewReader(data))
+	m.dataConsumed = false
+	m.UncompressedSize = len(data)
+}
+
 // PublicMessageContentUnmarshaler is interface for unmarshal message content
 type PublicMessageContentUnmarshaler interface {
 	// UnmarshalYDBTopicMessage MUST NOT use data after return.
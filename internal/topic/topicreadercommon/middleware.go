@@ -0,0 +1,85 @@
+package topicreadercommon
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopiccommon"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// PublicReaderMessageHandler process the message before it returned to user code.
+// Return keep=false for skip the message, skipped messages committed with next messages of the partition.
+// Error from the handler stop the reader.
+type PublicReaderMessageHandler func(ctx context.Context, msg *PublicMessage) (keep bool, err error)
+
+// PublicReaderMiddleware wrap message handler for add own logic: filter, transform content, collect metrics, etc.
+type PublicReaderMiddleware func(next PublicReaderMessageHandler) PublicReaderMessageHandler
+
+// ChainReaderMiddlewares build message handler from middlewares, first middleware is outer.
+// It returns nil if middlewares is empty.
+func ChainReaderMiddlewares(middlewares []PublicReaderMiddleware) PublicReaderMessageHandler {
+	if len(middlewares) == 0 {
+		return nil
+	}
+
+	var handler PublicReaderMessageHandler = func(ctx context.Context, msg *PublicMessage) (bool, error) {
+		return true, nil
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// ApplyMessageHandler call handler for every message of the batch and remove skipped messages from the batch.
+// Commit range of the batch doesn't change. Commit ranges of kept messages extended for cover skipped messages,
+// then commit of every kept message in order commit all messages without gaps.
+// If all messages skipped - the batch has no messages, but has commit range of skipped messages.
+func ApplyMessageHandler(ctx context.Context, handler PublicReaderMessageHandler, batch *PublicBatch) error {
+	kept := batch.Messages[:0]
+	skippedStart := batch.commitRange.CommitOffsetStart
+	hasSkipped := false
+
+	for _, msg := range batch.Messages {
+		keep, err := handler(ctx, msg)
+		if err != nil {
+			return xerrors.WithStackTrace(err)
+		}
+
+		if !keep {
+			if !hasSkipped {
+				skippedStart = msg.commitRange.CommitOffsetStart
+				hasSkipped = true
+			}
+
+			continue
+		}
+
+		if hasSkipped {
+			msg.commitRange.CommitOffsetStart = skippedStart
+			hasSkipped = false
+		}
+		kept = append(kept, msg)
+	}
+
+	if hasSkipped && len(kept) > 0 {
+		kept[len(kept)-1].commitRange.CommitOffsetEnd = batch.commitRange.CommitOffsetEnd
+	}
+
+	for i := len(kept); i < len(batch.Messages); i++ {
+		batch.Messages[i] = nil
+	}
+	batch.Messages = kept
+
+	return nil
+}
+
+// BatchExtendCommitRangeStart move start of commit range of the batch and its first message to offset.
+// It used for add skipped messages from previous batches to commit range of the batch.
+func BatchExtendCommitRangeStart(batch *PublicBatch, offset rawtopiccommon.Offset) {
+	batch.commitRange.CommitOffsetStart = offset
+	if len(batch.Messages) > 0 {
+		batch.Messages[0].commitRange.CommitOffsetStart = offset
+	}
+}
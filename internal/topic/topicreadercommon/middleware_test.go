@@ -0,0 +1,118 @@
+package topicreadercommon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopiccommon"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+func TestChainReaderMiddlewares(t *testing.T) {
+	require.Nil(t, ChainReaderMiddlewares(nil))
+
+	var calls []string
+	middleware := func(name string) PublicReaderMiddleware {
+		return func(next PublicReaderMessageHandler) PublicReaderMessageHandler {
+			return func(ctx context.Context, msg *PublicMessage) (bool, error) {
+				calls = append(calls, name)
+
+				return next(ctx, msg)
+			}
+		}
+	}
+
+	handler := ChainReaderMiddlewares([]PublicReaderMiddleware{middleware("first"), middleware("second")})
+	keep, err := handler(xtest.Context(t), &PublicMessage{})
+	require.NoError(t, err)
+	require.True(t, keep)
+	require.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestApplyMessageHandler(t *testing.T) {
+	newTestBatch := func(t *testing.T) *PublicBatch {
+		session := &PartitionSession{}
+		var messages []*PublicMessage
+		for offset := int64(1); offset <= 4; offset++ {
+			messages = append(messages, &PublicMessage{
+				Offset: offset,
+				commitRange: CommitRange{
+					CommitOffsetStart: rawtopiccommon.Offset(offset),
+					CommitOffsetEnd:   rawtopiccommon.Offset(offset + 1),
+					PartitionSession:  session,
+				},
+			})
+		}
+		batch, err := NewBatch(session, messages)
+		require.NoError(t, err)
+
+		return batch
+	}
+	skipOffsets := func(offsets ...int64) PublicReaderMessageHandler {
+		return func(ctx context.Context, msg *PublicMessage) (bool, error) {
+			for _, offset := range offsets {
+				if msg.Offset == offset {
+					return false, nil
+				}
+			}
+
+			return true, nil
+		}
+	}
+	messageRanges := func(batch *PublicBatch) [][2]rawtopiccommon.Offset {
+		var res [][2]rawtopiccommon.Offset
+		for _, msg := range batch.Messages {
+			res = append(res, [2]rawtopiccommon.Offset{msg.commitRange.CommitOffsetStart, msg.commitRange.CommitOffsetEnd})
+		}
+
+		return res
+	}
+
+	t.Run("SkipMiddle", func(t *testing.T) {
+		batch := newTestBatch(t)
+		require.NoError(t, ApplyMessageHandler(xtest.Context(t), skipOffsets(2, 3), batch))
+		require.Equal(t, [][2]rawtopiccommon.Offset{{1, 2}, {2, 5}}, messageRanges(batch))
+		require.Equal(t, rawtopiccommon.Offset(1), batch.commitRange.CommitOffsetStart)
+		require.Equal(t, rawtopiccommon.Offset(5), batch.commitRange.CommitOffsetEnd)
+	})
+	t.Run("SkipHeadAndTail", func(t *testing.T) {
+		batch := newTestBatch(t)
+		require.NoError(t, ApplyMessageHandler(xtest.Context(t), skipOffsets(1, 4), batch))
+		require.Equal(t, [][2]rawtopiccommon.Offset{{1, 3}, {3, 5}}, messageRanges(batch))
+	})
+	t.Run("SkipAll", func(t *testing.T) {
+		batch := newTestBatch(t)
+		require.NoError(t, ApplyMessageHandler(xtest.Context(t), skipOffsets(1, 2, 3, 4), batch))
+		require.Empty(t, batch.Messages)
+		require.Equal(t, rawtopiccommon.Offset(1), batch.commitRange.CommitOffsetStart)
+		require.Equal(t, rawtopiccommon.Offset(5), batch.commitRange.CommitOffsetEnd)
+
+		BatchExtendCommitRangeStart(batch, 0)
+		require.Equal(t, rawtopiccommon.Offset(0), batch.commitRange.CommitOffsetStart)
+	})
+	t.Run("Error", func(t *testing.T) {
+		testErr := errors.New("test")
+		batch := newTestBatch(t)
+		err := ApplyMessageHandler(xtest.Context(t), func(ctx context.Context, msg *PublicMessage) (bool, error) {
+			return false, testErr
+		}, batch)
+		require.ErrorIs(t, err, testErr)
+	})
+}
+
+func TestMessage_ReplaceData(t *testing.T) {
+	msg := NewPublicMessageBuilder().DataAndUncompressedSize([]byte("encrypted")).Build()
+	_, err := io.ReadAll(msg)
+	require.NoError(t, err)
+
+	msg.ReplaceData([]byte("plain"))
+	require.Equal(t, len("plain"), msg.UncompressedSize)
+
+	data, err := io.ReadAll(msg)
+	require.NoError(t, err)
+	require.Equal(t, "plain", string(data))
+}
@@ -0,0 +1,17 @@
+package topicreaderinternal
+
+import (
+	"context"
+)
+
+// PublicOffsetStore keep read progress of partitions at external storage instead of/additional to server side consumer
+type PublicOffsetStore interface {
+	// LoadOffset return offset of the next message for read from the partition.
+	// ok=false mean the store has no saved progress for the partition and the reader start read from
+	// server side position.
+	LoadOffset(ctx context.Context, topic string, partitionID int64) (offset int64, ok bool, err error)
+
+	// StoreOffset save offset of the next message for read from the partition.
+	// It calls on every reader commit.
+	StoreOffset(ctx context.Context, topic string, partitionID, offset int64) error
+}
@@ -36,6 +36,10 @@ var (
 	errTopicSelectorsEmpty           = xerrors.Wrap(errors.New("ydb: topic selector for topic reader is empty, see arguments on topic starts"))                             //nolint:lll
 )
 
+var errSetOffsetStoreAndStartOffsetCallback = xerrors.Wrap(errors.New(
+	"ydb: reader has offset store and get partition start offset callback. Only one of them must be set",
+))
+
 var clientSessionCounter atomic.Int64
 
 type partitionSessionID = rawtopicreader.PartitionSessionID
@@ -56,6 +60,12 @@ type topicStreamReaderImpl struct {
 	batcher   *batcher
 	committer *topicreadercommon.Committer
 
+	messageHandler topicreadercommon.PublicReaderMessageHandler
+
+	// skippedCommitRanges contains commit range of skipped by middlewares messages
+	// for partition sessions, which got only skipped messages from last batches
+	skippedCommitRanges map[*topicreadercommon.PartitionSession]topicreadercommon.CommitRange
+
 	stream           topicreadercommon.RawTopicReaderStream
 	readConnectionID string
 	readerID         int64
@@ -75,9 +85,12 @@ type topicStreamReaderConfig struct {
 	CredUpdateInterval              time.Duration
 	Consumer                        string
 	ReadWithoutConsumer             bool
+	AutoPartitioningSupport         bool
 	ReadSelectors                   []*topicreadercommon.PublicReadSelector
 	Trace                           *trace.Topic
 	GetPartitionStartOffsetCallback PublicGetPartitionStartOffsetFunc
+	OffsetStore                     PublicOffsetStore
+	Middlewares                     []topicreadercommon.PublicReaderMiddleware
 	CommitMode                      topicreadercommon.PublicCommitMode
 	Decoders                        topicreadercommon.DecoderMap
 }
@@ -107,6 +120,9 @@ func (cfg *topicStreamReaderConfig) Validate() []error {
 	if cfg.ReadWithoutConsumer && cfg.CommitMode != topicreadercommon.CommitModeNone {
 		validateErrors = append(validateErrors, errCantCommitWithoutConsumer)
 	}
+	if cfg.OffsetStore != nil && cfg.GetPartitionStartOffsetCallback != nil {
+		validateErrors = append(validateErrors, errSetOffsetStoreAndStartOffsetCallback)
+	}
 	if cfg.BufferSizeProtoBytes <= 0 {
 		validateErrors = append(validateErrors, errBufferSize)
 	}
@@ -165,6 +181,9 @@ func newTopicStreamReaderStopped(
 		readConnectionID:      "preinitID-" + readerConnectionID.String(),
 		readerID:              readerID,
 		rawMessagesFromBuffer: make(chan rawtopicreader.ServerMessage, 1),
+		messageHandler:        topicreadercommon.ChainReaderMiddlewares(cfg.Middlewares),
+
+		skippedCommitRanges: make(map[*topicreadercommon.PartitionSession]topicreadercommon.CommitRange),
 	}
 
 	res.backgroundWorkers = *background.NewWorker(stopPump, "topic-reader-stream-background")
@@ -373,12 +392,6 @@ func (r *topicStreamReaderImpl) ReadMessageBatch(
 		return nil, err
 	}
 
-	defer func() {
-		if err == nil {
-			r.freeBufferFromMessages(batch)
-		}
-	}()
-
 	return r.consumeMessagesUntilBatch(ctx, opts)
 }
 
@@ -394,7 +407,15 @@ func (r *topicStreamReaderImpl) consumeMessagesUntilBatch(
 
 		switch {
 		case item.IsBatch():
-			return item.Batch, nil
+			r.freeBufferFromMessages(item.Batch)
+
+			batch, err := r.applyMessageHandler(ctx, item.Batch)
+			if err != nil {
+				return nil, err
+			}
+			if batch != nil {
+				return batch, nil
+			}
 		case item.IsRawMessage():
 			r.sendRawMessageToChannelUnblocked(item.RawMessage)
 		default:
@@ -403,6 +424,43 @@ func (r *topicStreamReaderImpl) consumeMessagesUntilBatch(
 	}
 }
 
+// applyMessageHandler process messages of the batch by middlewares.
+// It returns nil batch if all messages skipped, then commit range of the batch will be added to next batch
+// of the partition session or committed on graceful stop of the partition session.
+func (r *topicStreamReaderImpl) applyMessageHandler(
+	ctx context.Context,
+	batch *topicreadercommon.PublicBatch,
+) (*topicreadercommon.PublicBatch, error) {
+	if r.messageHandler == nil {
+		return batch, nil
+	}
+
+	if err := topicreadercommon.ApplyMessageHandler(ctx, r.messageHandler, batch); err != nil {
+		// messages of the batch lost, reconnect needs for read them again from last committed offset
+		_ = r.CloseWithError(ctx, err)
+
+		return nil, err
+	}
+
+	session := topicreadercommon.BatchGetPartitionSession(batch)
+	r.m.WithLock(func() {
+		if skipped, ok := r.skippedCommitRanges[session]; ok {
+			delete(r.skippedCommitRanges, session)
+			topicreadercommon.BatchExtendCommitRangeStart(batch, skipped.CommitOffsetStart)
+		}
+
+		if len(batch.Messages) == 0 && session.Context().Err() == nil {
+			r.skippedCommitRanges[session] = topicreadercommon.GetCommitRange(batch)
+		}
+	})
+
+	if len(batch.Messages) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	return batch, nil
+}
+
 func (r *topicStreamReaderImpl) sendRawMessageToChannelUnblocked(msg rawtopicreader.ServerMessage) {
 	select {
 	case r.rawMessagesFromBuffer <- msg:
@@ -477,7 +535,14 @@ func (r *topicStreamReaderImpl) onStopPartitionSessionRequestFromBuffer(
 		onDone(err)
 	}()
 
+	skipped, hasSkipped := r.takeSkippedCommitRange(session)
+
 	if msg.Graceful {
+		if hasSkipped {
+			// error is not critical: the skipped messages will be read and skipped again by next reader
+			_ = r.Commit(r.ctx, skipped)
+		}
+
 		session.Close()
 		resp := &rawtopicreader.StopPartitionSessionResponse{
 			PartitionSessionID: session.StreamPartitionSessionID,
@@ -501,6 +566,19 @@ func (r *topicStreamReaderImpl) onStopPartitionSessionRequestFromBuffer(
 	return nil
 }
 
+// takeSkippedCommitRange returns commit range of skipped messages at the end of the partition session
+// and forget it
+func (r *topicStreamReaderImpl) takeSkippedCommitRange(
+	session *topicreadercommon.PartitionSession,
+) (commitRange topicreadercommon.CommitRange, ok bool) {
+	r.m.WithLock(func() {
+		commitRange, ok = r.skippedCommitRanges[session]
+		delete(r.skippedCommitRanges, session)
+	})
+
+	return commitRange, ok
+}
+
 func (r *topicStreamReaderImpl) onPartitionSessionStatusResponseFromBuffer(
 	ctx context.Context,
 	m *rawtopicreader.PartitionSessionStatusResponse,
@@ -543,11 +621,31 @@ func (r *topicStreamReaderImpl) Commit(ctx context.Context, commitRange topicrea
 		return err
 	}
 
+	if r.cfg.OffsetStore != nil {
+		if err = r.storeOffset(ctx, commitRange); err != nil {
+			return err
+		}
+		if !r.cfg.CommitMode.CommitsEnabled() {
+			return nil
+		}
+	}
+
 	return r.committer.Commit(ctx, commitRange)
 }
 
+func (r *topicStreamReaderImpl) storeOffset(ctx context.Context, commitRange topicreadercommon.CommitRange) error {
+	session := commitRange.PartitionSession
+	err := r.cfg.OffsetStore.StoreOffset(ctx, session.Topic, session.PartitionID, commitRange.CommitOffsetEnd.ToInt64())
+	if err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to store offset of topic reader: %w", err))
+	}
+	session.SetCommittedOffsetForward(commitRange.CommitOffsetEnd)
+
+	return nil
+}
+
 func (r *topicStreamReaderImpl) checkCommitRange(commitRange topicreadercommon.CommitRange) error {
-	if r.cfg.CommitMode == topicreadercommon.CommitModeNone {
+	if r.cfg.CommitMode == topicreadercommon.CommitModeNone && r.cfg.OffsetStore == nil {
 		return topicreadercommon.ErrCommitDisabled
 	}
 	session := commitRange.PartitionSession
@@ -612,6 +710,7 @@ func (r *topicStreamReaderImpl) setStarted() error {
 
 func (r *topicStreamReaderImpl) initSession() (err error) {
 	initMessage := topicreadercommon.CreateInitMessage(r.cfg.Consumer, r.cfg.ReadSelectors)
+	initMessage.AutoPartitioningSupport = r.cfg.AutoPartitioningSupport
 
 	onDone := trace.TopicOnReaderInit(r.cfg.Trace, r.readConnectionID, initMessage)
 	defer func() {
@@ -700,6 +799,12 @@ func (r *topicStreamReaderImpl) readMessagesLoop(ctx context.Context) {
 			if err = r.onStopPartitionSessionRequest(m); err != nil {
 				_ = r.CloseWithError(ctx, err)
 
+				return
+			}
+		case *rawtopicreader.EndPartitionSession:
+			if err = r.onEndPartitionSession(m); err != nil {
+				_ = r.CloseWithError(ctx, err)
+
 				return
 			}
 		case *rawtopicreader.CommitOffsetResponse:
@@ -956,6 +1061,19 @@ func (r *topicStreamReaderImpl) onStartPartitionSessionRequestFromBuffer(
 		}
 	}
 
+	if r.cfg.OffsetStore != nil {
+		storedOffset, ok, loadErr := r.cfg.OffsetStore.LoadOffset(session.Context(), session.Topic, session.PartitionID)
+		if loadErr != nil {
+			return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to load offset of topic reader: %w", loadErr))
+		}
+		if ok {
+			forceOffset = &storedOffset
+			var offset rawtopiccommon.Offset
+			offset.FromInt64(storedOffset)
+			session.SetCommittedOffsetForward(offset)
+		}
+	}
+
 	respMessage.ReadOffset.FromInt64Pointer(forceOffset)
 	if r.cfg.CommitMode.CommitsEnabled() {
 		commitOffset = forceOffset
@@ -965,6 +1083,15 @@ func (r *topicStreamReaderImpl) onStartPartitionSessionRequestFromBuffer(
 	return r.send(respMessage)
 }
 
+// onEndPartitionSession called after the partition was splitted or merged and all its messages sent to the reader.
+// Server starts read child partitions after all messages of the parent partition committed,
+// then the reader gets messages of parent partition before messages of its children without additional actions.
+func (r *topicStreamReaderImpl) onEndPartitionSession(m *rawtopicreader.EndPartitionSession) error {
+	_, err := r.sessionController.Get(m.PartitionSessionID)
+
+	return err
+}
+
 func (r *topicStreamReaderImpl) onStopPartitionSessionRequest(m *rawtopicreader.StopPartitionSessionRequest) error {
 	session, err := r.sessionController.Get(m.PartitionSessionID)
 	if err != nil {
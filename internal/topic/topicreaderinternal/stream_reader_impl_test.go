@@ -358,6 +358,367 @@ func TestTopicStreamReaderImpl_Create(t *testing.T) {
 		require.Error(t, err)
 		require.Nil(t, reader)
 	})
+	xtest.TestManyTimesWithName(t, "AutoPartitioningSupport", func(t testing.TB) {
+		mc := gomock.NewController(t)
+		stream := NewMockRawTopicReaderStream(mc)
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(msg rawtopicreader.ClientMessage) error {
+			require.True(t, msg.(*rawtopicreader.InitRequest).AutoPartitioningSupport)
+
+			return nil
+		})
+		stream.EXPECT().Recv().Return(&rawtopicreader.InitResponse{
+			ServerMessageMetadata: rawtopiccommon.ServerMessageMetadata{Status: rawydb.StatusInternalError},
+		}, nil)
+		stream.EXPECT().CloseSend().Return(nil)
+
+		cfg := newTopicStreamReaderConfig()
+		cfg.AutoPartitioningSupport = true
+		reader, err := newTopicStreamReader(nil, topicreadercommon.NextReaderID(), stream, cfg)
+		require.Error(t, err)
+		require.Nil(t, reader)
+	})
+}
+
+func TestTopicStreamReaderImpl_EndPartitionSession(t *testing.T) {
+	xtest.TestManyTimesWithName(t, "OK", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		e.Start()
+
+		e.SendFromServer(&rawtopicreader.EndPartitionSession{
+			PartitionSessionID: e.partitionSessionID,
+			ChildPartitionIDs:  []int64{6, 7},
+		})
+		e.WaitMessageReceived()
+
+		require.NoError(t, e.partitionSession.Context().Err())
+		e.reader.m.WithLock(func() {
+			require.False(t, e.reader.closed)
+		})
+	})
+	xtest.TestManyTimesWithName(t, "UnknownSession", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		e.Start()
+
+		e.SendFromServer(&rawtopicreader.EndPartitionSession{
+			PartitionSessionID: e.partitionSessionID + 1,
+		})
+		xtest.SpinWaitCondition(t, &e.reader.m, func() bool {
+			return e.reader.closed
+		})
+	})
+}
+
+func TestTopicStreamReaderImpl_OffsetStore(t *testing.T) {
+	xtest.TestManyTimesWithName(t, "LoadOnStartPartition", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		store := newTestOffsetStore()
+		store.offsets[testOffsetStoreKey{topic: "/test", partitionID: 7}] = 42
+		e.reader.cfg.OffsetStore = store
+		e.reader.cfg.CommitMode = topicreadercommon.CommitModeNone
+		e.Start()
+
+		readMessagesCtx, readMessagesCtxCancel := xcontext.WithCancel(context.Background())
+		responseSent := make(empty.Chan)
+		e.stream.EXPECT().Send(&rawtopicreader.StartPartitionSessionResponse{
+			PartitionSessionID: 100,
+			ReadOffset:         rawtopicreader.OptionalOffset{Offset: 42, HasValue: true},
+		}).DoAndReturn(func(_ rawtopicreader.ClientMessage) error {
+			close(responseSent)
+			readMessagesCtxCancel()
+
+			return nil
+		})
+
+		e.SendFromServer(&rawtopicreader.StartPartitionSessionRequest{
+			PartitionSession: rawtopicreader.PartitionSession{
+				PartitionSessionID: 100,
+				Path:               "/test",
+				PartitionID:        7,
+			},
+		})
+
+		_, err := e.reader.ReadMessageBatch(readMessagesCtx, newReadMessageBatchOptions())
+		require.Error(t, err)
+		xtest.WaitChannelClosed(t, responseSent)
+
+		session, err := e.reader.sessionController.Get(100)
+		require.NoError(t, err)
+		require.Equal(t, rawtopiccommon.NewOffset(42), session.CommittedOffset())
+	})
+	xtest.TestManyTimesWithName(t, "CommitToStore", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		store := newTestOffsetStore()
+		e.reader.cfg.OffsetStore = store
+		e.reader.cfg.CommitMode = topicreadercommon.CommitModeNone
+		e.Start()
+
+		commitRange := topicreadercommon.CommitRange{
+			CommitOffsetStart: e.partitionSession.CommittedOffset(),
+			CommitOffsetEnd:   e.partitionSession.CommittedOffset() + 5,
+			PartitionSession:  e.partitionSession,
+		}
+		require.NoError(t, e.reader.Commit(e.ctx, commitRange))
+
+		offset, ok, err := store.LoadOffset(e.ctx, e.partitionSession.Topic, e.partitionSession.PartitionID)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, commitRange.CommitOffsetEnd.ToInt64(), offset)
+		require.Equal(t, commitRange.CommitOffsetEnd, e.partitionSession.CommittedOffset())
+	})
+	xtest.TestManyTimesWithName(t, "StoreError", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		store := newTestOffsetStore()
+		store.err = errors.New("test")
+		e.reader.cfg.OffsetStore = store
+		e.reader.cfg.CommitMode = topicreadercommon.CommitModeNone
+		e.Start()
+
+		commitRange := topicreadercommon.CommitRange{
+			CommitOffsetStart: e.partitionSession.CommittedOffset(),
+			CommitOffsetEnd:   e.partitionSession.CommittedOffset() + 5,
+			PartitionSession:  e.partitionSession,
+		}
+		require.ErrorIs(t, e.reader.Commit(e.ctx, commitRange), store.err)
+		require.Equal(t, commitRange.CommitOffsetStart, e.partitionSession.CommittedOffset())
+	})
+	t.Run("ValidateWithStartOffsetCallback", func(t *testing.T) {
+		cfg := newTopicStreamReaderConfig()
+		cfg.Consumer = "test"
+		cfg.ReadSelectors = []*topicreadercommon.PublicReadSelector{{Path: "/test"}}
+		cfg.OffsetStore = newTestOffsetStore()
+		require.Empty(t, cfg.Validate())
+
+		cfg.GetPartitionStartOffsetCallback = func(
+			ctx context.Context,
+			req PublicGetPartitionStartOffsetRequest,
+		) (res PublicGetPartitionStartOffsetResponse, err error) {
+			return res, nil
+		}
+		require.Equal(t, []error{errSetOffsetStoreAndStartOffsetCallback}, cfg.Validate())
+	})
+}
+
+type testOffsetStoreKey struct {
+	topic       string
+	partitionID int64
+}
+
+type testOffsetStore struct {
+	m       sync.Mutex
+	offsets map[testOffsetStoreKey]int64
+	err     error
+}
+
+func newTestOffsetStore() *testOffsetStore {
+	return &testOffsetStore{offsets: make(map[testOffsetStoreKey]int64)}
+}
+
+func (s *testOffsetStore) LoadOffset(
+	ctx context.Context,
+	topic string,
+	partitionID int64,
+) (offset int64, ok bool, err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	offset, ok = s.offsets[testOffsetStoreKey{topic: topic, partitionID: partitionID}]
+
+	return offset, ok, s.err
+}
+
+func (s *testOffsetStore) StoreOffset(ctx context.Context, topic string, partitionID, offset int64) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.offsets[testOffsetStoreKey{topic: topic, partitionID: partitionID}] = offset
+
+	return nil
+}
+
+func TestTopicStreamReaderImpl_Middleware(t *testing.T) {
+	skipSeqNo := func(seqNo int64) topicreadercommon.PublicReaderMiddleware {
+		return func(next topicreadercommon.PublicReaderMessageHandler) topicreadercommon.PublicReaderMessageHandler {
+			return func(ctx context.Context, msg *topicreadercommon.PublicMessage) (bool, error) {
+				if msg.SeqNo == seqNo {
+					return false, nil
+				}
+
+				return next(ctx, msg)
+			}
+		}
+	}
+	readResponse := func(e *streamEnv, messages ...rawtopicreader.MessageData) *rawtopicreader.ReadResponse {
+		return &rawtopicreader.ReadResponse{
+			PartitionData: []rawtopicreader.PartitionData{
+				{
+					PartitionSessionID: e.partitionSessionID,
+					Batches: []rawtopicreader.Batch{
+						{
+							Codec:       rawtopiccommon.CodecRaw,
+							ProducerID:  "1",
+							MessageData: messages,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	xtest.TestManyTimesWithName(t, "SkipMessage", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		e.reader.messageHandler = topicreadercommon.ChainReaderMiddlewares(
+			[]topicreadercommon.PublicReaderMiddleware{skipSeqNo(2)},
+		)
+		e.Start()
+
+		lastOffset := e.partitionSession.LastReceivedMessageOffset()
+		e.SendFromServer(readResponse(&e,
+			rawtopicreader.MessageData{Offset: lastOffset + 1, SeqNo: 1},
+			rawtopicreader.MessageData{Offset: lastOffset + 2, SeqNo: 2},
+			rawtopicreader.MessageData{Offset: lastOffset + 3, SeqNo: 3},
+		))
+
+		opts := newReadMessageBatchOptions()
+		opts.MinCount = 2
+		batch, err := e.reader.ReadMessageBatch(e.ctx, opts)
+		require.NoError(t, err)
+		require.Len(t, batch.Messages, 2)
+		require.Equal(t, int64(1), batch.Messages[0].SeqNo)
+		require.Equal(t, int64(3), batch.Messages[1].SeqNo)
+
+		secondRange := topicreadercommon.GetCommitRange(batch.Messages[1])
+		require.Equal(t, lastOffset+2, secondRange.CommitOffsetStart)
+		require.Equal(t, lastOffset+4, secondRange.CommitOffsetEnd)
+	})
+	xtest.TestManyTimesWithName(t, "SkipWholeBatch", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		e.reader.messageHandler = topicreadercommon.ChainReaderMiddlewares(
+			[]topicreadercommon.PublicReaderMiddleware{skipSeqNo(1)},
+		)
+		e.Start()
+
+		lastOffset := e.partitionSession.LastReceivedMessageOffset()
+		e.SendFromServer(readResponse(&e, rawtopicreader.MessageData{Offset: lastOffset + 1, SeqNo: 1}))
+		e.SendFromServer(readResponse(&e, rawtopicreader.MessageData{Offset: lastOffset + 2, SeqNo: 2}))
+
+		batch, err := e.reader.ReadMessageBatch(e.ctx, newReadMessageBatchOptions())
+		require.NoError(t, err)
+		require.Len(t, batch.Messages, 1)
+		require.Equal(t, int64(2), batch.Messages[0].SeqNo)
+
+		batchRange := topicreadercommon.GetCommitRange(batch)
+		require.Equal(t, lastOffset+1, batchRange.CommitOffsetStart)
+		require.Equal(t, lastOffset+3, batchRange.CommitOffsetEnd)
+		require.Equal(t, batchRange.CommitOffsetStart, topicreadercommon.GetCommitRange(batch.Messages[0]).CommitOffsetStart)
+	})
+	xtest.TestManyTimesWithName(t, "CommitSkippedOnGracefulStop", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		e.reader.messageHandler = topicreadercommon.ChainReaderMiddlewares(
+			[]topicreadercommon.PublicReaderMiddleware{skipSeqNo(1)},
+		)
+
+		committed := e.partitionSession.CommittedOffset()
+		commitReceived := make(empty.Chan)
+		e.stream.EXPECT().Send(&rawtopicreader.CommitOffsetRequest{CommitOffsets: []rawtopicreader.PartitionCommitOffset{
+			{
+				PartitionSessionID: e.partitionSessionID,
+				Offsets: []rawtopiccommon.OffsetRange{
+					{
+						Start: committed,
+						End:   committed + 1,
+					},
+				},
+			},
+		}}).DoAndReturn(func(_ rawtopicreader.ClientMessage) error {
+			close(commitReceived)
+
+			return nil
+		})
+
+		stopPartitionResponseSent := make(empty.Chan)
+		e.stream.EXPECT().Send(&rawtopicreader.StopPartitionSessionResponse{PartitionSessionID: e.partitionSessionID}).
+			DoAndReturn(func(_ rawtopicreader.ClientMessage) error {
+				close(stopPartitionResponseSent)
+
+				return nil
+			})
+
+		e.Start()
+
+		go func() {
+			e.SendFromServer(readResponse(&e, rawtopicreader.MessageData{Offset: committed, SeqNo: 1}))
+			e.SendFromServer(&rawtopicreader.StopPartitionSessionRequest{
+				PartitionSessionID: e.partitionSessionID,
+				Graceful:           true,
+			})
+		}()
+
+		readCtx, readCtxCancel := xcontext.WithCancel(e.ctx)
+		go func() {
+			<-stopPartitionResponseSent
+			readCtxCancel()
+		}()
+
+		_, err := e.reader.ReadMessageBatch(readCtx, newReadMessageBatchOptions())
+		require.ErrorIs(t, err, context.Canceled)
+		xtest.WaitChannelClosed(t, commitReceived)
+		e.reader.m.WithLock(func() {
+			require.Empty(t, e.reader.skippedCommitRanges)
+		})
+	})
+	xtest.TestManyTimesWithName(t, "ForgetSkippedOnStop", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		e.reader.messageHandler = topicreadercommon.ChainReaderMiddlewares(
+			[]topicreadercommon.PublicReaderMiddleware{skipSeqNo(1)},
+		)
+		readCtx, readCtxCancel := xcontext.WithCancel(e.ctx)
+		e.reader.cfg.Trace.OnReaderPartitionReadStopResponse = func(
+			trace.TopicReaderPartitionReadStopResponseStartInfo,
+		) func(trace.TopicReaderPartitionReadStopResponseDoneInfo) {
+			return func(trace.TopicReaderPartitionReadStopResponseDoneInfo) {
+				readCtxCancel()
+			}
+		}
+		e.Start()
+
+		committed := e.partitionSession.CommittedOffset()
+		go func() {
+			e.SendFromServer(readResponse(&e, rawtopicreader.MessageData{Offset: committed, SeqNo: 1}))
+			xtest.SpinWaitCondition(t, &e.reader.m, func() bool {
+				return len(e.reader.skippedCommitRanges) == 1
+			})
+			e.SendFromServer(&rawtopicreader.StopPartitionSessionRequest{
+				PartitionSessionID: e.partitionSessionID,
+				Graceful:           false,
+			})
+		}()
+
+		_, err := e.reader.ReadMessageBatch(readCtx, newReadMessageBatchOptions())
+		require.ErrorIs(t, err, context.Canceled)
+		e.reader.m.WithLock(func() {
+			require.Empty(t, e.reader.skippedCommitRanges)
+		})
+	})
+	xtest.TestManyTimesWithName(t, "Error", func(t testing.TB) {
+		e := newTopicReaderTestEnv(t)
+		testErr := errors.New("test")
+		e.reader.messageHandler = func(ctx context.Context, msg *topicreadercommon.PublicMessage) (bool, error) {
+			return false, testErr
+		}
+		e.Start()
+
+		lastOffset := e.partitionSession.LastReceivedMessageOffset()
+		e.SendFromServer(readResponse(&e, rawtopicreader.MessageData{Offset: lastOffset + 1, SeqNo: 1}))
+
+		_, err := e.reader.ReadMessageBatch(e.ctx, newReadMessageBatchOptions())
+		require.ErrorIs(t, err, testErr)
+		e.reader.m.WithLock(func() {
+			require.True(t, e.reader.closed)
+		})
+	})
 }
 
 func TestTopicStreamReaderImpl_WaitInit(t *testing.T) {
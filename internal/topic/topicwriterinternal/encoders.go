@@ -30,6 +30,7 @@ func NewEncoderMap() *EncoderMap {
 			rawtopiccommon.CodecGzip: func(writer io.Writer) (io.WriteCloser, error) {
 				return gzip.NewWriter(writer), nil
 			},
+			rawtopiccommon.CodecZstd: NewZstdEncoderFunc(0),
 		},
 	}
 }
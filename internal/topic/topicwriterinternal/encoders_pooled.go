@@ -0,0 +1,127 @@
+package topicwriterinternal
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+var errEncoderClosed = xerrors.Wrap(errors.New("ydb: write to closed encoder"))
+
+// NewZstdEncoderFunc create zstd encoders with reuse instances through pool.
+// level is zstd compression level from 1 (fastest) to 22 (best compression), 0 mean default level.
+func NewZstdEncoderFunc(level int) PublicCreateEncoderFunc {
+	encoderLevel := zstd.SpeedDefault
+	if level > 0 {
+		encoderLevel = zstd.EncoderLevelFromZstd(level)
+	}
+
+	var pool sync.Pool
+
+	return func(writer io.Writer) (io.WriteCloser, error) {
+		encoder, ok := pool.Get().(*zstd.Encoder)
+		if ok {
+			encoder.Reset(writer)
+		} else {
+			var err error
+			encoder, err = zstd.NewWriter(writer,
+				zstd.WithEncoderLevel(encoderLevel),
+				zstd.WithEncoderConcurrency(1),
+			)
+			if err != nil {
+				return nil, xerrors.WithStackTrace(err)
+			}
+		}
+
+		return &pooledEncoder{
+			encoder: encoder,
+			release: func() {
+				encoder.Reset(nil)
+				pool.Put(encoder)
+			},
+		}, nil
+	}
+}
+
+var lz4Levels = []lz4.CompressionLevel{
+	lz4.Fast,
+	lz4.Level1,
+	lz4.Level2,
+	lz4.Level3,
+	lz4.Level4,
+	lz4.Level5,
+	lz4.Level6,
+	lz4.Level7,
+	lz4.Level8,
+	lz4.Level9,
+}
+
+// NewLz4EncoderFunc create lz4 frame encoders with reuse instances through pool.
+// level is lz4 compression level from 0 (fast) to 9 (best compression).
+func NewLz4EncoderFunc(level int) PublicCreateEncoderFunc {
+	if level < 0 {
+		level = 0
+	}
+	if level >= len(lz4Levels) {
+		level = len(lz4Levels) - 1
+	}
+	compressionLevel := lz4Levels[level]
+
+	var pool sync.Pool
+
+	return func(writer io.Writer) (io.WriteCloser, error) {
+		encoder, ok := pool.Get().(*lz4.Writer)
+		if ok {
+			encoder.Reset(writer)
+		} else {
+			encoder = lz4.NewWriter(writer)
+			err := encoder.Apply(lz4.CompressionLevelOption(compressionLevel), lz4.ConcurrencyOption(1))
+			if err != nil {
+				return nil, xerrors.WithStackTrace(err)
+			}
+		}
+
+		return &pooledEncoder{
+			encoder: encoder,
+			release: func() {
+				encoder.Reset(nil)
+				pool.Put(encoder)
+			},
+		}, nil
+	}
+}
+
+// pooledEncoder return encoder to the pool after close
+type pooledEncoder struct {
+	encoder io.WriteCloser
+	release func()
+}
+
+func (e *pooledEncoder) Write(p []byte) (n int, err error) {
+	if e.encoder == nil {
+		return 0, xerrors.WithStackTrace(errEncoderClosed)
+	}
+
+	return e.encoder.Write(p)
+}
+
+func (e *pooledEncoder) Close() error {
+	if e.encoder == nil {
+		return nil
+	}
+
+	err := e.encoder.Close()
+	e.encoder = nil
+	if err != nil {
+		// encoder state is unknown after error, don't reuse it
+		return xerrors.WithStackTrace(err)
+	}
+	e.release()
+
+	return nil
+}
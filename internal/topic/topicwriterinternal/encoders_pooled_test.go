@@ -0,0 +1,83 @@
+package topicwriterinternal
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopiccommon"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/topic/topicreadercommon"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func TestPooledEncoders(t *testing.T) {
+	const lz4Codec = rawtopiccommon.CodecCustomerFirst
+
+	decoders := topicreadercommon.NewDecoderMap()
+	decoders.AddDecoder(lz4Codec, topicreadercommon.NewLz4DecoderFunc())
+
+	table := []struct {
+		name    string
+		codec   rawtopiccommon.Codec
+		encoder PublicCreateEncoderFunc
+	}{
+		{"ZstdDefault", rawtopiccommon.CodecZstd, NewZstdEncoderFunc(0)},
+		{"ZstdFastest", rawtopiccommon.CodecZstd, NewZstdEncoderFunc(1)},
+		{"ZstdBest", rawtopiccommon.CodecZstd, NewZstdEncoderFunc(22)},
+		{"Lz4Fast", lz4Codec, NewLz4EncoderFunc(0)},
+		{"Lz4Best", lz4Codec, NewLz4EncoderFunc(9)},
+		{"Lz4OutOfRange", lz4Codec, NewLz4EncoderFunc(100)},
+	}
+
+	for _, test := range table {
+		t.Run(test.name, func(t *testing.T) {
+			// second iterations use encoders and decoders from pools
+			for i := 0; i < 3; i++ {
+				content := strings.Repeat("test content ", 100+i)
+
+				buf := &bytes.Buffer{}
+				encoder, err := test.encoder(buf)
+				require.NoError(t, err)
+				_, err = encoder.Write([]byte(content))
+				require.NoError(t, err)
+				require.NoError(t, encoder.Close())
+				require.NoError(t, encoder.Close())
+				require.Less(t, buf.Len(), len(content))
+
+				_, err = encoder.Write([]byte(content))
+				require.ErrorIs(t, err, errEncoderClosed)
+
+				decoder, err := decoders.Decode(test.codec, bytes.NewReader(buf.Bytes()))
+				require.NoError(t, err)
+				decoded, err := io.ReadAll(decoder)
+				require.NoError(t, err)
+				require.Equal(t, content, string(decoded))
+
+				_, err = decoder.Read(make([]byte, 1))
+				require.ErrorIs(t, err, io.EOF)
+			}
+		})
+	}
+}
+
+func TestEncoderSelector_SelectZstd(t *testing.T) {
+	encoders := NewEncoderMap()
+	s := NewEncoderSelector(encoders, rawtopiccommon.SupportedCodecs{
+		rawtopiccommon.CodecRaw,
+		rawtopiccommon.CodecGzip,
+		rawtopiccommon.CodecZstd,
+	}, 1, &trace.Topic{}, "", "")
+
+	var messages []messageWithDataContent
+	for i := 0; i < 10; i++ {
+		data := strings.Repeat("zstd compress repeated data better than gzip ", 1000)
+		messages = append(messages, newMessageDataWithContent(PublicMessage{Data: strings.NewReader(data)}, encoders))
+	}
+
+	codec, err := s.measureCodecs(messages)
+	require.NoError(t, err)
+	require.Equal(t, rawtopiccommon.CodecZstd, codec)
+}
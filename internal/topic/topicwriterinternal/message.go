@@ -69,6 +69,7 @@ type messageWithDataContent struct {
 	rawBuf              bytes.Buffer
 	encoders            *EncoderMap
 	BufUncompressedSize int
+	future              *PublicWriteFuture
 }
 
 func (m *messageWithDataContent) GetEncodedBytes(codec rawtopiccommon.Codec) ([]byte, error) {
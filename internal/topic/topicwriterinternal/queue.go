@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
+
+	"github.com/jonboulle/clockwork"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/empty"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopicwriter"
@@ -30,7 +33,17 @@ const (
 )
 
 type messageQueue struct {
-	OnAckReceived func(count int)
+	// OnAckReceived called with count and summary uncompressed size of messages, removed from the queue
+	OnAckReceived  func(count, size int)
+	OnAcksReceived func(acks []rawtopicwriter.WriteAck)
+
+	// maxBatchBytes limit uncompressed size of messages, returned by GetMessagesForSend. 0 mean unlimited.
+	// Batch contains one message at least, even if the message is more than the limit.
+	maxBatchBytes int
+
+	// linger is time for wait more messages for send, before send not full batch
+	linger time.Duration
+	clock  clockwork.Clock
 
 	hasNewMessages    empty.Chan
 	closedErr         error
@@ -43,6 +56,8 @@ type messageQueue struct {
 	lastWrittenIndex          int
 	lastSentIndex             int
 	lastSeqNo                 int64
+	unsentSince               time.Time
+	flushRequested            bool
 
 	messagesByOrder map[int]messageWithDataContent
 	seqNoToOrderID  map[int64]int
@@ -55,6 +70,7 @@ func newMessageQueue() messageQueue {
 		hasNewMessages:  make(empty.Chan, 1),
 		closedChan:      make(empty.Chan),
 		lastSeqNo:       -1,
+		clock:           clockwork.NewRealClock(),
 	}
 }
 
@@ -88,6 +104,10 @@ func (q *messageQueue) addMessages(messages []messageWithDataContent, needWaiter
 		return waiter, err
 	}
 
+	if q.lastWrittenIndex == q.lastSentIndex {
+		q.unsentSince = q.clock.Now()
+	}
+
 	for i := range messages {
 		messageIndex := q.addMessageNeedLock(messages[i])
 
@@ -147,13 +167,22 @@ func (q *messageQueue) addMessageNeedLock(
 }
 
 func (q *messageQueue) AcksReceived(acks []rawtopicwriter.WriteAck) error {
+	return q.AcksReceivedFromPartition(0, acks)
+}
+
+// AcksReceivedFromPartition remove acked messages from the queue and resolve write futures of the messages
+func (q *messageQueue) AcksReceivedFromPartition(partitionID int64, acks []rawtopicwriter.WriteAck) error {
 	ackReceivedCounter := 0
+	ackReceivedSize := 0
 	q.m.Lock()
 	defer func() {
 		q.m.Unlock()
 
 		if q.OnAckReceived != nil {
-			q.OnAckReceived(ackReceivedCounter)
+			q.OnAckReceived(ackReceivedCounter, ackReceivedSize)
+		}
+		if q.OnAcksReceived != nil && ackReceivedCounter > 0 {
+			q.OnAcksReceived(acks[:ackReceivedCounter])
 		}
 	}()
 	if q.closed {
@@ -161,10 +190,12 @@ func (q *messageQueue) AcksReceived(acks []rawtopicwriter.WriteAck) error {
 	}
 
 	for i := range acks {
-		if err := q.ackReceivedNeedLock(acks[i].SeqNo); err != nil {
+		size, err := q.ackReceivedNeedLock(partitionID, &acks[i])
+		if err != nil {
 			return err
 		}
 		ackReceivedCounter++
+		ackReceivedSize += size
 	}
 
 	q.acksReceivedEvent.Broadcast()
@@ -172,16 +203,21 @@ func (q *messageQueue) AcksReceived(acks []rawtopicwriter.WriteAck) error {
 	return nil
 }
 
-func (q *messageQueue) ackReceivedNeedLock(seqNo int64) error {
-	orderID, ok := q.seqNoToOrderID[seqNo]
+func (q *messageQueue) ackReceivedNeedLock(partitionID int64, ack *rawtopicwriter.WriteAck) (size int, _ error) {
+	orderID, ok := q.seqNoToOrderID[ack.SeqNo]
 	if !ok {
-		return xerrors.WithStackTrace(errAckUnexpectedMessage)
+		return 0, xerrors.WithStackTrace(errAckUnexpectedMessage)
 	}
 
-	delete(q.seqNoToOrderID, seqNo)
+	if future := q.messagesByOrder[orderID].future; future != nil {
+		future.ackReceived(partitionID, ack)
+	}
+	size = q.messagesByOrder[orderID].BufUncompressedSize
+
+	delete(q.seqNoToOrderID, ack.SeqNo)
 	delete(q.messagesByOrder, orderID)
 
-	return nil
+	return size, nil
 }
 
 func (q *messageQueue) StopAddNewMessages(reason error) {
@@ -194,18 +230,22 @@ func (q *messageQueue) StopAddNewMessages(reason error) {
 func (q *messageQueue) stopAddNewMessagesNeedLock(reason error) {
 	if q.stopReceiveMessagesReason == nil {
 		q.stopReceiveMessagesReason = reason
+
+		// wake up sender for send messages without linger
+		q.notifyNewMessages()
 	}
 }
 
 func (q *messageQueue) Close(err error) error {
 	isFirstTimeClosed := false
+	releaseSize := 0
 	q.m.Lock()
 	defer func() {
 		q.m.Unlock()
 
 		// release all
 		if isFirstTimeClosed && q.OnAckReceived != nil {
-			q.OnAckReceived(len(q.seqNoToOrderID))
+			q.OnAckReceived(len(q.seqNoToOrderID), releaseSize)
 		}
 	}()
 
@@ -220,6 +260,13 @@ func (q *messageQueue) Close(err error) error {
 	q.closedErr = err
 	close(q.closedChan)
 
+	for k := range q.messagesByOrder {
+		releaseSize += q.messagesByOrder[k].BufUncompressedSize
+		if future := q.messagesByOrder[k].future; future != nil {
+			future.fail(xerrors.WithStackTrace(fmt.Errorf("ydb: message queue closed with: %w", err)))
+		}
+	}
+
 	return nil
 }
 
@@ -232,7 +279,8 @@ func (q *messageQueue) ensureNoSmallIntIndexes() {
 }
 
 // GetMessagesForSend one or more messages for send
-// it blocked until context cancelled of have least one message for send
+// it blocked until context cancelled of have least one message for send.
+// If linger set - it wait while batch will be full or the linger time will expire since first unsent message
 func (q *messageQueue) GetMessagesForSend(ctx context.Context) ([]messageWithDataContent, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -246,19 +294,34 @@ func (q *messageQueue) GetMessagesForSend(ctx context.Context) ([]messageWithDat
 	}
 
 	for {
-		res := q.getMessagesForSendWithLock()
+		res, lingerWait := q.getMessagesForSendWithLock()
 		if len(res) != 0 {
 			return res, nil
 		}
 
+		var (
+			lingerTimer     clockwork.Timer
+			lingerTimerChan <-chan time.Time
+		)
+		if lingerWait > 0 {
+			lingerTimer = q.clock.NewTimer(lingerWait)
+			lingerTimerChan = lingerTimer.Chan()
+		}
+
 		select {
 		case <-ctx.Done():
 			return nil, xerrors.WithStackTrace(ctx.Err())
 		case <-q.hasNewMessages:
 			// pass
+		case <-lingerTimerChan:
+			// pass
 		case <-q.closedChan:
 			return nil, xerrors.WithStackTrace(fmt.Errorf("ydb: message queue closed with: %w", q.closedErr))
 		}
+
+		if lingerTimer != nil {
+			lingerTimer.Stop()
+		}
 	}
 }
 
@@ -274,31 +337,78 @@ func (q *messageQueue) ResetSentProgress() {
 	}
 
 	q.lastSentIndex = minKey - 1
+
+	// messages was waited linger time already
+	q.unsentSince = time.Time{}
 	q.notifyNewMessages()
 }
 
-func (q *messageQueue) getMessagesForSendWithLock() []messageWithDataContent {
+// getMessagesForSendWithLock return messages for send or time for wait more messages
+func (q *messageQueue) getMessagesForSendWithLock() (_ []messageWithDataContent, lingerWait time.Duration) {
 	q.m.Lock()
 	defer q.m.Unlock()
 
 	if q.lastWrittenIndex == q.lastSentIndex {
-		return nil
+		return nil, 0
+	}
+
+	if q.linger > 0 && !q.needSendImmediatelyNeedLock() {
+		lingerWait = q.unsentSince.Add(q.linger).Sub(q.clock.Now())
+		if lingerWait > 0 {
+			return nil, lingerWait
+		}
 	}
 
-	var res []messageWithDataContent
+	var (
+		res       []messageWithDataContent
+		batchSize int
+	)
 
 	// use  "!=" stop instead of  "<" - for work with negative indexes after overflow
 	for q.lastWrittenIndex != q.lastSentIndex {
-		q.lastSentIndex++
+		nextIndex := q.lastSentIndex + 1
 
 		// msg may be unexisted if it already has ack from server
 		// pass
-		if msg, ok := q.messagesByOrder[q.lastSentIndex]; ok {
+		msg, ok := q.messagesByOrder[nextIndex]
+		if ok && q.maxBatchBytes > 0 && len(res) > 0 && batchSize+msg.BufUncompressedSize > q.maxBatchBytes {
+			break
+		}
+
+		q.lastSentIndex = nextIndex
+		if ok {
 			res = append(res, msg)
+			batchSize += msg.BufUncompressedSize
+		}
+	}
+
+	if q.lastWrittenIndex == q.lastSentIndex {
+		q.flushRequested = false
+	}
+
+	return res, 0
+}
+
+// needSendImmediatelyNeedLock return true if unsent messages must be sent without wait linger time
+func (q *messageQueue) needSendImmediatelyNeedLock() bool {
+	if q.flushRequested || q.stopReceiveMessagesReason != nil {
+		return true
+	}
+
+	if q.maxBatchBytes <= 0 {
+		return false
+	}
+
+	unsentSize := 0
+	for index := q.lastSentIndex; index != q.lastWrittenIndex; {
+		index++
+		unsentSize += q.messagesByOrder[index].BufUncompressedSize
+		if unsentSize >= q.maxBatchBytes {
+			return true
 		}
 	}
 
-	return res
+	return false
 }
 
 func (q *messageQueue) Wait(ctx context.Context, waiter MessageQueueAckWaiter) error {
@@ -306,6 +416,14 @@ func (q *messageQueue) Wait(ctx context.Context, waiter MessageQueueAckWaiter) e
 		return err
 	}
 
+	// no wait linger time for messages, which waited by user
+	q.m.WithLock(func() {
+		if q.lastWrittenIndex != q.lastSentIndex {
+			q.flushRequested = true
+			q.notifyNewMessages()
+		}
+	})
+
 	ctxDone := ctx.Done()
 	for {
 		ackReceived := q.acksReceivedEvent.Waiter()
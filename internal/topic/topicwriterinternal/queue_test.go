@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/empty"
@@ -233,6 +234,107 @@ func TestMessageQueue_GetMessages(t *testing.T) {
 	})
 }
 
+func TestMessageQueue_Batching(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("MaxBatchBytes", func(t *testing.T) {
+		q := newMessageQueue()
+		q.maxBatchBytes = 10
+		require.NoError(t, q.AddMessages(newTestMessagesWithSize(4, 4, 4, 20, 1)))
+
+		messages, err := q.GetMessagesForSend(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 2}, getSeqNumbers(messages))
+
+		// big message sent in separate batch
+		messages, err = q.GetMessagesForSend(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []int64{3}, getSeqNumbers(messages))
+
+		messages, err = q.GetMessagesForSend(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []int64{4}, getSeqNumbers(messages))
+
+		messages, err = q.GetMessagesForSend(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []int64{5}, getSeqNumbers(messages))
+	})
+
+	t.Run("Linger", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		q := newMessageQueue()
+		q.clock = clock
+		q.linger = time.Second
+
+		var messages []messageWithDataContent
+		var err error
+		gotMessages := make(empty.Chan)
+
+		require.NoError(t, q.AddMessages(newTestMessagesWithContent(1)))
+		go func() {
+			messages, err = q.GetMessagesForSend(ctx)
+			close(gotMessages)
+		}()
+
+		clock.BlockUntil(1)
+		require.NoError(t, q.AddMessages(newTestMessagesWithContent(2)))
+		clock.BlockUntil(1)
+		require.False(t, isClosed(gotMessages))
+
+		clock.Advance(time.Second)
+		<-gotMessages
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 2}, getSeqNumbers(messages))
+	})
+
+	t.Run("LingerFullBatch", func(t *testing.T) {
+		q := newMessageQueue()
+		q.clock = clockwork.NewFakeClock()
+		q.linger = time.Hour
+		q.maxBatchBytes = 10
+
+		require.NoError(t, q.AddMessages(newTestMessagesWithSize(5, 5, 5)))
+
+		messages, err := q.GetMessagesForSend(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 2}, getSeqNumbers(messages))
+	})
+
+	t.Run("LingerFlush", func(t *testing.T) {
+		q := newMessageQueue()
+		q.clock = clockwork.NewFakeClock()
+		q.linger = time.Hour
+
+		waiter, err := q.AddMessagesWithWaiter(newTestMessagesWithContent(1))
+		require.NoError(t, err)
+
+		waitCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			_ = q.Wait(waitCtx, waiter)
+		}()
+		defer cancel()
+
+		messages, err := q.GetMessagesForSend(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []int64{1}, getSeqNumbers(messages))
+	})
+
+	t.Run("LingerAfterReset", func(t *testing.T) {
+		q := newMessageQueue()
+		q.linger = time.Hour
+		require.NoError(t, q.AddMessages(newTestMessagesWithContent(1)))
+		q.StopAddNewMessages(errors.New("test"))
+
+		res1, err := q.GetMessagesForSend(ctx)
+		require.NoError(t, err)
+
+		q.ResetSentProgress()
+		res2, err := q.GetMessagesForSend(ctx)
+		require.NoError(t, err)
+		require.Equal(t, res1, res2)
+	})
+}
+
 func TestMessageQueue_ResetSentProgress(t *testing.T) {
 	ctx := context.Background()
 
@@ -411,7 +513,7 @@ func TestRegressionIssue1038_ReceiveAckAfterCloseQueue(t *testing.T) {
 	counter := 0
 
 	q := newMessageQueue()
-	q.OnAckReceived = func(count int) {
+	q.OnAckReceived = func(count, size int) {
 		counter -= count
 	}
 	require.NoError(t, q.AddMessages(newTestMessagesWithContent(1)))
@@ -463,13 +565,15 @@ func TestQueue_Ack(t *testing.T) {
 
 	t.Run("OnAckReceived", func(t *testing.T) {
 		receivedCount := 0
+		receivedSize := 0
 
 		q := newMessageQueue()
-		q.OnAckReceived = func(count int) {
+		q.OnAckReceived = func(count, size int) {
 			receivedCount = count
+			receivedSize = size
 		}
 
-		err := q.AddMessages(newTestMessagesWithContent(1, 2, 3))
+		err := q.AddMessages(newTestMessagesWithSize(1, 2, 3))
 		require.NoError(t, err)
 
 		err = q.AcksReceived([]rawtopicwriter.WriteAck{
@@ -483,6 +587,7 @@ func TestQueue_Ack(t *testing.T) {
 
 		require.NoError(t, err)
 		require.Equal(t, 2, receivedCount)
+		require.Equal(t, 4, receivedSize)
 
 		// Double ack
 		err = q.AcksReceived([]rawtopicwriter.WriteAck{
@@ -506,6 +611,18 @@ func waitGetMessageStarted(q *messageQueue) {
 	}
 }
 
+// newTestMessagesWithSize create messages with seqno from 1 and uncompressed sizes
+func newTestMessagesWithSize(sizes ...int) []messageWithDataContent {
+	messages := newTestMessagesWithContent()
+	for i, size := range sizes {
+		mess := newTestMessageWithDataContent(i + 1)
+		mess.BufUncompressedSize = size
+		messages = append(messages, mess)
+	}
+
+	return messages
+}
+
 func getSeqNumbers(messages []messageWithDataContent) []int64 {
 	res := make([]int64, 0, len(messages))
 	for i := range messages {
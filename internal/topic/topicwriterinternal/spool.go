@@ -0,0 +1,519 @@
+package topicwriterinternal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopiccommon"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+const (
+	spoolSegmentSuffix        = ".seg"
+	spoolRecordHeaderSize     = 8
+	defaultSpoolSegmentSize   = 64 * 1024 * 1024 //nolint:gomnd
+	spoolMaxRecordPayloadSize = 1 << 31          //nolint:gomnd
+)
+
+var (
+	errSpoolClosed         = xerrors.Wrap(errors.New("ydb: topic writer spool closed"))
+	errSpoolBrokenRecord   = xerrors.Wrap(errors.New("ydb: topic writer spool has broken record"))
+	errSpoolDuplicateSeqNo = xerrors.Wrap(errors.New("ydb: topic writer spool already has message with the seqno"))
+)
+
+// writerSpool is durable buffer of unacked messages
+// messages appended to segments files before put to the queue and segment removed
+// after all its messages acked by server
+type writerSpool struct {
+	dir            string
+	maxSegmentSize int64
+
+	m             sync.Mutex
+	closed        bool
+	segments      map[uint64]*spoolSegment
+	seqNoSegment  map[int64]uint64
+	active        *spoolSegment
+	nextSegmentID uint64
+	recovered     []spoolRecord
+}
+
+type spoolSegment struct {
+	id      uint64
+	path    string
+	file    *os.File
+	size    int64
+	unacked int
+
+	// lastAppendOffset is size of the segment before the last append, used for discard the append
+	lastAppendOffset int64
+}
+
+type spoolRecord struct {
+	SeqNo            int64
+	CreatedAt        time.Time
+	Metadata         map[string][]byte
+	Codec            rawtopiccommon.Codec
+	UncompressedSize int
+	Data             []byte
+}
+
+func openWriterSpool(dir string, maxSegmentSize int64) (*writerSpool, error) {
+	if maxSegmentSize <= 0 {
+		maxSegmentSize = defaultSpoolSegmentSize
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil { //nolint:gomnd
+		return nil, xerrors.WithStackTrace(fmt.Errorf("ydb: failed to create topic writer spool dir: %w", err))
+	}
+
+	s := &writerSpool{
+		dir:            dir,
+		maxSegmentSize: maxSegmentSize,
+		segments:       make(map[uint64]*spoolSegment),
+		seqNoSegment:   make(map[int64]uint64),
+	}
+
+	if err := s.recover(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// TakeRecovered return messages from previous writer sessions, not acked by server, ordered by seqno
+// the spool doesn't hold the messages after the call
+func (s *writerSpool) TakeRecovered() []spoolRecord {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	res := s.recovered
+	s.recovered = nil
+
+	return res
+}
+
+func (s *writerSpool) recover() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to read topic writer spool dir: %w", err))
+	}
+
+	var ids []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, spoolSegmentSuffix) {
+			continue
+		}
+
+		var id uint64
+		if _, err = fmt.Sscanf(strings.TrimSuffix(name, spoolSegmentSuffix), "%x", &id); err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, k int) bool {
+		return ids[i] < ids[k]
+	})
+
+	for _, id := range ids {
+		segment := &spoolSegment{id: id, path: s.segmentPath(id)}
+		records, err := readSpoolSegment(segment.path)
+		if err != nil {
+			return err
+		}
+
+		for i := range records {
+			if _, ok := s.seqNoSegment[records[i].SeqNo]; ok {
+				// the message already recovered from previous segment
+				continue
+			}
+			s.seqNoSegment[records[i].SeqNo] = id
+			s.recovered = append(s.recovered, records[i])
+			segment.unacked++
+		}
+
+		if segment.unacked == 0 {
+			_ = os.Remove(segment.path)
+		} else {
+			s.segments[id] = segment
+		}
+		s.nextSegmentID = id + 1
+	}
+
+	sort.SliceStable(s.recovered, func(i, k int) bool {
+		return s.recovered[i].SeqNo < s.recovered[k].SeqNo
+	})
+
+	return nil
+}
+
+// Append save messages to active segment and sync it to disk
+func (s *writerSpool) Append(messages []messageWithDataContent) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	var buf []byte
+	for i := range messages {
+		buf = appendSpoolRecord(buf, &messages[i])
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.closed {
+		return xerrors.WithStackTrace(errSpoolClosed)
+	}
+
+	for i := range messages {
+		if _, ok := s.seqNoSegment[messages[i].SeqNo]; ok {
+			return xerrors.WithStackTrace(fmt.Errorf("%w: %v", errSpoolDuplicateSeqNo, messages[i].SeqNo))
+		}
+	}
+
+	if s.active != nil && s.active.size >= s.maxSegmentSize {
+		s.rotateNeedLock()
+	}
+
+	if s.active == nil {
+		if err := s.createActiveSegmentNeedLock(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.active.file.Write(buf); err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to write to topic writer spool: %w", err))
+	}
+	if err := s.active.file.Sync(); err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to sync topic writer spool: %w", err))
+	}
+
+	s.active.lastAppendOffset = s.active.size
+	s.active.size += int64(len(buf))
+	s.active.unacked += len(messages)
+	for i := range messages {
+		s.seqNoSegment[messages[i].SeqNo] = s.active.id
+	}
+
+	return nil
+}
+
+// Ack mark message as written to the server, segment deleted after all its messages acked
+func (s *writerSpool) Ack(seqNo int64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	id, ok := s.seqNoSegment[seqNo]
+	if !ok {
+		return
+	}
+	delete(s.seqNoSegment, seqNo)
+
+	segment := s.segments[id]
+	segment.unacked--
+	if segment.unacked > 0 {
+		return
+	}
+
+	if segment == s.active {
+		s.active = nil
+	}
+	s.removeSegmentNeedLock(segment)
+}
+
+// Discard remove messages of the last Append call from the spool
+// used when the messages were saved to the spool but not added to the writer queue
+func (s *writerSpool) Discard(messages []messageWithDataContent) {
+	if len(messages) == 0 {
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	segment := s.active
+	lastAppend := segment != nil && segment.file != nil
+	for i := range messages {
+		id, ok := s.seqNoSegment[messages[i].SeqNo]
+		lastAppend = lastAppend && ok && id == segment.id
+		delete(s.seqNoSegment, messages[i].SeqNo)
+	}
+	if !lastAppend {
+		return
+	}
+
+	segment.unacked -= len(messages)
+	if segment.unacked <= 0 {
+		s.active = nil
+		s.removeSegmentNeedLock(segment)
+
+		return
+	}
+
+	if err := s.truncateActiveNeedLock(); err == nil {
+		return
+	}
+
+	// the messages stay in the segment file and will be sent again after restart,
+	// close the segment for prevent appends after the broken tail
+	s.rotateNeedLock()
+}
+
+func (s *writerSpool) truncateActiveNeedLock() error {
+	segment := s.active
+	if err := segment.file.Truncate(segment.lastAppendOffset); err != nil {
+		return err
+	}
+	if _, err := segment.file.Seek(segment.lastAppendOffset, io.SeekStart); err != nil {
+		return err
+	}
+	if err := segment.file.Sync(); err != nil {
+		return err
+	}
+	segment.size = segment.lastAppendOffset
+
+	return nil
+}
+
+func (s *writerSpool) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	if s.active != nil && s.active.file != nil {
+		return s.active.file.Close()
+	}
+
+	return nil
+}
+
+func (s *writerSpool) rotateNeedLock() {
+	segment := s.active
+	s.active = nil
+
+	if segment.unacked == 0 {
+		s.removeSegmentNeedLock(segment)
+
+		return
+	}
+
+	_ = segment.file.Close()
+	segment.file = nil
+}
+
+func (s *writerSpool) createActiveSegmentNeedLock() error {
+	id := s.nextSegmentID
+	s.nextSegmentID++
+
+	segment := &spoolSegment{id: id, path: s.segmentPath(id)}
+
+	file, err := os.OpenFile(segment.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600) //nolint:gomnd
+	if err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to create topic writer spool segment: %w", err))
+	}
+	segment.file = file
+
+	s.segments[id] = segment
+	s.active = segment
+
+	return nil
+}
+
+func (s *writerSpool) removeSegmentNeedLock(segment *spoolSegment) {
+	if segment.file != nil {
+		_ = segment.file.Close()
+		segment.file = nil
+	}
+	delete(s.segments, segment.id)
+
+	// error of remove is not critical: messages from the segment will be sent again after restart
+	// and skipped by server as duplicates
+	_ = os.Remove(segment.path)
+}
+
+func (s *writerSpool) segmentPath(id uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%016x%s", id, spoolSegmentSuffix))
+}
+
+func appendSpoolRecord(buf []byte, mess *messageWithDataContent) []byte {
+	codec := rawtopiccommon.CodecRaw
+	data := mess.rawBuf.Bytes()
+	if !mess.hasRawContent {
+		codec = mess.bufCodec
+		data = mess.bufEncoded.Bytes()
+	}
+
+	var payload []byte
+	payload = binary.BigEndian.AppendUint64(payload, uint64(mess.SeqNo))
+	var createdAt int64
+	if !mess.CreatedAt.IsZero() {
+		createdAt = mess.CreatedAt.UnixNano()
+	}
+	payload = binary.BigEndian.AppendUint64(payload, uint64(createdAt))
+	payload = binary.BigEndian.AppendUint32(payload, uint32(codec))
+	payload = binary.BigEndian.AppendUint64(payload, uint64(mess.BufUncompressedSize))
+	payload = binary.BigEndian.AppendUint32(payload, uint32(len(mess.Metadata)))
+	for key, val := range mess.Metadata {
+		payload = appendSpoolBytes(payload, []byte(key))
+		payload = appendSpoolBytes(payload, val)
+	}
+	payload = appendSpoolBytes(payload, data)
+
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(payload))
+
+	return append(buf, payload...)
+}
+
+func appendSpoolBytes(buf, data []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+
+	return append(buf, data...)
+}
+
+// readSpoolSegment read all valid records of segment
+// segment tail, broken by crash while write, ignored
+func readSpoolSegment(path string) ([]spoolRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("ydb: failed to open topic writer spool segment: %w", err))
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	var (
+		records []spoolRecord
+		header  [spoolRecordHeaderSize]byte
+	)
+	for {
+		if _, err = io.ReadFull(reader, header[:]); err != nil {
+			break
+		}
+
+		size := binary.BigEndian.Uint32(header[:4])
+		if size >= spoolMaxRecordPayloadSize {
+			break
+		}
+
+		payload := make([]byte, size)
+		if _, err = io.ReadFull(reader, payload); err != nil {
+			break
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:]) {
+			break
+		}
+
+		record, err := parseSpoolRecord(payload)
+		if err != nil {
+			break
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseSpoolRecord(payload []byte) (record spoolRecord, _ error) {
+	r := spoolRecordReader{buf: payload}
+
+	record.SeqNo = int64(r.uint64())
+	if createdAt := int64(r.uint64()); createdAt != 0 {
+		record.CreatedAt = time.Unix(0, createdAt)
+	}
+	record.Codec = rawtopiccommon.Codec(int32(r.uint32()))
+	record.UncompressedSize = int(r.uint64())
+
+	metadataCount := int(r.uint32())
+	if metadataCount > 0 && r.err == nil {
+		record.Metadata = make(map[string][]byte, metadataCount)
+		for i := 0; i < metadataCount && r.err == nil; i++ {
+			key := string(r.bytes())
+			record.Metadata[key] = r.bytes()
+		}
+	}
+	record.Data = r.bytes()
+
+	return record, r.err
+}
+
+type spoolRecordReader struct {
+	buf []byte
+	err error
+}
+
+func (r *spoolRecordReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = xerrors.WithStackTrace(errSpoolBrokenRecord)
+
+		return nil
+	}
+	res := r.buf[:n:n]
+	r.buf = r.buf[n:]
+
+	return res
+}
+
+func (r *spoolRecordReader) uint32() uint32 {
+	if b := r.next(4); b != nil { //nolint:gomnd
+		return binary.BigEndian.Uint32(b)
+	}
+
+	return 0
+}
+
+func (r *spoolRecordReader) uint64() uint64 {
+	if b := r.next(8); b != nil { //nolint:gomnd
+		return binary.BigEndian.Uint64(b)
+	}
+
+	return 0
+}
+
+func (r *spoolRecordReader) bytes() []byte {
+	size := r.uint32()
+	if r.err != nil {
+		return nil
+	}
+
+	return r.next(int(size))
+}
+
+func newMessageDataWithSpooledContent(record *spoolRecord, encoders *EncoderMap) messageWithDataContent {
+	res := newMessageDataWithContent(PublicMessage{
+		SeqNo:     record.SeqNo,
+		CreatedAt: record.CreatedAt,
+		Metadata:  record.Metadata,
+	}, encoders)
+
+	res.metadataCached = true
+	res.dataWasRead = true
+	res.BufUncompressedSize = record.UncompressedSize
+	if record.Codec == rawtopiccommon.CodecRaw {
+		res.hasRawContent = true
+		res.rawBuf.Write(record.Data)
+	} else {
+		res.hasEncodedContent = true
+		res.bufCodec = record.Codec
+		res.bufEncoded.Write(record.Data)
+	}
+
+	return res
+}
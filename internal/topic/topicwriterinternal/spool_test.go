@@ -0,0 +1,262 @@
+package topicwriterinternal
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopiccommon"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopicwriter"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+func TestWriterSpool(t *testing.T) {
+	t.Run("Recover", func(t *testing.T) {
+		dir := t.TempDir()
+
+		spool, err := openWriterSpool(dir, 0)
+		require.NoError(t, err)
+		require.Empty(t, spool.TakeRecovered())
+
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+		messages := []messageWithDataContent{
+			newTestSpoolMessage(t, 1, "a", rawtopiccommon.CodecRaw),
+			newTestSpoolMessage(t, 2, "b", rawtopiccommon.CodecGzip),
+		}
+		messages[0].CreatedAt = createdAt
+		messages[0].Metadata = map[string][]byte{"key": []byte("val")}
+		require.NoError(t, spool.Append(messages))
+		spool.Ack(1)
+		require.NoError(t, spool.Close())
+
+		spool, err = openWriterSpool(dir, 0)
+		require.NoError(t, err)
+		recovered := spool.TakeRecovered()
+		require.Len(t, recovered, 2)
+		require.Equal(t, int64(1), recovered[0].SeqNo)
+		require.True(t, createdAt.Equal(recovered[0].CreatedAt))
+		require.Equal(t, map[string][]byte{"key": []byte("val")}, recovered[0].Metadata)
+		require.Equal(t, rawtopiccommon.CodecRaw, recovered[0].Codec)
+		require.Equal(t, []byte("a"), recovered[0].Data)
+
+		require.Equal(t, int64(2), recovered[1].SeqNo)
+		require.True(t, recovered[1].CreatedAt.IsZero())
+		require.Equal(t, rawtopiccommon.CodecGzip, recovered[1].Codec)
+		require.Equal(t, 1, recovered[1].UncompressedSize)
+
+		mess := newMessageDataWithSpooledContent(&recovered[1], testCommonEncoders)
+		raw, err := mess.GetEncodedBytes(rawtopiccommon.CodecGzip)
+		require.NoError(t, err)
+		require.Equal(t, recovered[1].Data, raw)
+		require.NoError(t, spool.Close())
+	})
+
+	t.Run("RemoveAckedSegments", func(t *testing.T) {
+		dir := t.TempDir()
+
+		spool, err := openWriterSpool(dir, 1)
+		require.NoError(t, err)
+
+		// every append to new segment, because max segment size is 1 byte
+		for i := 1; i <= 3; i++ {
+			require.NoError(t, spool.Append([]messageWithDataContent{
+				newTestSpoolMessage(t, int64(i), "data", rawtopiccommon.CodecRaw),
+			}))
+		}
+		require.Len(t, testSpoolSegments(t, dir), 3)
+
+		spool.Ack(2)
+		require.Len(t, testSpoolSegments(t, dir), 2)
+
+		spool.Ack(3)
+		spool.Ack(1)
+		require.Empty(t, testSpoolSegments(t, dir))
+		require.NoError(t, spool.Close())
+	})
+
+	t.Run("BrokenTail", func(t *testing.T) {
+		dir := t.TempDir()
+
+		spool, err := openWriterSpool(dir, 0)
+		require.NoError(t, err)
+		require.NoError(t, spool.Append([]messageWithDataContent{
+			newTestSpoolMessage(t, 1, "a", rawtopiccommon.CodecRaw),
+			newTestSpoolMessage(t, 2, "b", rawtopiccommon.CodecRaw),
+		}))
+		require.NoError(t, spool.Close())
+
+		segments := testSpoolSegments(t, dir)
+		require.Len(t, segments, 1)
+		content, err := os.ReadFile(segments[0])
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(segments[0], content[:len(content)-1], 0o600))
+
+		spool, err = openWriterSpool(dir, 0)
+		require.NoError(t, err)
+		recovered := spool.TakeRecovered()
+		require.Len(t, recovered, 1)
+		require.Equal(t, int64(1), recovered[0].SeqNo)
+
+		// new messages written to new segment
+		require.NoError(t, spool.Append([]messageWithDataContent{
+			newTestSpoolMessage(t, 3, "c", rawtopiccommon.CodecRaw),
+		}))
+		require.Len(t, testSpoolSegments(t, dir), 2)
+		require.NoError(t, spool.Close())
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		dir := t.TempDir()
+
+		spool, err := openWriterSpool(dir, 0)
+		require.NoError(t, err)
+		require.NoError(t, spool.Append([]messageWithDataContent{
+			newTestSpoolMessage(t, 1, "a", rawtopiccommon.CodecRaw),
+		}))
+		discarded := []messageWithDataContent{
+			newTestSpoolMessage(t, 2, "b", rawtopiccommon.CodecRaw),
+			newTestSpoolMessage(t, 3, "c", rawtopiccommon.CodecRaw),
+		}
+		require.NoError(t, spool.Append(discarded))
+		spool.Discard(discarded)
+
+		// the seqno can be used again
+		require.NoError(t, spool.Append([]messageWithDataContent{
+			newTestSpoolMessage(t, 2, "d", rawtopiccommon.CodecRaw),
+		}))
+		require.NoError(t, spool.Close())
+
+		spool, err = openWriterSpool(dir, 0)
+		require.NoError(t, err)
+		recovered := spool.TakeRecovered()
+		require.Len(t, recovered, 2)
+		require.Equal(t, int64(1), recovered[0].SeqNo)
+		require.Equal(t, int64(2), recovered[1].SeqNo)
+		require.Equal(t, []byte("d"), recovered[1].Data)
+
+		spool.Ack(1)
+		spool.Ack(2)
+		discarded = []messageWithDataContent{
+			newTestSpoolMessage(t, 4, "e", rawtopiccommon.CodecRaw),
+		}
+		require.NoError(t, spool.Append(discarded))
+		spool.Discard(discarded)
+		require.Empty(t, testSpoolSegments(t, dir))
+		require.NoError(t, spool.Close())
+	})
+}
+
+func TestWriterReconnectorDiscardSpoolOnClosedQueue(t *testing.T) {
+	dir := t.TempDir()
+
+	spool, err := openWriterSpool(dir, 0)
+	require.NoError(t, err)
+
+	w := newTestWriterStopped(WithAutoSetSeqNo(true))
+	require.NoError(t, w.attachSpool(spool))
+	w.firstConnectionHandled.Store(true)
+
+	require.NoError(t, w.Write(xtest.Context(t), []PublicMessage{{Data: bytes.NewReader([]byte("a"))}}))
+	require.NoError(t, w.queue.Close(errors.New("test")))
+	require.Error(t, w.Write(xtest.Context(t), []PublicMessage{{Data: bytes.NewReader([]byte("b"))}}))
+
+	require.NoError(t, spool.Close())
+	spool, err = openWriterSpool(dir, 0)
+	require.NoError(t, err)
+	recovered := spool.TakeRecovered()
+	require.Len(t, recovered, 1)
+	require.Equal(t, []byte("a"), recovered[0].Data)
+	require.NoError(t, spool.Close())
+}
+
+func TestWriterReconnectorAttachSpool(t *testing.T) {
+	dir := t.TempDir()
+
+	spool, err := openWriterSpool(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, spool.Append([]messageWithDataContent{
+		newTestSpoolMessage(t, 5, "a", rawtopiccommon.CodecRaw),
+		newTestSpoolMessage(t, 6, "b", rawtopiccommon.CodecRaw),
+	}))
+	require.NoError(t, spool.Close())
+
+	spool, err = openWriterSpool(dir, 0)
+	require.NoError(t, err)
+
+	w := newTestWriterStopped(WithAutoSetSeqNo(true), WithMaxQueueLen(1))
+	require.NoError(t, w.attachSpool(spool))
+	require.Equal(t, int64(6), w.lastSeqNo)
+	require.Len(t, w.queue.messagesByOrder, 2)
+
+	w.firstConnectionHandled.Store(true)
+	require.NoError(t, w.queue.AcksReceived([]rawtopicwriter.WriteAck{{SeqNo: 5}}))
+	require.False(t, w.semaphore.TryAcquire(1), "the ack pays the backlog over the limit")
+	require.NoError(t, w.queue.AcksReceived([]rawtopicwriter.WriteAck{{SeqNo: 6}}))
+	require.Empty(t, testSpoolSegments(t, dir))
+	require.False(t, w.semaphore.TryAcquire(2), "the limit of the queue is kept")
+
+	require.NoError(t, w.Write(xtest.Context(t), []PublicMessage{{Data: bytes.NewReader([]byte("c"))}}))
+	require.Equal(t, int64(7), w.lastSeqNo)
+	require.Len(t, testSpoolSegments(t, dir), 1)
+
+	require.NoError(t, spool.Close())
+	spool, err = openWriterSpool(dir, 0)
+	require.NoError(t, err)
+	recovered := spool.TakeRecovered()
+	require.Len(t, recovered, 1)
+	require.Equal(t, int64(7), recovered[0].SeqNo)
+	require.Equal(t, []byte("c"), recovered[0].Data)
+	require.NoError(t, spool.Close())
+}
+
+func TestWriterReconnectorAttachSpoolOverInflightBytes(t *testing.T) {
+	dir := t.TempDir()
+
+	spool, err := openWriterSpool(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, spool.Append([]messageWithDataContent{
+		newTestSpoolMessage(t, 1, "aaa", rawtopiccommon.CodecRaw),
+		newTestSpoolMessage(t, 2, "bbb", rawtopiccommon.CodecRaw),
+	}))
+	require.NoError(t, spool.Close())
+
+	spool, err = openWriterSpool(dir, 0)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, spool.Close())
+	}()
+
+	w := newTestWriterStopped(WithAutoSetSeqNo(true), WithMaxInflightBytes(4))
+	require.NoError(t, w.attachSpool(spool))
+	require.False(t, w.inflightBytesSemaphore.TryAcquire(1))
+
+	w.firstConnectionHandled.Store(true)
+	require.NoError(t, w.queue.AcksReceived([]rawtopicwriter.WriteAck{{SeqNo: 1}}))
+	require.False(t, w.inflightBytesSemaphore.TryAcquire(2), "the ack pays the backlog over the limit")
+
+	require.NoError(t, w.queue.AcksReceived([]rawtopicwriter.WriteAck{{SeqNo: 2}}))
+	require.False(t, w.inflightBytesSemaphore.TryAcquire(5), "the limit of the inflight bytes is kept")
+	require.True(t, w.inflightBytesSemaphore.TryAcquire(4))
+}
+
+func newTestSpoolMessage(t testing.TB, seqNo int64, data string, codec rawtopiccommon.Codec) messageWithDataContent {
+	mess := newMessageDataWithContent(PublicMessage{
+		SeqNo: seqNo,
+		Data:  bytes.NewReader([]byte(data)),
+	}, testCommonEncoders)
+	require.NoError(t, mess.CacheMessageData(codec))
+
+	return mess
+}
+
+func testSpoolSegments(t testing.TB, dir string) []string {
+	res, err := filepath.Glob(filepath.Join(dir, "*"+spoolSegmentSuffix))
+	require.NoError(t, err)
+
+	return res
+}
@@ -0,0 +1,106 @@
+package topicwriterinternal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/empty"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopicwriter"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// PublicWriteStatus is result status of write message to the server
+type PublicWriteStatus int
+
+const (
+	PublicWriteStatusUnknown PublicWriteStatus = iota
+
+	// PublicWriteStatusWritten the message written to the topic
+	PublicWriteStatusWritten
+
+	// PublicWriteStatusSkipped the message skipped by server, because message with the seqno already written
+	PublicWriteStatusSkipped
+
+	// PublicWriteStatusWrittenInTx the message written within transaction
+	// it will be visible in topic after commit the transaction
+	PublicWriteStatusWrittenInTx
+)
+
+func (s PublicWriteStatus) String() string {
+	switch s {
+	case PublicWriteStatusWritten:
+		return "Written"
+	case PublicWriteStatusSkipped:
+		return "Skipped"
+	case PublicWriteStatusWrittenInTx:
+		return "WrittenInTx"
+	default:
+		return "Unknown"
+	}
+}
+
+// PublicWriteResult is ack from server for one message
+type PublicWriteResult struct {
+	SeqNo       int64
+	PartitionID int64
+	Status      PublicWriteStatus
+
+	// Offset of the message in the partition, filled for PublicWriteStatusWritten only
+	Offset int64
+}
+
+// PublicWriteFuture is result of async write one message, resolved by server ack
+type PublicWriteFuture struct {
+	done   empty.Chan
+	once   sync.Once
+	result PublicWriteResult
+	err    error
+}
+
+func newWriteFuture() *PublicWriteFuture {
+	return &PublicWriteFuture{
+		done: make(empty.Chan),
+	}
+}
+
+// Done closed after the future resolved
+func (f *PublicWriteFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait waits server ack for the message.
+// Error returned if the writer closed before the ack received or ctx done.
+func (f *PublicWriteFuture) Wait(ctx context.Context) (PublicWriteResult, error) {
+	select {
+	case <-ctx.Done():
+		return PublicWriteResult{}, xerrors.WithStackTrace(ctx.Err())
+	case <-f.done:
+		return f.result, f.err
+	}
+}
+
+func (f *PublicWriteFuture) ackReceived(partitionID int64, ack *rawtopicwriter.WriteAck) {
+	f.once.Do(func() {
+		f.result = PublicWriteResult{
+			SeqNo:       ack.SeqNo,
+			PartitionID: partitionID,
+		}
+		switch ack.MessageWriteStatus.Type {
+		case rawtopicwriter.WriteStatusTypeWritten:
+			f.result.Status = PublicWriteStatusWritten
+			f.result.Offset = ack.MessageWriteStatus.WrittenOffset
+		case rawtopicwriter.WriteStatusTypeSkipped:
+			f.result.Status = PublicWriteStatusSkipped
+		case rawtopicwriter.WriteStatusTypeWrittenInTx:
+			f.result.Status = PublicWriteStatusWrittenInTx
+		}
+		close(f.done)
+	})
+}
+
+func (f *PublicWriteFuture) fail(err error) {
+	f.once.Do(func() {
+		f.err = err
+		close(f.done)
+	})
+}
@@ -101,6 +101,31 @@ func WithMaxQueueLen(num int) PublicWriterOption {
 	}
 }
 
+func WithMaxBatchBytes(size int) PublicWriterOption {
+	return func(cfg *WriterReconnectorConfig) {
+		cfg.MaxBatchBytes = size
+	}
+}
+
+func WithLinger(linger time.Duration) PublicWriterOption {
+	return func(cfg *WriterReconnectorConfig) {
+		cfg.Linger = linger
+	}
+}
+
+func WithMaxInflightBytes(size int) PublicWriterOption {
+	return func(cfg *WriterReconnectorConfig) {
+		cfg.MaxInflightBytes = size
+	}
+}
+
+func WithRateLimiter(limiter PublicRateLimiter, cost PublicRateLimitCostFunc) PublicWriterOption {
+	return func(cfg *WriterReconnectorConfig) {
+		cfg.RateLimiter = limiter
+		cfg.RateLimitCost = cost
+	}
+}
+
 func WithPartitioning(partitioning PublicFuturePartitioning) PublicWriterOption {
 	return func(cfg *WriterReconnectorConfig) {
 		cfg.defaultPartitioning = partitioning.ToRaw()
@@ -62,10 +62,25 @@ type WriterReconnectorConfig struct {
 	AutoSetCreatedTime           bool
 	OnWriterInitResponseCallback PublicOnWriterInitResponseCallback
 	RetrySettings                topic.RetrySettings
+	SpoolDir                     string
+	SpoolSegmentSize             int64
+	MaxBatchBytes                int
+	Linger                       time.Duration
+	MaxInflightBytes             int
+	RateLimiter                  PublicRateLimiter
+	RateLimitCost                PublicRateLimitCostFunc
 
 	connectTimeout time.Duration
 }
 
+// PublicRateLimiter limits the writes of the writer
+type PublicRateLimiter interface {
+	Wait(ctx context.Context, n uint64) error
+}
+
+// PublicRateLimitCostFunc returns the amount of the rate limiter quota to take before the messages are written
+type PublicRateLimitCostFunc func(messages []PublicMessage) uint64
+
 func (cfg *WriterReconnectorConfig) validate() error {
 	if cfg.defaultPartitioning.Type == rawtopicwriter.PartitioningMessageGroupID &&
 		cfg.producerID != cfg.defaultPartitioning.MessageGroupID {
@@ -122,6 +137,9 @@ type WriterReconnector struct {
 	retrySettings                  topic.RetrySettings
 	writerInstanceID               string
 	semaphore                      *semaphore.Weighted
+	semaphoreDebt                  semaphoreDebt
+	inflightBytesSemaphore         *semaphore.Weighted
+	inflightBytesSemaphoreDebt     semaphoreDebt
 	firstInitResponseProcessedChan empty.Chan
 	lastSeqNo                      int64
 	encodersMap                    *EncoderMap
@@ -131,6 +149,7 @@ type WriterReconnector struct {
 	sessionID                      string
 	firstConnectionHandled         atomic.Bool
 	initDone                       bool
+	spool                          *writerSpool
 }
 
 func NewWriterReconnector(
@@ -141,11 +160,67 @@ func NewWriterReconnector(
 	}
 
 	res := newWriterReconnectorStopped(cfg)
+
+	if cfg.SpoolDir != "" {
+		spool, err := openWriterSpool(cfg.SpoolDir, cfg.SpoolSegmentSize)
+		if err != nil {
+			return nil, err
+		}
+		if err = res.attachSpool(spool); err != nil {
+			_ = spool.Close()
+
+			return nil, err
+		}
+	}
+
 	res.start()
 
 	return res, nil
 }
 
+// attachSpool put messages, recovered from spool to the queue with original seqno
+// and save new messages to the spool before put to the queue
+func (w *WriterReconnector) attachSpool(spool *writerSpool) error {
+	recovered := spool.TakeRecovered()
+
+	messages := make([]messageWithDataContent, len(recovered))
+	for i := range recovered {
+		messages[i] = newMessageDataWithSpooledContent(&recovered[i], w.encodersMap)
+	}
+
+	// the recovered messages over the limits of the queue and the inflight bytes are admitted in debt: their acks
+	// do not release the semaphores, so new messages wait until the backlog drains below the limits
+	count := min(len(messages), w.cfg.MaxQueueLen)
+	if !w.semaphore.TryAcquire(int64(count)) {
+		return xerrors.WithStackTrace(PublicErrQueueIsFull)
+	}
+	w.semaphoreDebt.Add(int64(len(messages) - count))
+	if w.inflightBytesSemaphore != nil {
+		size := int64(messagesSize(messages))
+		admitted := min(size, int64(w.cfg.MaxInflightBytes))
+		if !w.inflightBytesSemaphore.TryAcquire(admitted) {
+			return xerrors.WithStackTrace(PublicErrQueueIsFull)
+		}
+		w.inflightBytesSemaphoreDebt.Add(size - admitted)
+	}
+
+	if err := w.queue.AddMessages(messages); err != nil {
+		return err
+	}
+	if len(messages) > 0 {
+		w.lastSeqNo = messages[len(messages)-1].SeqNo
+	}
+
+	w.spool = spool
+	w.queue.OnAcksReceived = func(acks []rawtopicwriter.WriteAck) {
+		for i := range acks {
+			spool.Ack(acks[i].SeqNo)
+		}
+	}
+
+	return nil
+}
+
 func newWriterReconnectorStopped(
 	cfg WriterReconnectorConfig, //nolint:gocritic
 ) *WriterReconnector {
@@ -162,6 +237,13 @@ func newWriterReconnectorStopped(
 	}
 
 	res.queue.OnAckReceived = res.onAckReceived
+	res.queue.maxBatchBytes = cfg.MaxBatchBytes
+	res.queue.linger = cfg.Linger
+	res.queue.clock = cfg.clock
+
+	if cfg.MaxInflightBytes > 0 {
+		res.inflightBytesSemaphore = semaphore.NewWeighted(int64(cfg.MaxInflightBytes))
+	}
 
 	for codec, creator := range cfg.AdditionalEncoders {
 		res.encodersMap.AddEncoder(codec, creator)
@@ -210,7 +292,31 @@ func (w *WriterReconnector) start() {
 	w.background.Start(name+", sendloop", w.connectionLoop)
 }
 
-func (w *WriterReconnector) Write(ctx context.Context, messages []PublicMessage) (resErr error) {
+func (w *WriterReconnector) Write(ctx context.Context, messages []PublicMessage) error {
+	return w.write(ctx, messages, nil, w.cfg.WaitServerAck)
+}
+
+// WriteAsync put messages to the queue and return future for every message, resolved by server ack.
+// It doesn't wait acks even if WaitServerAck enabled.
+func (w *WriterReconnector) WriteAsync(ctx context.Context, messages []PublicMessage) ([]*PublicWriteFuture, error) {
+	futures := make([]*PublicWriteFuture, len(messages))
+	for i := range futures {
+		futures[i] = newWriteFuture()
+	}
+
+	if err := w.write(ctx, messages, futures, false); err != nil {
+		return nil, err
+	}
+
+	return futures, nil
+}
+
+func (w *WriterReconnector) write(
+	ctx context.Context,
+	messages []PublicMessage,
+	futures []*PublicWriteFuture,
+	waitServerAck bool,
+) (resErr error) {
 	if err := w.background.CloseReason(); err != nil {
 		return xerrors.WithStackTrace(fmt.Errorf("ydb: writer is closed: %w", err))
 	}
@@ -246,26 +352,46 @@ func (w *WriterReconnector) Write(ctx context.Context, messages []PublicMessage)
 	if err != nil {
 		return err
 	}
+	for i := range futures {
+		messagesSlice[i].future = futures[i]
+	}
 
 	if err = w.checkMessages(messagesSlice); err != nil {
 		return err
 	}
 
+	if err = w.waitRateLimit(ctx, messages); err != nil {
+		return err
+	}
+
+	inflightBytes, err := w.acquireInflightBytes(ctx, messagesSlice)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if inflightBytes > 0 {
+			w.inflightBytesSemaphore.Release(inflightBytes)
+		}
+	}()
+
 	if err = w.waitFirstInitResponse(ctx); err != nil {
 		return err
 	}
 
-	waiter, err := w.addMessageToInternalQueueWithLock(messagesSlice, &semaphoreWeight)
+	waiter, err := w.addMessageToInternalQueueWithLock(messagesSlice, &semaphoreWeight, waitServerAck)
 	if err != nil {
 		return err
 	}
+
+	// inflight bytes moved to queue with messages and will be released after ack
+	inflightBytes = 0
 	defer func() {
 		if resErr != nil {
 			resErr = xerrors.Join(resErr, PublicErrMessagesPutToInternalQueueBeforeError)
 		}
 	}()
 
-	if !w.cfg.WaitServerAck {
+	if !waitServerAck {
 		return nil
 	}
 
@@ -275,6 +401,7 @@ func (w *WriterReconnector) Write(ctx context.Context, messages []PublicMessage)
 func (w *WriterReconnector) addMessageToInternalQueueWithLock(
 	messagesSlice []messageWithDataContent,
 	semaphoreWeight *int64,
+	needWaiter bool,
 ) (MessageQueueAckWaiter, error) {
 	var (
 		waiter MessageQueueAckWaiter
@@ -287,11 +414,23 @@ func (w *WriterReconnector) addMessageToInternalQueueWithLock(
 			return
 		}
 
-		if w.cfg.WaitServerAck {
+		// messages within transaction can't be delivered after restart
+		if w.spool != nil && messagesSlice[0].tx == nil {
+			err = w.spool.Append(messagesSlice)
+			if err != nil {
+				return
+			}
+		}
+
+		if needWaiter {
 			waiter, err = w.queue.AddMessagesWithWaiter(messagesSlice)
 		} else {
 			err = w.queue.AddMessages(messagesSlice)
 		}
+		if err != nil && w.spool != nil && messagesSlice[0].tx == nil {
+			// the caller receive error, so the messages must not be sent after restart
+			w.spool.Discard(messagesSlice)
+		}
 		if err == nil {
 			// move semaphore weight to queue
 			*semaphoreWeight = 0
@@ -301,6 +440,58 @@ func (w *WriterReconnector) addMessageToInternalQueueWithLock(
 	return waiter, err
 }
 
+// waitRateLimit takes the rate limiter quota for the messages
+func (w *WriterReconnector) waitRateLimit(ctx context.Context, messages []PublicMessage) error {
+	if w.cfg.RateLimiter == nil {
+		return nil
+	}
+
+	cost := uint64(len(messages))
+	if w.cfg.RateLimitCost != nil {
+		cost = w.cfg.RateLimitCost(messages)
+	}
+	if cost == 0 {
+		return nil
+	}
+
+	if err := w.cfg.RateLimiter.Wait(ctx, cost); err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to take rate limiter quota for messages: %w", err))
+	}
+
+	return nil
+}
+
+// acquireInflightBytes wait while summary size of not acked messages allow to add the messages
+func (w *WriterReconnector) acquireInflightBytes(ctx context.Context, messages []messageWithDataContent) (
+	size int64,
+	_ error,
+) {
+	if w.inflightBytesSemaphore == nil {
+		return 0, nil
+	}
+
+	size = int64(messagesSize(messages))
+	if size > int64(w.cfg.MaxInflightBytes) {
+		return 0, xerrors.WithStackTrace(fmt.Errorf(
+			"ydb: add messages more, then max inflight bytes limit. max inflight bytes: %v, try to add: %v: %w",
+			w.cfg.MaxInflightBytes,
+			size,
+			PublicErrQueueIsFull,
+		))
+	}
+
+	if err := w.inflightBytesSemaphore.Acquire(ctx, size); err != nil {
+		return 0, xerrors.WithStackTrace(fmt.Errorf(
+			"ydb: add new messages exceed max inflight bytes limit. Add bytes: %v, max inflight bytes: %v: %w",
+			size,
+			w.cfg.MaxInflightBytes,
+			PublicErrQueueIsFull,
+		))
+	}
+
+	return size, nil
+}
+
 func (w *WriterReconnector) checkMessages(messages []messageWithDataContent) error {
 	for i := range messages {
 		size := messages[i].BufUncompressedSize
@@ -381,6 +572,12 @@ func (w *WriterReconnector) close(ctx context.Context, reason error) (resErr err
 		resErr = closeErr
 	}
 
+	if w.spool != nil {
+		if spoolErr := w.spool.Close(); resErr == nil && spoolErr != nil {
+			resErr = xerrors.WithStackTrace(spoolErr)
+		}
+	}
+
 	return resErr
 }
 
@@ -539,8 +736,34 @@ func (w *WriterReconnector) connectWithTimeout(streamLifetimeContext context.Con
 	}
 }
 
-func (w *WriterReconnector) onAckReceived(count int) {
-	w.semaphore.Release(int64(count))
+// semaphoreDebt is the weight admitted over the limit of the semaphore. The releases pay the debt first
+type semaphoreDebt struct {
+	atomic.Int64
+}
+
+func (debt *semaphoreDebt) release(s *semaphore.Weighted, n int64) {
+	for {
+		d := debt.Load()
+		if d <= 0 {
+			break
+		}
+		paid := min(d, n)
+		if debt.CompareAndSwap(d, d-paid) {
+			n -= paid
+
+			break
+		}
+	}
+	if n > 0 {
+		s.Release(n)
+	}
+}
+
+func (w *WriterReconnector) onAckReceived(count, size int) {
+	w.semaphoreDebt.release(w.semaphore, int64(count))
+	if w.inflightBytesSemaphore != nil && size > 0 {
+		w.inflightBytesSemaphoreDebt.release(w.inflightBytesSemaphore, int64(size))
+	}
 }
 
 func (w *WriterReconnector) onWriterChange(writerStream *SingleStreamWriter) {
@@ -559,7 +782,8 @@ func (w *WriterReconnector) onWriterChange(writerStream *SingleStreamWriter) {
 		defer close(w.firstInitResponseProcessedChan)
 		isFirstInit = true
 
-		if writerStream.LastSeqNumRequested {
+		// messages, recovered from spool may have seqno greater than last written to the server
+		if writerStream.LastSeqNumRequested && writerStream.ReceivedLastSeqNum > w.lastSeqNo {
 			w.lastSeqNo = writerStream.ReceivedLastSeqNum
 		}
 	})
@@ -680,6 +904,16 @@ func allMessagesHasSameBufCodec(messages []messageWithDataContent) bool {
 	return true
 }
 
+// messagesSize return summary uncompressed size of the messages
+func messagesSize(messages []messageWithDataContent) int {
+	res := 0
+	for i := range messages {
+		res += messages[i].BufUncompressedSize
+	}
+
+	return res
+}
+
 func splitMessagesByBufCodec(messages []messageWithDataContent) (res [][]messageWithDataContent) {
 	if len(messages) == 0 {
 		return nil
@@ -342,6 +342,148 @@ func TestMessagesPutToInternalQueueBeforeError(t *testing.T) {
 	require.ErrorIs(t, err, PublicErrMessagesPutToInternalQueueBeforeError)
 }
 
+func TestWriterReconnector_MaxInflightBytes(t *testing.T) {
+	ctx := xtest.Context(t)
+	w := newTestWriterStopped(WithMaxInflightBytes(5))
+	w.firstConnectionHandled.Store(true)
+
+	newMessage := func(seqNo int64, data string) []PublicMessage {
+		return []PublicMessage{{SeqNo: seqNo, Data: bytes.NewReader([]byte(data))}}
+	}
+
+	require.NoError(t, w.Write(ctx, newMessage(1, "123")))
+
+	err := w.Write(ctx, newMessage(2, "123456"))
+	require.ErrorIs(t, err, PublicErrQueueIsFull)
+
+	// no space for the message while first message wait ack
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = w.Write(timeoutCtx, newMessage(2, "123"))
+	require.ErrorIs(t, err, PublicErrQueueIsFull)
+
+	writeCompleted := make(empty.Chan)
+	go func() {
+		defer close(writeCompleted)
+
+		require.NoError(t, w.Write(ctx, newMessage(2, "123")))
+	}()
+
+	require.NoError(t, w.queue.AcksReceived([]rawtopicwriter.WriteAck{{SeqNo: 1}}))
+	xtest.WaitChannelClosed(t, writeCompleted)
+	require.Len(t, w.queue.messagesByOrder, 1)
+}
+
+func TestWriterReconnector_RateLimit(t *testing.T) {
+	t.Run("DefaultCost", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		limiter := &testRateLimiter{}
+		w := newTestWriterStopped(WithRateLimiter(limiter, nil))
+		w.firstConnectionHandled.Store(true)
+
+		require.NoError(t, w.Write(ctx, newTestMessages(1, 2, 3)))
+		require.Equal(t, []uint64{3}, limiter.taken)
+	})
+	t.Run("CustomCost", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		limiter := &testRateLimiter{}
+		w := newTestWriterStopped(WithRateLimiter(limiter, func(messages []PublicMessage) uint64 {
+			return uint64(10 * len(messages))
+		}))
+		w.firstConnectionHandled.Store(true)
+
+		require.NoError(t, w.Write(ctx, newTestMessages(1, 2)))
+		require.Equal(t, []uint64{20}, limiter.taken)
+	})
+	t.Run("LimiterError", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		testErr := errors.New("test")
+		w := newTestWriterStopped(WithRateLimiter(&testRateLimiter{err: testErr}, nil))
+		w.firstConnectionHandled.Store(true)
+
+		err := w.Write(ctx, newTestMessages(1))
+		require.ErrorIs(t, err, testErr)
+		require.NotErrorIs(t, err, PublicErrMessagesPutToInternalQueueBeforeError)
+		require.Empty(t, w.queue.messagesByOrder)
+	})
+}
+
+type testRateLimiter struct {
+	err   error
+	taken []uint64
+}
+
+func (l *testRateLimiter) Wait(ctx context.Context, n uint64) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.taken = append(l.taken, n)
+
+	return nil
+}
+
+func TestWriterReconnector_WriteAsync(t *testing.T) {
+	t.Run("ResolveByAcks", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		w := newTestWriterStopped(WithWaitAckOnWrite(true))
+		w.firstConnectionHandled.Store(true)
+
+		futures, err := w.WriteAsync(ctx, newTestMessages(1, 2, 3))
+		require.NoError(t, err)
+		require.Len(t, futures, 3)
+
+		err = w.queue.AcksReceivedFromPartition(5, []rawtopicwriter.WriteAck{
+			{
+				SeqNo: 1,
+				MessageWriteStatus: rawtopicwriter.MessageWriteStatus{
+					Type:          rawtopicwriter.WriteStatusTypeWritten,
+					WrittenOffset: 10,
+				},
+			},
+			{
+				SeqNo: 2,
+				MessageWriteStatus: rawtopicwriter.MessageWriteStatus{
+					Type: rawtopicwriter.WriteStatusTypeSkipped,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		res, err := futures[0].Wait(ctx)
+		require.NoError(t, err)
+		require.Equal(t, PublicWriteResult{
+			SeqNo:       1,
+			PartitionID: 5,
+			Status:      PublicWriteStatusWritten,
+			Offset:      10,
+		}, res)
+
+		res, err = futures[1].Wait(ctx)
+		require.NoError(t, err)
+		require.Equal(t, PublicWriteResult{
+			SeqNo:       2,
+			PartitionID: 5,
+			Status:      PublicWriteStatusSkipped,
+		}, res)
+
+		require.False(t, isClosed(futures[2].Done()))
+
+		testErr := errors.New("test")
+		require.NoError(t, w.queue.Close(testErr))
+		_, err = futures[2].Wait(ctx)
+		require.ErrorIs(t, err, testErr)
+	})
+	t.Run("NoFuturesOnError", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		w := newTestWriterStopped(WithAutoSetSeqNo(true))
+		w.firstConnectionHandled.Store(true)
+
+		futures, err := w.WriteAsync(ctx, newTestMessages(1))
+		require.Error(t, err)
+		require.Nil(t, futures)
+	})
+}
+
 func TestEnv(t *testing.T) {
 	xtest.TestManyTimes(t, func(t testing.TB) {
 		env := newTestEnv(t, nil)
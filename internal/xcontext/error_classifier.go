@@ -0,0 +1,31 @@
+package xcontext
+
+import "context"
+
+type ctxErrorClassifierKey struct{}
+
+// WithErrorClassifier returns a copy of parent context with the function which overrides the retry properties
+// of the errors of the retried operation
+func WithErrorClassifier(ctx context.Context, classify func(err error) error) context.Context {
+	return context.WithValue(ctx, ctxErrorClassifierKey{}, classify)
+}
+
+// ClassifyError applies the error classifier of the context to err
+func ClassifyError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if classify, ok := ctx.Value(ctxErrorClassifierKey{}).(func(err error) error); ok && classify != nil {
+		return classify(err)
+	}
+
+	return err
+}
+
+// HasErrorClassifier reports whether the context has the error classifier
+func HasErrorClassifier(ctx context.Context) bool {
+	classify, ok := ctx.Value(ctxErrorClassifierKey{}).(func(err error) error)
+
+	return ok && classify != nil
+}
@@ -477,6 +477,54 @@ func internalDriver(l Logger, d trace.Detailer) trace.Driver {
 				)
 			}
 		},
+		OnBalancerEject: func(info trace.DriverBalancerEjectInfo) {
+			if d.Details()&trace.DriverBalancerEvents == 0 {
+				return
+			}
+			ctx := with(info.Context, WARN, "ydb", "driver", "balancer", "eject")
+			l.Log(ctx, "endpoint ejected",
+				kv.Stringer("endpoint", info.Endpoint),
+				kv.NamedError("cause", info.Cause),
+				kv.Int("ejected", info.Ejected),
+				kv.Version(),
+			)
+		},
+		OnBalancerProbe: func(info trace.DriverBalancerProbeStartInfo) func(trace.DriverBalancerProbeDoneInfo) {
+			if d.Details()&trace.DriverBalancerEvents == 0 {
+				return nil
+			}
+			ctx := with(*info.Context, TRACE, "ydb", "driver", "balancer", "probe")
+			endpoint := info.Endpoint
+			l.Log(ctx, "start",
+				kv.Stringer("endpoint", endpoint),
+			)
+			start := time.Now()
+
+			return func(info trace.DriverBalancerProbeDoneInfo) {
+				if info.Error == nil {
+					l.Log(ctx, "done",
+						kv.Stringer("endpoint", endpoint),
+						kv.Latency(start),
+					)
+				} else {
+					l.Log(WithLevel(ctx, DEBUG), "failed",
+						kv.Stringer("endpoint", endpoint),
+						kv.Latency(start),
+						kv.Error(info.Error),
+					)
+				}
+			}
+		},
+		OnBalancerRestore: func(info trace.DriverBalancerRestoreInfo) {
+			if d.Details()&trace.DriverBalancerEvents == 0 {
+				return
+			}
+			ctx := with(info.Context, INFO, "ydb", "driver", "balancer", "restore")
+			l.Log(ctx, "endpoint restored",
+				kv.Stringer("endpoint", info.Endpoint),
+				kv.Int("ejected", info.Ejected),
+			)
+		},
 		OnGetCredentials: func(info trace.DriverGetCredentialsStartInfo) func(trace.DriverGetCredentialsDoneInfo) {
 			if d.Details()&trace.DriverCredentialsEvents == 0 {
 				return nil
@@ -340,6 +340,26 @@ func internalQuery(
 				}
 			}
 		},
+		OnHedge: func(info trace.QueryHedgeStartInfo) func(trace.QueryHedgeDoneInfo) {
+			if d.Details()&trace.QueryEvents == 0 {
+				return nil
+			}
+			ctx := with(*info.Context, DEBUG, "ydb", "query", "hedge")
+			l.Log(ctx, "start",
+				kv.Int("attempt", info.Attempt),
+				kv.Duration("delay", info.Delay),
+			)
+			start := time.Now()
+
+			return func(doneInfo trace.QueryHedgeDoneInfo) {
+				l.Log(ctx, "done",
+					kv.Latency(start),
+					kv.Int("attempt", info.Attempt),
+					kv.Bool("won", doneInfo.Won),
+					kv.Error(doneInfo.Error),
+				)
+			}
+		},
 		OnQueryResultSet: func(info trace.QueryQueryResultSetStartInfo) func(trace.QueryQueryResultSetDoneInfo) {
 			if d.Details()&trace.QueryEvents == 0 {
 				return nil
@@ -54,6 +54,17 @@ func internalRetry(l Logger, d trace.Detailer) (t trace.Retry) {
 			}
 		}
 	}
+	t.OnCircuitBreakerStateChange = func(info trace.RetryCircuitBreakerStateChangeInfo) {
+		if d.Details()&trace.RetryEvents == 0 {
+			return
+		}
+		ctx := with(*info.Context, WARN, "ydb", "retry", "circuit", "breaker")
+		l.Log(ctx, "state changed",
+			kv.String("key", info.Key),
+			kv.String("previous", info.PreviousState),
+			kv.String("state", info.State),
+		)
+	}
 
 	return t
 }
@@ -17,6 +17,8 @@ func driver(config Config) (t trace.Driver) {
 	endpoints := config.WithSystem("balancer").GaugeVec("endpoints", "az")
 	balancersDiscoveries := config.WithSystem("balancer").CounterVec("discoveries", "status", "cause")
 	balancerUpdates := config.WithSystem("balancer").CounterVec("updates", "cause")
+	ejected := config.WithSystem("balancer").GaugeVec("ejected", "endpoint", "node_id")
+	probes := config.WithSystem("balancer").CounterVec("probes", "status", "endpoint", "node_id")
 	conns := config.GaugeVec("conns", "endpoint", "node_id")
 	banned := config.WithSystem("conn").GaugeVec("banned", "endpoint", "node_id", "cause")
 	requestStatuses := config.WithSystem("conn").CounterVec("request_statuses", "status", "endpoint", "node_id")
@@ -137,6 +139,38 @@ func driver(config Config) (t trace.Driver) {
 			}
 		}
 	}
+	t.OnBalancerEject = func(info trace.DriverBalancerEjectInfo) {
+		if config.Details()&trace.DriverBalancerEvents != 0 {
+			ejected.With(map[string]string{
+				"endpoint": info.Endpoint.Address(),
+				"node_id":  idToString(info.Endpoint.NodeID()),
+			}).Set(1)
+		}
+	}
+	t.OnBalancerProbe = func(info trace.DriverBalancerProbeStartInfo) func(trace.DriverBalancerProbeDoneInfo) {
+		var (
+			endpoint = info.Endpoint.Address()
+			nodeID   = info.Endpoint.NodeID()
+		)
+
+		return func(info trace.DriverBalancerProbeDoneInfo) {
+			if config.Details()&trace.DriverBalancerEvents != 0 {
+				probes.With(map[string]string{
+					"status":   errorBrief(info.Error),
+					"endpoint": endpoint,
+					"node_id":  idToString(nodeID),
+				}).Inc()
+			}
+		}
+	}
+	t.OnBalancerRestore = func(info trace.DriverBalancerRestoreInfo) {
+		if config.Details()&trace.DriverBalancerEvents != 0 {
+			ejected.With(map[string]string{
+				"endpoint": info.Endpoint.Address(),
+				"node_id":  idToString(info.Endpoint.NodeID()),
+			}).Set(0)
+		}
+	}
 	t.OnConnDial = func(info trace.DriverConnDialStartInfo) func(trace.DriverConnDialDoneInfo) {
 		endpoint := info.Endpoint.Address()
 		nodeID := info.Endpoint.NodeID()
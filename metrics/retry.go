@@ -11,6 +11,7 @@ func retry(config Config) (t trace.Retry) {
 	errs := config.CounterVec("errors", "status", "retry_label", "final")
 	attempts := config.HistogramVec("attempts", []float64{0, 1, 2, 3, 4, 5, 7, 10}, "retry_label")
 	latency := config.TimerVec("latency", "retry_label")
+	circuitBreakerStates := config.CounterVec("circuit_breaker_state_changes", "retry_label", "state")
 	t.OnRetry = func(info trace.RetryLoopStartInfo) func(trace.RetryLoopDoneInfo) {
 		label := info.Label
 		if label == "" {
@@ -34,6 +35,33 @@ func retry(config Config) (t trace.Retry) {
 			}
 		}
 	}
+	{
+		budgetConfig := config.WithSystem("budget")
+		tokens := budgetConfig.GaugeVec("tokens")
+		maxTokens := budgetConfig.GaugeVec("max_tokens")
+		throttled := budgetConfig.GaugeVec("throttled")
+		t.OnBudgetUpdate = func(info trace.RetryBudgetUpdateInfo) {
+			if budgetConfig.Details()&trace.RetryEvents == 0 {
+				return
+			}
+
+			tokens.With(nil).Set(info.Tokens)
+			maxTokens.With(nil).Set(info.MaxTokens)
+			if info.Throttled {
+				throttled.With(nil).Set(1)
+			} else {
+				throttled.With(nil).Set(0)
+			}
+		}
+	}
+	t.OnCircuitBreakerStateChange = func(info trace.RetryCircuitBreakerStateChangeInfo) {
+		if config.Details()&trace.RetryEvents != 0 {
+			circuitBreakerStates.With(map[string]string{
+				"retry_label": info.Key,
+				"state":       info.State,
+			}).Inc()
+		}
+	}
 
 	return t
 }
@@ -25,6 +25,8 @@ import (
 	tableConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"github.com/ydb-platform/ydb-go-sdk/v3/ratelimiter"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/retry/budget"
 	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicoptions"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
@@ -400,6 +402,18 @@ func WithRetryBudget(b budget.Budget) Option {
 	}
 }
 
+// WithRetryErrorClassifier sets the classifier of the errors for the retryers of the query and table clients.
+// The classifier is consulted before the built-in rules, so the errors of the application can be retried.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithRetryErrorClassifier(classifier retry.ErrorClassifier) Option {
+	return func(ctx context.Context, d *Driver) error {
+		d.options = append(d.options, config.WithRetryErrorClassifier(classifier))
+
+		return nil
+	}
+}
+
 // WithTraceDriver appends trace.Driver into driver traces
 func WithTraceDriver(t trace.Driver, opts ...trace.DriverComposeOption) Option { //nolint:gocritic
 	return func(ctx context.Context, d *Driver) error {
@@ -544,6 +558,30 @@ func WithLazyTx(lazyTx bool) Option {
 	}
 }
 
+// WithQueryRateLimit makes the query client take the quota of the rate limiter resource before every operation, so
+// all the clients which share the resource share the common budget of request units.
+//
+// The costFn function returns the amount of request units to take before an operation, its argument is the amount of
+// request units consumed by the last completed operation according to the server or zero if it is unknown yet.
+// If costFn is nil, every operation takes a single request unit. If the server reports that an operation has consumed
+// more request units than taken, the difference is reported to the resource afterwards.
+//
+// The quota is prefetched in batches with ratelimiter.Limiter, the coordination node and the resource must exist.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithQueryRateLimit(nodePath, resourcePath string, costFn func(lastConsumedUnits uint64) uint64) Option {
+	return func(ctx context.Context, d *Driver) error {
+		d.queryOptions = append(d.queryOptions, queryConfig.WithRateLimiter(
+			func() queryConfig.RateLimiter {
+				return ratelimiter.NewLimiter(d.Ratelimiter(), nodePath, resourcePath)
+			},
+			costFn,
+		))
+
+		return nil
+	}
+}
+
 // WithSessionPoolIdleThreshold defines interval for idle sessions
 func WithSessionPoolIdleThreshold(idleThreshold time.Duration) Option {
 	return func(ctx context.Context, d *Driver) error {
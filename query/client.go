@@ -6,6 +6,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/closer"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/query/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/retry/budget"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
@@ -148,6 +149,14 @@ func WithLabel(lbl string) options.RetryOptionsOption {
 	return options.WithLabel(lbl)
 }
 
+// WithRetryErrorClassifier creates option with the classifier of the errors which is consulted before
+// the built-in rules of retries
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithRetryErrorClassifier(classifier retry.ErrorClassifier) options.RetryOptionsOption {
+	return options.WithRetryErrorClassifier(classifier)
+}
+
 // WithRetryBudget creates option with external budget
 func WithRetryBudget(b budget.Budget) options.RetryOptionsOption {
 	return options.WithRetryBudget(b)
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"time"
+
 	"google.golang.org/grpc"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/params"
@@ -73,3 +75,17 @@ func WithCallOptions(opts ...grpc.CallOption) ExecuteOption {
 func WithResourcePool(id string) ExecuteOption {
 	return options.WithResourcePool(id)
 }
+
+// WithHedging is an option for send hedged copies of the query to other nodes if the query does not answer in time.
+// The first answer wins and the other copies are canceled. The copy is sent after the 95th percentile of the
+// recent latencies of the hedged queries of the client, but not later than delay. No more than maxAttempts
+// copies of the query are in flight at a time.
+//
+// Hedging is applied only to the idempotent queries (WithIdempotent) with the read-only transaction control
+// which commits the transaction (SnapshotReadOnlyTxControl, StaleReadOnlyTxControl or OnlineReadOnlyTxControl)
+// and only by the methods of the client. Otherwise, the option is ignored.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithHedging(delay time.Duration, maxAttempts int) ExecuteOption {
+	return options.WithHedging(delay, maxAttempts)
+}
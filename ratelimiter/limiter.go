@@ -0,0 +1,449 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/ratelimiter/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+)
+
+var (
+	// ErrLimiterClosed is returned by the operations of a closed limiter.
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	ErrLimiterClosed = xerrors.Wrap(errors.New("ratelimiter: limiter is closed"))
+
+	// ErrReservationCanceled is returned by the Err method of a canceled reservation.
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	ErrReservationCanceled = xerrors.Wrap(errors.New("ratelimiter: reservation is canceled"))
+)
+
+// Limiter is a local token bucket which is filled with the quota of a rate limiter resource. Limiter acquires the
+// quota from the server in batches, so most of the calls are served locally without a round trip. The batch size
+// adapts to the observed demand: it grows when the callers have to wait for the quota and shrinks when the prefetched
+// quota is not used.
+//
+// The API resembles golang.org/x/time/rate: use Allow to take the tokens if they are available right away, Wait to
+// block until they are and Reserve to be notified when they are.
+//
+// Limiter is safe for concurrent use.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type Limiter struct {
+	client       Client
+	nodePath     string
+	resourcePath string
+	config       options.LimiterConfig
+
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
+	wakeup chan struct{}
+	done   sync.WaitGroup
+
+	mutex      sync.Mutex // guards the fields below
+	closed     bool
+	tokens     uint64
+	pending    []*Reservation
+	missed     uint64 // the largest amount the Allow calls have not got since the last fetch
+	demand     uint64 // the amount requested since the last fetch
+	used       uint64 // the amount taken since the last report
+	unreported uint64
+	batch      uint64
+}
+
+// Reservation holds the tokens reserved with the Limiter.Reserve method.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type Reservation struct {
+	limiter *Limiter
+	amount  uint64
+	ready   chan struct{}
+
+	// the fields below are guarded by the limiter mutex
+	granted  bool
+	canceled bool
+	err      error
+}
+
+// LimiterOption configures a Limiter.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type LimiterOption = options.LimiterOption
+
+// WithLimiterBatch sets the bounds of the amount which the limiter acquires from the server at once.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLimiterBatch(minBatch, maxBatch uint64) LimiterOption {
+	return options.WithLimiterBatch(minBatch, maxBatch)
+}
+
+// WithLimiterReportInterval sets the interval of reporting the consumption passed to the Limiter.Report method.
+// Non-positive interval is ignored.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLimiterReportInterval(interval time.Duration) LimiterOption {
+	return options.WithLimiterReportInterval(interval)
+}
+
+// WithLimiterRetryDelay sets the delay before acquiring the quota again after a failure. Non-positive delay is
+// ignored.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLimiterRetryDelay(delay time.Duration) LimiterOption {
+	return options.WithLimiterRetryDelay(delay)
+}
+
+// NewLimiter creates a new limiter which takes the quota of the resource of the coordination node. Close the limiter
+// to stop the background acquiring and report the remaining consumption.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewLimiter(client Client, nodePath, resourcePath string, opts ...LimiterOption) *Limiter {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Limiter{
+		client:       client,
+		nodePath:     nodePath,
+		resourcePath: resourcePath,
+		config:       options.NewLimiterConfig(opts...),
+		ctx:          ctx,
+		cancel:       cancel,
+		wakeup:       make(chan struct{}, 1),
+	}
+	l.batch = l.config.MinBatch
+
+	l.done.Add(2) //nolint:gomnd
+	go l.fetchLoop()
+	go l.reportLoop()
+
+	return l
+}
+
+// Allow reports whether a single token may be taken right now.
+func (l *Limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN reports whether n tokens may be taken right now. If so, the tokens are taken. Otherwise, the limiter takes
+// the request into account and acquires more quota in background.
+func (l *Limiter) AllowN(n uint64) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.closed {
+		return false
+	}
+
+	l.demand += n
+	if len(l.pending) == 0 && l.tokens >= n {
+		l.take(n)
+
+		return true
+	}
+
+	if n > l.missed {
+		l.missed = n
+	}
+	l.wake()
+
+	return false
+}
+
+// Reserve reserves n tokens. The returned reservation is ready when the tokens are taken or an error occurs.
+// Reservations are served in the FIFO order.
+func (l *Limiter) Reserve(n uint64) *Reservation {
+	r := &Reservation{
+		limiter: l,
+		amount:  n,
+		ready:   make(chan struct{}),
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	switch {
+	case l.closed:
+		r.fail(xerrors.WithStackTrace(ErrLimiterClosed))
+	case len(l.pending) == 0 && l.tokens >= n:
+		l.demand += n
+		l.take(n)
+		r.grant()
+	default:
+		l.demand += n
+		l.pending = append(l.pending, r)
+		l.wake()
+	}
+
+	return r
+}
+
+// Wait blocks until n tokens are taken, an error occurs or ctx is canceled.
+func (l *Limiter) Wait(ctx context.Context, n uint64) error {
+	r := l.Reserve(n)
+
+	select {
+	case <-r.Ready():
+		return r.Err()
+	case <-ctx.Done():
+		r.Cancel()
+
+		return xerrors.WithStackTrace(ctx.Err())
+	}
+}
+
+// Report records the consumption of n tokens which have not been taken from the limiter beforehand, for example when
+// the actual cost of an operation turns out to be greater than the reserved one. The consumption is covered from the
+// local tokens if possible, the rest is reported to the server in background.
+func (l *Limiter) Report(n uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	local := n
+	if local > l.tokens {
+		local = l.tokens
+	}
+	l.take(local)
+	l.unreported += n - local
+}
+
+// Close stops the limiter and reports the remaining consumption to the server. The pending reservations fail with
+// the ErrLimiterClosed error. The unused prefetched quota is lost.
+func (l *Limiter) Close(ctx context.Context) error {
+	l.mutex.Lock()
+	if l.closed {
+		l.mutex.Unlock()
+
+		return xerrors.WithStackTrace(ErrLimiterClosed)
+	}
+	l.closed = true
+	l.failPending(xerrors.WithStackTrace(ErrLimiterClosed))
+	l.mutex.Unlock()
+
+	l.cancel()
+	l.done.Wait()
+
+	l.mutex.Lock()
+	unreported := l.unreported
+	l.unreported = 0
+	l.mutex.Unlock()
+
+	if unreported == 0 {
+		return nil
+	}
+
+	return xerrors.WithStackTrace(
+		l.client.AcquireResource(ctx, l.nodePath, l.resourcePath, unreported, WithReport()),
+	)
+}
+
+// Ready returns a channel which is closed when the reservation is granted or failed.
+func (r *Reservation) Ready() <-chan struct{} {
+	return r.ready
+}
+
+// Err returns the error of the reservation. It is nil until the reservation is ready and after it is granted.
+func (r *Reservation) Err() error {
+	r.limiter.mutex.Lock()
+	defer r.limiter.mutex.Unlock()
+
+	return r.err
+}
+
+// Cancel cancels the reservation. If the reservation has been granted already, its tokens are returned to the
+// limiter, so call Cancel only if the tokens have not been consumed.
+func (r *Reservation) Cancel() {
+	l := r.limiter
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if r.canceled || r.err != nil {
+		return
+	}
+	r.canceled = true
+
+	if r.granted {
+		l.tokens += r.amount
+		if l.used >= r.amount {
+			l.used -= r.amount
+		}
+		l.grantPending()
+
+		return
+	}
+
+	for i, p := range l.pending {
+		if p == r {
+			l.pending = append(l.pending[:i], l.pending[i+1:]...)
+
+			break
+		}
+	}
+	r.fail(xerrors.WithStackTrace(ErrReservationCanceled))
+	// The head of the queue may have changed.
+	l.grantPending()
+}
+
+// grant must be called with the limiter mutex held.
+func (r *Reservation) grant() {
+	r.granted = true
+	close(r.ready)
+}
+
+// fail must be called with the limiter mutex held.
+func (r *Reservation) fail(err error) {
+	r.err = err
+	close(r.ready)
+}
+
+func (l *Limiter) take(n uint64) {
+	l.tokens -= n
+	l.used += n
+}
+
+func (l *Limiter) wake() {
+	select {
+	case l.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (l *Limiter) grantPending() {
+	for len(l.pending) > 0 && l.tokens >= l.pending[0].amount {
+		r := l.pending[0]
+		l.pending[0] = nil
+		l.pending = l.pending[1:]
+		l.take(r.amount)
+		r.grant()
+	}
+}
+
+func (l *Limiter) failPending(err error) {
+	for _, r := range l.pending {
+		r.fail(err)
+	}
+	l.pending = nil
+}
+
+// fetchAmount returns the amount to acquire from the server or zero if the local tokens are enough. It must be called
+// with the limiter mutex held.
+func (l *Limiter) fetchAmount() uint64 {
+	var required uint64
+	for _, r := range l.pending {
+		required += r.amount
+	}
+	if l.missed > required {
+		required = l.missed
+	}
+
+	if required > l.tokens {
+		// The callers are starving, prefetch more next time.
+		l.batch *= 2
+		if l.batch > l.config.MaxBatch {
+			l.batch = l.config.MaxBatch
+		}
+
+		if deficit := required - l.tokens; deficit > l.batch {
+			return deficit
+		}
+
+		return l.batch
+	}
+
+	if l.demand > 0 && l.tokens < l.batch/2 {
+		return l.batch - l.tokens
+	}
+
+	return 0
+}
+
+func (l *Limiter) fetchLoop() {
+	defer l.done.Done()
+
+	for {
+		l.mutex.Lock()
+		amount := l.fetchAmount()
+		if amount > 0 {
+			l.missed, l.demand = 0, 0
+		}
+		l.mutex.Unlock()
+
+		if amount == 0 {
+			select {
+			case <-l.wakeup:
+				continue
+			case <-l.ctx.Done():
+				return
+			}
+		}
+
+		err := l.client.AcquireResource(l.ctx, l.nodePath, l.resourcePath, amount, WithAcquire())
+		if l.ctx.Err() != nil {
+			return
+		}
+
+		l.mutex.Lock()
+		if err != nil {
+			if !retry.Check(err).MustRetry(true) {
+				l.failPending(err)
+			}
+		} else {
+			l.tokens += amount
+			l.grantPending()
+		}
+		l.mutex.Unlock()
+
+		if err != nil {
+			select {
+			case <-l.config.Clock.After(l.config.RetryDelay):
+			case <-l.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func (l *Limiter) reportLoop() {
+	defer l.done.Done()
+
+	ticker := l.config.Clock.NewTicker(l.config.ReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.Chan():
+		case <-l.ctx.Done():
+			return
+		}
+
+		l.mutex.Lock()
+		if l.used < l.batch/2 {
+			// The prefetched quota is hardly used, shrink the batch to waste less of it.
+			l.batch /= 2
+			if l.batch < l.config.MinBatch {
+				l.batch = l.config.MinBatch
+			}
+		}
+		l.used = 0
+		unreported := l.unreported
+		l.unreported = 0
+		l.mutex.Unlock()
+
+		if unreported == 0 {
+			continue
+		}
+
+		if err := l.client.AcquireResource(l.ctx, l.nodePath, l.resourcePath, unreported, WithReport()); err != nil {
+			l.mutex.Lock()
+			l.unreported += unreported
+			l.mutex.Unlock()
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/ratelimiter/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+type testAcquireClient struct {
+	Client
+
+	mutex    sync.Mutex
+	err      error
+	acquired []uint64
+	reported []uint64
+}
+
+func (c *testAcquireClient) AcquireResource(
+	ctx context.Context,
+	coordinationNodePath string,
+	resourcePath string,
+	amount uint64,
+	opts ...options.AcquireOption,
+) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	if options.NewAcquire(opts...).Type() == options.AcquireTypeReport {
+		c.reported = append(c.reported, amount)
+	} else {
+		c.acquired = append(c.acquired, amount)
+	}
+
+	return nil
+}
+
+func (c *testAcquireClient) acquiredTotal() (total uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, amount := range c.acquired {
+		total += amount
+	}
+
+	return total
+}
+
+func newTestLimiter(t *testing.T, client Client, opts ...LimiterOption) (*Limiter, clockwork.FakeClock) {
+	clock := clockwork.NewFakeClock()
+	l := NewLimiter(client, "/node", "resource", append([]LimiterOption{
+		options.WithLimiterClock(clock),
+	}, opts...)...)
+	t.Cleanup(func() {
+		_ = l.Close(context.Background())
+	})
+
+	return l, clock
+}
+
+func TestLimiter(t *testing.T) {
+	t.Run("AllowAfterFetch", func(t *testing.T) {
+		client := &testAcquireClient{}
+		l, _ := newTestLimiter(t, client, WithLimiterBatch(10, 100))
+
+		require.False(t, l.Allow(), "no quota is prefetched before the demand")
+
+		xtest.SpinWaitCondition(t, &l.mutex, func() bool {
+			return l.tokens > 0
+		})
+		require.True(t, l.Allow())
+	})
+	t.Run("WaitGrowsBatch", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		client := &testAcquireClient{}
+		l, _ := newTestLimiter(t, client, WithLimiterBatch(1, 8))
+
+		require.NoError(t, l.Wait(ctx, 1))
+		require.NoError(t, l.Wait(ctx, 20))
+		require.NoError(t, l.Close(ctx))
+
+		require.Equal(t, uint64(2), client.acquired[0], "the batch is doubled when the caller has to wait")
+		require.Greater(t, client.acquired[len(client.acquired)-1], uint64(8), "the deficit exceeds the max batch")
+		require.Equal(t, uint64(4), l.batch)
+	})
+	t.Run("ShrinkBatch", func(t *testing.T) {
+		client := &testAcquireClient{}
+		l, clock := newTestLimiter(t, client, WithLimiterBatch(1, 8), WithLimiterReportInterval(time.Second))
+
+		l.mutex.Lock()
+		l.batch = 8
+		l.mutex.Unlock()
+
+		xtest.SpinWaitCondition(t, &l.mutex, func() bool {
+			clock.Advance(time.Second)
+
+			return l.batch == 1
+		})
+	})
+	t.Run("FIFO", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		client := &testAcquireClient{}
+		l, _ := newTestLimiter(t, client, WithLimiterBatch(4, 4))
+
+		require.NoError(t, l.Wait(ctx, 4))
+
+		r := l.Reserve(4)
+		require.False(t, l.Allow(), "the tokens are reserved for the pending reservation")
+		<-r.Ready()
+		require.NoError(t, r.Err())
+	})
+	t.Run("CancelReservation", func(t *testing.T) {
+		client := &testAcquireClient{}
+		l, _ := newTestLimiter(t, client, WithLimiterBatch(4, 4))
+
+		require.False(t, l.AllowN(4))
+		xtest.SpinWaitCondition(t, &l.mutex, func() bool {
+			return l.tokens == 4
+		})
+
+		r := l.Reserve(3)
+		<-r.Ready()
+		require.NoError(t, r.Err())
+		r.Cancel()
+		require.NoError(t, r.Err())
+
+		l.mutex.Lock()
+		require.Equal(t, uint64(4), l.tokens)
+		l.mutex.Unlock()
+
+		client.mutex.Lock()
+		client.err = xerrors.Retryable(errors.New("unavailable"))
+		client.mutex.Unlock()
+
+		r = l.Reserve(10)
+		r.Cancel()
+		<-r.Ready()
+		require.ErrorIs(t, r.Err(), ErrReservationCanceled)
+	})
+	t.Run("FailOnNonRetryableError", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		errNotFound := errors.New("resource not found")
+		l, _ := newTestLimiter(t, &testAcquireClient{err: errNotFound})
+
+		require.ErrorIs(t, l.Wait(ctx, 1), errNotFound)
+	})
+	t.Run("WaitCanceled", func(t *testing.T) {
+		client := &testAcquireClient{err: xerrors.Retryable(errors.New("unavailable"))}
+		l, _ := newTestLimiter(t, client)
+
+		ctx, cancel := context.WithCancel(xtest.Context(t))
+		cancel()
+		require.ErrorIs(t, l.Wait(ctx, 1), context.Canceled)
+
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		require.Empty(t, l.pending)
+	})
+	t.Run("Report", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		client := &testAcquireClient{}
+		l, clock := newTestLimiter(t, client, WithLimiterBatch(4, 4), WithLimiterReportInterval(time.Second))
+
+		require.NoError(t, l.Wait(ctx, 1))
+		xtest.SpinWaitCondition(t, &l.mutex, func() bool {
+			return l.tokens == 3
+		})
+
+		l.Report(5)
+		l.mutex.Lock()
+		require.Zero(t, l.tokens)
+		require.Equal(t, uint64(2), l.unreported)
+		l.mutex.Unlock()
+
+		xtest.SpinWaitCondition(t, &client.mutex, func() bool {
+			clock.Advance(time.Second)
+
+			return len(client.reported) == 1
+		})
+		require.Equal(t, uint64(2), client.reported[0])
+
+		l.Report(7)
+		require.NoError(t, l.Close(ctx))
+		require.Equal(t, []uint64{2, 7}, client.reported, "the remaining consumption is reported on close")
+	})
+	t.Run("NonPositiveIntervals", func(t *testing.T) {
+		client := &testAcquireClient{}
+		l, _ := newTestLimiter(t, client, WithLimiterReportInterval(0), WithLimiterRetryDelay(-time.Second))
+
+		require.Equal(t, options.DefaultLimiterReportInterval, l.config.ReportInterval)
+		require.Equal(t, options.DefaultLimiterRetryDelay, l.config.RetryDelay)
+	})
+	t.Run("Close", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		client := &testAcquireClient{err: xerrors.Retryable(errors.New("unavailable"))}
+		l, _ := newTestLimiter(t, client)
+
+		r := l.Reserve(1)
+		require.NoError(t, l.Close(ctx))
+		<-r.Ready()
+		require.ErrorIs(t, r.Err(), ErrLimiterClosed)
+
+		require.ErrorIs(t, l.Wait(ctx, 1), ErrLimiterClosed)
+		require.False(t, l.Allow())
+		require.ErrorIs(t, l.Close(ctx), ErrLimiterClosed)
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		client := &testAcquireClient{}
+		l, _ := newTestLimiter(t, client, WithLimiterBatch(1, 16))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					require.NoError(t, l.Wait(ctx, 1))
+				}
+			}()
+		}
+		wg.Wait()
+		require.NoError(t, l.Close(ctx))
+
+		require.Equal(t, uint64(1000), client.acquiredTotal()-l.tokens)
+	})
+}
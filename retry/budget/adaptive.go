@@ -0,0 +1,168 @@
+package budget
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+const (
+	defaultAdaptiveMaxTokens  = 100
+	defaultAdaptiveTokenRatio = 0.1
+	defaultAdaptiveThreshold  = 0.5
+)
+
+type (
+	// Feedback is implemented by the budgets which adapt to the results of attempts. The retry calls report the
+	// result of every attempt to the budget if it implements Feedback.
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	Feedback interface {
+		Budget
+
+		// Report records the result of an attempt and returns the state of the budget after it. The success flag
+		// is false for the attempts failed with retryable errors, other failures are not reported.
+		Report(success bool) State
+	}
+
+	// State is the state of an adaptive budget
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	State struct {
+		Tokens    float64
+		MaxTokens float64
+
+		// Throttled reports whether the retries are switched off
+		Throttled bool
+	}
+
+	adaptiveBudget struct {
+		maxTokens  float64
+		tokenRatio float64
+		threshold  float64
+
+		mutex  sync.Mutex // guards the field below
+		tokens float64
+	}
+	adaptiveBudgetOption func(b *adaptiveBudget)
+)
+
+var _ Feedback = (*adaptiveBudget)(nil)
+
+// WithAdaptiveMaxTokens sets the capacity of the token bucket of the adaptive budget. Default is 100, values less
+// than 1 are ignored.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithAdaptiveMaxTokens(maxTokens float64) adaptiveBudgetOption {
+	return func(b *adaptiveBudget) {
+		if maxTokens >= 1 {
+			b.maxTokens = maxTokens
+		}
+	}
+}
+
+// WithAdaptiveTokenRatio sets the amount of tokens credited on every successful attempt, every failed attempt
+// debits a single token. Default is 0.1, so the retries are switched off when more than about one attempt of
+// eleven fails. Non-positive ratio is ignored.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithAdaptiveTokenRatio(ratio float64) adaptiveBudgetOption {
+	return func(b *adaptiveBudget) {
+		if ratio > 0 {
+			b.tokenRatio = ratio
+		}
+	}
+}
+
+// WithAdaptiveThreshold sets the share of max tokens which the bucket must hold to allow retries. Default is 0.5,
+// values out of the [0, 1) range are ignored.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithAdaptiveThreshold(threshold float64) adaptiveBudgetOption {
+	return func(b *adaptiveBudget) {
+		if threshold >= 0 && threshold < 1 {
+			b.threshold = threshold
+		}
+	}
+}
+
+// Adaptive returns the retry budget in the style of gRPC retry throttling. The budget is a token bucket which is
+// full at first. Every failed attempt debits a token, every successful attempt credits the token ratio. Retries are
+// allowed only while the bucket holds more than the threshold share of max tokens, so the retries are switched off
+// when the error ratio gets high and switched on again when the cluster recovers.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func Adaptive(opts ...adaptiveBudgetOption) *adaptiveBudget {
+	b := &adaptiveBudget{
+		maxTokens:  defaultAdaptiveMaxTokens,
+		tokenRatio: defaultAdaptiveTokenRatio,
+		threshold:  defaultAdaptiveThreshold,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(b)
+		}
+	}
+	b.tokens = b.maxTokens
+
+	return b
+}
+
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (b *adaptiveBudget) Acquire(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.WithStackTrace(err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.throttled() {
+		return xerrors.WithStackTrace(ErrNoQuota)
+	}
+
+	return nil
+}
+
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (b *adaptiveBudget) Report(success bool) State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if success {
+		b.tokens += b.tokenRatio
+		if b.tokens > b.maxTokens {
+			b.tokens = b.maxTokens
+		}
+	} else {
+		b.tokens--
+		if b.tokens < 0 {
+			b.tokens = 0
+		}
+	}
+
+	return b.state()
+}
+
+// State returns the current state of the budget
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (b *adaptiveBudget) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state()
+}
+
+func (b *adaptiveBudget) state() State {
+	return State{
+		Tokens:    b.tokens,
+		MaxTokens: b.maxTokens,
+		Throttled: b.throttled(),
+	}
+}
+
+func (b *adaptiveBudget) throttled() bool {
+	return b.tokens <= b.maxTokens*b.threshold
+}
@@ -0,0 +1,72 @@
+package budget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+func TestAdaptive(t *testing.T) {
+	t.Run("ThrottleOnFailures", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		b := Adaptive(WithAdaptiveMaxTokens(10), WithAdaptiveTokenRatio(0.5))
+		require.Equal(t, State{Tokens: 10, MaxTokens: 10}, b.State())
+		require.NoError(t, b.Acquire(ctx))
+
+		for i := 0; i < 4; i++ {
+			require.False(t, b.Report(false).Throttled)
+		}
+		require.True(t, b.Report(false).Throttled, "the bucket holds the threshold share of max tokens")
+		require.ErrorIs(t, b.Acquire(ctx), ErrNoQuota)
+
+		// failures never take the bucket below zero
+		for i := 0; i < 10; i++ {
+			b.Report(false)
+		}
+		require.Zero(t, b.State().Tokens)
+
+		for i := 0; i < 10; i++ {
+			require.True(t, b.Report(true).Throttled)
+		}
+		require.False(t, b.Report(true).Throttled)
+		require.NoError(t, b.Acquire(ctx))
+
+		// successes never take the bucket over max tokens
+		for i := 0; i < 100; i++ {
+			b.Report(true)
+		}
+		require.Equal(t, float64(10), b.State().Tokens)
+	})
+	t.Run("Threshold", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		b := Adaptive(WithAdaptiveMaxTokens(10), WithAdaptiveThreshold(0.9))
+
+		require.NoError(t, b.Acquire(ctx))
+		require.True(t, b.Report(false).Throttled)
+		require.ErrorIs(t, b.Acquire(ctx), ErrNoQuota)
+	})
+	t.Run("InvalidOptions", func(t *testing.T) {
+		for _, opt := range []adaptiveBudgetOption{
+			WithAdaptiveMaxTokens(0),
+			WithAdaptiveTokenRatio(0),
+			WithAdaptiveTokenRatio(-1),
+			WithAdaptiveThreshold(-0.1),
+			WithAdaptiveThreshold(1),
+		} {
+			b := Adaptive(opt)
+			require.Equal(t, float64(defaultAdaptiveMaxTokens), b.maxTokens)
+			require.Equal(t, defaultAdaptiveTokenRatio, b.tokenRatio)
+			require.Equal(t, defaultAdaptiveThreshold, b.threshold)
+			require.NoError(t, b.Acquire(xtest.Context(t)))
+		}
+	})
+	t.Run("ContextCanceled", func(t *testing.T) {
+		ctx, cancel := xcontext.WithCancel(xtest.Context(t))
+		cancel()
+		require.ErrorIs(t, Adaptive().Acquire(ctx), context.Canceled)
+	})
+}
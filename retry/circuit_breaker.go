@@ -0,0 +1,448 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+const (
+	// CircuitBreakerClosed is the state of the circuit which lets the operations through and counts their failures
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	CircuitBreakerClosed = CircuitBreakerState(iota)
+
+	// CircuitBreakerOpen is the state of the circuit which fails the operations fast
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	CircuitBreakerOpen
+
+	// CircuitBreakerHalfOpen is the state of the circuit which lets a few probe operations through to check
+	// whether the cluster has recovered
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	CircuitBreakerHalfOpen
+)
+
+const (
+	defaultCircuitBreakerFailureRate      = 0.5
+	defaultCircuitBreakerMinRequests      = 10
+	defaultCircuitBreakerWindow           = 10 * time.Second
+	defaultCircuitBreakerOpenTimeout      = 5 * time.Second
+	defaultCircuitBreakerHalfOpenRequests = 1
+
+	circuitBreakerWindowBuckets = 10
+)
+
+// ErrCircuitBreakerOpen is matched by the errors returned while the circuit breaker is open
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+var ErrCircuitBreakerOpen = errors.New("retry: circuit breaker is open")
+
+type (
+	// CircuitBreakerState is the state of a circuit of the circuit breaker
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	CircuitBreakerState int
+
+	// CircuitBreaker stops calling the operations which fail too often or too slow. Every retry label (or operation
+	// if the label is not set) has its own circuit.
+	//
+	// The circuit is closed at first. If the rate of failed or slow attempts within the window exceeds the threshold,
+	// the circuit is opened and the calls fail fast with the CircuitBreakerOpenError error. After the open timeout the
+	// circuit becomes half-open and lets a few probe attempts through: if they succeed, the circuit is closed again,
+	// otherwise it is opened again.
+	//
+	// Only retryable errors are counted as failures, because non-retryable ones do not indicate problems of the
+	// cluster. CircuitBreaker is safe for concurrent use and is meant to be shared between calls.
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	CircuitBreaker struct {
+		clock            clockwork.Clock
+		failureRate      float64
+		slowCallDuration time.Duration
+		slowCallRate     float64
+		minRequests      int
+		window           time.Duration
+		openTimeout      time.Duration
+		halfOpenRequests int
+
+		mutex    sync.Mutex // guards the field below
+		circuits map[string]*circuit
+	}
+
+	// CircuitBreakerOption configures a CircuitBreaker
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	CircuitBreakerOption func(cb *CircuitBreaker)
+
+	// CircuitBreakerOpenError is returned from the retry call while the circuit breaker is open
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	CircuitBreakerOpenError struct {
+		// Key is the retry label or the operation of the circuit
+		Key string
+
+		// RetryAfter is the time left until the circuit becomes half-open
+		RetryAfter time.Duration
+	}
+
+	circuit struct {
+		state      CircuitBreakerState
+		generation uint64 // changes with every state change to skip the results of the stale attempts
+		openedAt   time.Time
+		buckets    [circuitBreakerWindowBuckets]circuitBucket
+		probes     int // inflight attempts in the half-open state
+		probed     int // succeeded attempts in the half-open state
+	}
+
+	circuitBucket struct {
+		start    time.Time
+		total    int
+		failures int
+		slow     int
+	}
+)
+
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerClosed:
+		return "closed"
+	case CircuitBreakerOpen:
+		return "open"
+	case CircuitBreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+func (e *CircuitBreakerOpenError) Error() string {
+	return fmt.Sprintf("retry: circuit breaker of %q is open, retry after %v", e.Key, e.RetryAfter)
+}
+
+func (e *CircuitBreakerOpenError) Is(target error) bool {
+	return target == ErrCircuitBreakerOpen //nolint:errorlint
+}
+
+// WithCircuitBreakerFailureRate sets the rate of failed attempts within the window which opens the circuit.
+// Default is 0.5.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCircuitBreakerFailureRate(rate float64) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.failureRate = rate
+	}
+}
+
+// WithCircuitBreakerSlowCalls makes the attempts which take longer than duration count as slow and sets the rate
+// of slow attempts within the window which opens the circuit. Slow attempts are not counted by default.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCircuitBreakerSlowCalls(duration time.Duration, rate float64) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.slowCallDuration = duration
+		cb.slowCallRate = rate
+	}
+}
+
+// WithCircuitBreakerMinRequests sets the minimum number of attempts within the window required to open the circuit.
+// Default is 10.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCircuitBreakerMinRequests(n int) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.minRequests = n
+	}
+}
+
+// WithCircuitBreakerWindow sets the sliding window of the attempts which are taken into account. Default is 10s.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCircuitBreakerWindow(window time.Duration) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.window = window
+	}
+}
+
+// WithCircuitBreakerOpenTimeout sets the time the circuit stays open before it becomes half-open. Default is 5s.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCircuitBreakerOpenTimeout(timeout time.Duration) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.openTimeout = timeout
+	}
+}
+
+// WithCircuitBreakerHalfOpenRequests sets the number of probe attempts in the half-open state which must succeed to
+// close the circuit. Default is 1.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCircuitBreakerHalfOpenRequests(n int) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.halfOpenRequests = n
+	}
+}
+
+func withCircuitBreakerClock(clock clockwork.Clock) CircuitBreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.clock = clock
+	}
+}
+
+// NewCircuitBreaker creates a new circuit breaker. Pass it to the retry calls with the WithCircuitBreaker option.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewCircuitBreaker(opts ...CircuitBreakerOption) *CircuitBreaker {
+	cb := &CircuitBreaker{
+		clock:            clockwork.NewRealClock(),
+		failureRate:      defaultCircuitBreakerFailureRate,
+		minRequests:      defaultCircuitBreakerMinRequests,
+		window:           defaultCircuitBreakerWindow,
+		openTimeout:      defaultCircuitBreakerOpenTimeout,
+		halfOpenRequests: defaultCircuitBreakerHalfOpenRequests,
+		circuits:         make(map[string]*circuit),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cb)
+		}
+	}
+	if cb.minRequests < 1 {
+		cb.minRequests = 1
+	}
+	if cb.halfOpenRequests < 1 {
+		cb.halfOpenRequests = 1
+	}
+
+	return cb
+}
+
+// State returns the current state of the circuit with the key
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (cb *CircuitBreaker) State(key string) CircuitBreakerState {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	c, ok := cb.circuits[key]
+	if !ok {
+		return CircuitBreakerClosed
+	}
+	if c.state == CircuitBreakerOpen && !cb.clock.Now().Before(c.openedAt.Add(cb.openTimeout)) {
+		return CircuitBreakerHalfOpen
+	}
+
+	return c.state
+}
+
+// acquire checks whether an attempt of the operation with the key may be done. If so, the returned function must be
+// called with the result of the attempt.
+func (cb *CircuitBreaker) acquire(
+	ctx context.Context, key string, t *trace.Retry,
+) (done func(err error), _ error) {
+	var changes []circuitStateChange
+	defer func() {
+		traceCircuitStateChanges(ctx, t, key, changes)
+	}()
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	c, ok := cb.circuits[key]
+	if !ok {
+		c = &circuit{}
+		cb.circuits[key] = c
+	}
+
+	now := cb.clock.Now()
+	if c.state == CircuitBreakerOpen {
+		if retryAfter := c.openedAt.Add(cb.openTimeout).Sub(now); retryAfter > 0 {
+			return nil, &CircuitBreakerOpenError{Key: key, RetryAfter: retryAfter}
+		}
+		changes = append(changes, c.setState(CircuitBreakerHalfOpen))
+	}
+
+	if c.state == CircuitBreakerHalfOpen {
+		if c.probes+c.probed >= cb.halfOpenRequests {
+			return nil, &CircuitBreakerOpenError{Key: key}
+		}
+		c.probes++
+	}
+
+	generation := c.generation
+
+	return func(err error) {
+		cb.done(ctx, t, key, c, generation, now, err)
+	}, nil
+}
+
+func (cb *CircuitBreaker) done(
+	ctx context.Context, t *trace.Retry, key string, c *circuit, generation uint64, start time.Time, err error,
+) {
+	now := cb.clock.Now()
+	failed := err != nil && Check(err).MustRetry(true)
+	slow := cb.slowCallDuration > 0 && now.Sub(start) >= cb.slowCallDuration
+
+	var changes []circuitStateChange
+	defer func() {
+		traceCircuitStateChanges(ctx, t, key, changes)
+	}()
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if c.generation != generation {
+		// The attempt has been started before the state change.
+		return
+	}
+
+	switch c.state {
+	case CircuitBreakerHalfOpen:
+		c.probes--
+		switch {
+		case failed || slow:
+			c.openedAt = now
+			changes = append(changes, c.setState(CircuitBreakerOpen))
+		case err == nil:
+			c.probed++
+			if c.probed >= cb.halfOpenRequests {
+				changes = append(changes, c.setState(CircuitBreakerClosed))
+			}
+		}
+	case CircuitBreakerClosed:
+		b := c.bucket(now, cb.window)
+		b.total++
+		if failed {
+			b.failures++
+		}
+		if slow {
+			b.slow++
+		}
+
+		total, failures, slows := c.count(now, cb.window)
+		if total < cb.minRequests {
+			return
+		}
+		if float64(failures) >= cb.failureRate*float64(total) ||
+			(cb.slowCallDuration > 0 && float64(slows) >= cb.slowCallRate*float64(total)) {
+			c.openedAt = now
+			changes = append(changes, c.setState(CircuitBreakerOpen))
+		}
+	case CircuitBreakerOpen:
+	}
+}
+
+// circuitStateChange is the state transition of the circuit, which is traced after the circuit breaker mutex is
+// released, so the trace callbacks may use the circuit breaker.
+type circuitStateChange struct {
+	previous CircuitBreakerState
+	state    CircuitBreakerState
+}
+
+// setState must be called with the circuit breaker mutex held.
+func (c *circuit) setState(state CircuitBreakerState) circuitStateChange {
+	previous := c.state
+	c.state = state
+	c.generation++
+	c.probes, c.probed = 0, 0
+	if state == CircuitBreakerClosed {
+		c.buckets = [circuitBreakerWindowBuckets]circuitBucket{}
+	}
+
+	return circuitStateChange{previous: previous, state: state}
+}
+
+func traceCircuitStateChanges(ctx context.Context, t *trace.Retry, key string, changes []circuitStateChange) {
+	for _, change := range changes {
+		trace.RetryOnCircuitBreakerStateChange(t, &ctx, key, change.previous.String(), change.state.String())
+	}
+}
+
+// bucket returns the bucket of the window for the moment, the stale bucket is reset.
+func (c *circuit) bucket(now time.Time, window time.Duration) *circuitBucket {
+	width := window / circuitBreakerWindowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	start := now.Truncate(width)
+	b := &c.buckets[(start.UnixNano()/int64(width))%circuitBreakerWindowBuckets]
+	if !b.start.Equal(start) {
+		*b = circuitBucket{start: start}
+	}
+
+	return b
+}
+
+func (c *circuit) count(now time.Time, window time.Duration) (total, failures, slow int) {
+	for i := range c.buckets {
+		b := &c.buckets[i]
+		if now.Sub(b.start) >= window {
+			continue
+		}
+		total += b.total
+		failures += b.failures
+		slow += b.slow
+	}
+
+	return total, failures, slow
+}
+
+var _ Option = circuitBreakerOption{}
+
+type circuitBreakerOption struct {
+	cb *CircuitBreaker
+}
+
+func (o circuitBreakerOption) ApplyRetryOption(opts *retryOptions) {
+	opts.circuitBreaker = o.cb
+}
+
+func (o circuitBreakerOption) ApplyDoOption(opts *doOptions) {
+	opts.retryOptions = append(opts.retryOptions, WithCircuitBreaker(o.cb))
+}
+
+func (o circuitBreakerOption) ApplyDoTxOption(opts *doTxOptions) {
+	opts.retryOptions = append(opts.retryOptions, WithCircuitBreaker(o.cb))
+}
+
+// WithCircuitBreaker makes the retry call go through the circuit breaker. Every attempt is counted by the circuit of
+// the retry label or the operation if the label is not set. While the circuit is open, the retry call fails fast with
+// the CircuitBreakerOpenError error.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCircuitBreaker(cb *CircuitBreaker) circuitBreakerOption {
+	return circuitBreakerOption{cb: cb}
+}
+
+func opWithCircuitBreaker[T any](ctx context.Context,
+	options *retryOptions, op func(context.Context) (T, error),
+) (_ T, finalErr error) {
+	if options.circuitBreaker == nil {
+		return opWithRecover(ctx, options, op)
+	}
+
+	var zeroValue T
+
+	key := options.label
+	if key == "" && options.call != nil {
+		key = options.call.String()
+	}
+
+	done, err := options.circuitBreaker.acquire(ctx, key, options.trace)
+	if err != nil {
+		return zeroValue, xerrors.WithStackTrace(err)
+	}
+
+	v, err := opWithRecover(ctx, options, op)
+	done(err)
+
+	return v, err
+}
@@ -0,0 +1,201 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+type testCircuitBreakerTrace struct {
+	trace.Retry
+
+	changes []string
+}
+
+func newTestCircuitBreakerTrace() *testCircuitBreakerTrace {
+	t := &testCircuitBreakerTrace{}
+	t.OnCircuitBreakerStateChange = func(info trace.RetryCircuitBreakerStateChangeInfo) {
+		t.changes = append(t.changes, info.Key+": "+info.PreviousState+" -> "+info.State)
+	}
+
+	return t
+}
+
+func attemptCircuitBreaker(cb *CircuitBreaker, t *trace.Retry, key string, err error) error {
+	done, acquireErr := cb.acquire(context.Background(), key, t)
+	if acquireErr != nil {
+		return acquireErr
+	}
+	done(err)
+
+	return nil
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	errRetryable := xerrors.Retryable(errors.New("overloaded"))
+
+	t.Run("OpenOnFailureRate", func(t *testing.T) {
+		tr := newTestCircuitBreakerTrace()
+		cb := NewCircuitBreaker(
+			withCircuitBreakerClock(clockwork.NewFakeClock()),
+			WithCircuitBreakerMinRequests(4),
+			WithCircuitBreakerFailureRate(0.5),
+		)
+
+		require.NoError(t, attemptCircuitBreaker(cb, &tr.Retry, "a", nil))
+		require.NoError(t, attemptCircuitBreaker(cb, &tr.Retry, "a", errRetryable))
+		require.NoError(t, attemptCircuitBreaker(cb, &tr.Retry, "a", nil))
+		require.Equal(t, CircuitBreakerClosed, cb.State("a"))
+
+		require.NoError(t, attemptCircuitBreaker(cb, &tr.Retry, "a", errRetryable))
+		require.Equal(t, CircuitBreakerOpen, cb.State("a"))
+		require.Equal(t, []string{"a: closed -> open"}, tr.changes)
+
+		err := attemptCircuitBreaker(cb, &tr.Retry, "a", nil)
+		require.ErrorIs(t, err, ErrCircuitBreakerOpen)
+		var openErr *CircuitBreakerOpenError
+		require.ErrorAs(t, err, &openErr)
+		require.Equal(t, "a", openErr.Key)
+		require.Equal(t, defaultCircuitBreakerOpenTimeout, openErr.RetryAfter)
+
+		require.NoError(t, attemptCircuitBreaker(cb, &tr.Retry, "b", nil), "circuits are independent")
+	})
+	t.Run("NonRetryableErrorsAreNotFailures", func(t *testing.T) {
+		cb := NewCircuitBreaker(
+			withCircuitBreakerClock(clockwork.NewFakeClock()),
+			WithCircuitBreakerMinRequests(2),
+		)
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, attemptCircuitBreaker(cb, &trace.Retry{}, "a", errors.New("bad request")))
+		}
+		require.Equal(t, CircuitBreakerClosed, cb.State("a"))
+	})
+	t.Run("HalfOpen", func(t *testing.T) {
+		tr := newTestCircuitBreakerTrace()
+		clock := clockwork.NewFakeClock()
+		cb := NewCircuitBreaker(
+			withCircuitBreakerClock(clock),
+			WithCircuitBreakerMinRequests(1),
+			WithCircuitBreakerOpenTimeout(time.Second),
+		)
+
+		require.NoError(t, attemptCircuitBreaker(cb, &tr.Retry, "a", errRetryable))
+		require.Equal(t, CircuitBreakerOpen, cb.State("a"))
+
+		clock.Advance(time.Second)
+		require.Equal(t, CircuitBreakerHalfOpen, cb.State("a"))
+
+		// a failed probe opens the circuit again
+		require.NoError(t, attemptCircuitBreaker(cb, &tr.Retry, "a", errRetryable))
+		require.ErrorIs(t, attemptCircuitBreaker(cb, &tr.Retry, "a", nil), ErrCircuitBreakerOpen)
+
+		clock.Advance(time.Second)
+		done, err := cb.acquire(context.Background(), "a", &tr.Retry)
+		require.NoError(t, err)
+		require.ErrorIs(t, attemptCircuitBreaker(cb, &tr.Retry, "a", nil), ErrCircuitBreakerOpen,
+			"only one probe is allowed",
+		)
+		done(nil)
+		require.Equal(t, CircuitBreakerClosed, cb.State("a"))
+
+		require.Equal(t, []string{
+			"a: closed -> open",
+			"a: open -> half-open",
+			"a: half-open -> open",
+			"a: open -> half-open",
+			"a: half-open -> closed",
+		}, tr.changes)
+	})
+	t.Run("SlowCalls", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		cb := NewCircuitBreaker(
+			withCircuitBreakerClock(clock),
+			WithCircuitBreakerMinRequests(2),
+			WithCircuitBreakerSlowCalls(time.Second, 1),
+		)
+
+		for i := 0; i < 2; i++ {
+			done, err := cb.acquire(context.Background(), "a", &trace.Retry{})
+			require.NoError(t, err)
+			clock.Advance(2 * time.Second)
+			done(nil)
+		}
+		require.Equal(t, CircuitBreakerOpen, cb.State("a"))
+	})
+	t.Run("SlidingWindow", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		cb := NewCircuitBreaker(
+			withCircuitBreakerClock(clock),
+			WithCircuitBreakerMinRequests(2),
+			WithCircuitBreakerFailureRate(1),
+			WithCircuitBreakerWindow(10*time.Second),
+		)
+
+		require.NoError(t, attemptCircuitBreaker(cb, &trace.Retry{}, "a", errRetryable))
+		clock.Advance(10 * time.Second)
+		require.NoError(t, attemptCircuitBreaker(cb, &trace.Retry{}, "a", errRetryable))
+		require.Equal(t, CircuitBreakerClosed, cb.State("a"), "the first failure is out of the window")
+
+		clock.Advance(5 * time.Second)
+		require.NoError(t, attemptCircuitBreaker(cb, &trace.Retry{}, "a", errRetryable))
+		require.Equal(t, CircuitBreakerOpen, cb.State("a"))
+	})
+	t.Run("Retry", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		cb := NewCircuitBreaker(WithCircuitBreakerMinRequests(3))
+
+		attempts := 0
+		err := Retry(ctx, func(ctx context.Context) error {
+			attempts++
+
+			return errRetryable
+		}, WithLabel("a"), WithCircuitBreaker(cb))
+		require.ErrorIs(t, err, ErrCircuitBreakerOpen)
+		require.Equal(t, 3, attempts)
+
+		err = Retry(ctx, func(ctx context.Context) error {
+			attempts++
+
+			return nil
+		}, WithLabel("a"), WithCircuitBreaker(cb))
+		require.ErrorIs(t, err, ErrCircuitBreakerOpen, "the open circuit fails fast")
+		require.Equal(t, 3, attempts)
+
+		require.NoError(t, Retry(ctx, func(ctx context.Context) error {
+			return nil
+		}, WithLabel("b"), WithCircuitBreaker(cb)))
+	})
+	t.Run("TraceOutsideLock", func(t *testing.T) {
+		clock := clockwork.NewFakeClock()
+		cb := NewCircuitBreaker(
+			withCircuitBreakerClock(clock),
+			WithCircuitBreakerMinRequests(1),
+			WithCircuitBreakerOpenTimeout(time.Second),
+		)
+		var states []CircuitBreakerState
+		tr := &trace.Retry{
+			OnCircuitBreakerStateChange: func(info trace.RetryCircuitBreakerStateChangeInfo) {
+				// The trace callback may use the circuit breaker.
+				states = append(states, cb.State(info.Key))
+			},
+		}
+
+		require.NoError(t, attemptCircuitBreaker(cb, tr, "a", errRetryable))
+		clock.Advance(time.Second)
+		require.NoError(t, attemptCircuitBreaker(cb, tr, "a", nil))
+		require.Equal(t, []CircuitBreakerState{
+			CircuitBreakerOpen,
+			CircuitBreakerHalfOpen,
+			CircuitBreakerClosed,
+		}, states)
+	})
+}
@@ -0,0 +1,135 @@
+package retry
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/backoff"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+type (
+	// Decision is the verdict of the ErrorClassifier on the error of the attempt
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	Decision struct {
+		// Classified reports whether the classifier recognizes the error. The errors which are not classified
+		// are checked with the built-in rules.
+		Classified bool
+
+		// Retryable reports whether the operation must be retried on the error regardless of idempotency
+		Retryable bool
+
+		// Backoff is the type of delay before the next attempt: TypeNoBackoff, TypeFastBackoff or TypeSlowBackoff
+		Backoff backoff.Type
+
+		// DeleteSession reports whether the session of the failed attempt must be deleted
+		DeleteSession bool
+	}
+
+	// ErrorClassifier decides how to retry the errors which the built-in rules do not know, such as the errors
+	// of the application
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	ErrorClassifier func(err error) Decision
+
+	// classifiedError overrides the retry properties of the wrapped error with the decision of the classifier
+	classifiedError struct {
+		err      error
+		decision Decision
+	}
+)
+
+var _ xerrors.Error = (*classifiedError)(nil)
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+func (e *classifiedError) Code() int32 {
+	var ydbErr xerrors.Error
+	if xerrors.As(e.err, &ydbErr) {
+		return ydbErr.Code()
+	}
+
+	return -1
+}
+
+func (e *classifiedError) Name() string {
+	return "classified"
+}
+
+func (e *classifiedError) Type() xerrors.Type {
+	if e.decision.Retryable {
+		return xerrors.TypeRetryable
+	}
+
+	return xerrors.TypeNonRetryable
+}
+
+func (e *classifiedError) BackoffType() backoff.Type {
+	return e.decision.Backoff
+}
+
+func (e *classifiedError) IsRetryObjectValid() bool {
+	return !e.decision.DeleteSession
+}
+
+var _ Option = errorClassifierOption(nil)
+
+type errorClassifierOption ErrorClassifier
+
+func (classifier errorClassifierOption) ApplyRetryOption(opts *retryOptions) {
+	opts.errorClassifier = ErrorClassifier(classifier)
+}
+
+func (classifier errorClassifierOption) ApplyDoOption(opts *doOptions) {
+	opts.retryOptions = append(opts.retryOptions, WithErrorClassifier(ErrorClassifier(classifier)))
+}
+
+func (classifier errorClassifierOption) ApplyDoTxOption(opts *doTxOptions) {
+	opts.retryOptions = append(opts.retryOptions, WithErrorClassifier(ErrorClassifier(classifier)))
+}
+
+// WithErrorClassifier returns the option which consults the classifier on the errors of the attempts before
+// the built-in rules
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithErrorClassifier(classifier ErrorClassifier) errorClassifierOption {
+	return errorClassifierOption(classifier)
+}
+
+// classify wraps err with the decision of the classifier if the classifier recognizes the error
+func (classifier ErrorClassifier) classify(err error) error {
+	if err == nil || classifier == nil {
+		return err
+	}
+
+	decision := classifier(err)
+	if !decision.Classified {
+		return err
+	}
+
+	return &classifiedError{
+		err:      err,
+		decision: decision,
+	}
+}
+
+// withErrorClassifier passes the classifier to the retried operation, so the pools of sessions can check
+// the errors of the operation with the classifier. The classifier of the outer retry call is reset.
+func withErrorClassifier(ctx context.Context, classifier ErrorClassifier) context.Context {
+	if classifier == nil {
+		if !xcontext.HasErrorClassifier(ctx) {
+			return ctx
+		}
+
+		return xcontext.WithErrorClassifier(ctx, nil)
+	}
+
+	return xcontext.WithErrorClassifier(ctx, classifier.classify)
+}
@@ -0,0 +1,112 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+var errConflict = errors.New("conflict, try again")
+
+func conflictClassifier(err error) Decision {
+	if errors.Is(err, errConflict) {
+		return Decision{
+			Classified:    true,
+			Retryable:     true,
+			Backoff:       TypeNoBackoff,
+			DeleteSession: true,
+		}
+	}
+
+	return Decision{}
+}
+
+func TestWithErrorClassifier(t *testing.T) {
+	t.Run("RetryApplicationError", func(t *testing.T) {
+		ctx := xtest.Context(t)
+
+		attempts := 0
+		err := Retry(ctx, func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errConflict
+			}
+
+			return nil
+		}, WithErrorClassifier(conflictClassifier))
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts)
+	})
+	t.Run("WithoutClassifier", func(t *testing.T) {
+		ctx := xtest.Context(t)
+
+		attempts := 0
+		err := Retry(ctx, func(ctx context.Context) error {
+			attempts++
+
+			return errConflict
+		})
+		require.ErrorIs(t, err, errConflict)
+		require.Equal(t, 1, attempts)
+	})
+	t.Run("OverrideBuiltInRules", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		errOverloaded := xerrors.Retryable(errors.New("overloaded"))
+
+		attempts := 0
+		err := Retry(ctx, func(ctx context.Context) error {
+			attempts++
+
+			return errOverloaded
+		}, WithErrorClassifier(func(err error) Decision {
+			return Decision{Classified: true}
+		}))
+		require.ErrorIs(t, err, errOverloaded)
+		require.Equal(t, 1, attempts)
+	})
+	t.Run("NotClassified", func(t *testing.T) {
+		ctx := xtest.Context(t)
+
+		attempts := 0
+		err := Retry(ctx, func(ctx context.Context) error {
+			attempts++
+			if attempts < 2 {
+				return xerrors.Retryable(errors.New("overloaded"), xerrors.WithBackoff(TypeNoBackoff))
+			}
+
+			return nil
+		}, WithErrorClassifier(conflictClassifier))
+		require.NoError(t, err)
+		require.Equal(t, 2, attempts)
+	})
+	t.Run("Check", func(t *testing.T) {
+		m := Check(ErrorClassifier(conflictClassifier).classify(errConflict))
+		require.True(t, m.MustRetry(false))
+		require.True(t, m.MustDeleteSession())
+		require.Equal(t, TypeNoBackoff, m.BackoffType())
+
+		require.False(t, Check(ErrorClassifier(conflictClassifier).classify(errors.New("test"))).MustRetry(true))
+	})
+	t.Run("Context", func(t *testing.T) {
+		ctx := xtest.Context(t)
+
+		err := Retry(ctx, func(ctx context.Context) error {
+			require.True(t, Check(xcontext.ClassifyError(ctx, errConflict)).MustDeleteSession(),
+				"the classifier is passed to the pools of sessions",
+			)
+
+			return Retry(ctx, func(ctx context.Context) error {
+				require.False(t, xcontext.HasErrorClassifier(ctx), "the classifier is reset by the nested call")
+
+				return nil
+			})
+		}, WithErrorClassifier(conflictClassifier))
+		require.NoError(t, err)
+	})
+}
@@ -28,6 +28,9 @@ type retryOptions struct {
 	slowBackoff backoff.Backoff
 	budget      budget.Budget
 
+	circuitBreaker  *CircuitBreaker
+	errorClassifier ErrorClassifier
+
 	panicCallback func(e interface{})
 }
 
@@ -307,6 +310,7 @@ func RetryWithResult[T any](ctx context.Context, //nolint:revive,funlen
 	if options.idempotent {
 		ctx = xcontext.WithIdempotent(ctx, options.idempotent)
 	}
+	ctx = withErrorClassifier(ctx, options.errorClassifier)
 
 	defer func() {
 		if finalErr != nil && options.stackTrace {
@@ -340,14 +344,15 @@ func RetryWithResult[T any](ctx context.Context, //nolint:revive,funlen
 			))
 
 		default:
-			v, err := opWithRecover(ctx, options, op)
+			v, err := opWithCircuitBreaker(ctx, options, op)
+
+			m := Check(err)
+			reportBudget(ctx, options, err, m)
 
 			if err == nil {
 				return v, nil
 			}
 
-			m := Check(err)
-
 			if m.StatusCode() != code {
 				i = 0
 			}
@@ -398,6 +403,18 @@ func RetryWithResult[T any](ctx context.Context, //nolint:revive,funlen
 	}
 }
 
+// reportBudget reports the result of the attempt to the budget if it adapts to the results of attempts. The attempts
+// failed with non-retryable errors are not reported, because they do not indicate problems of the cluster.
+func reportBudget(ctx context.Context, options *retryOptions, err error, m retryMode) {
+	feedback, ok := options.budget.(budget.Feedback)
+	if !ok || (err != nil && !m.MustRetry(options.idempotent)) {
+		return
+	}
+
+	state := feedback.Report(err == nil)
+	trace.RetryOnBudgetUpdate(options.trace, &ctx, state.Tokens, state.MaxTokens, state.Throttled)
+}
+
 func opWithRecover[T any](ctx context.Context,
 	options *retryOptions, op func(context.Context) (T, error),
 ) (_ T, finalErr error) {
@@ -415,7 +432,7 @@ func opWithRecover[T any](ctx context.Context,
 
 	v, err := op(ctx)
 	if err != nil {
-		return zeroValue, xerrors.WithStackTrace(err)
+		return zeroValue, xerrors.WithStackTrace(options.errorClassifier.classify(err))
 	}
 
 	return v, nil
@@ -16,6 +16,8 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry/budget"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
 func TestRetryModes(t *testing.T) {
@@ -188,6 +190,42 @@ func TestRetryWithBudget(t *testing.T) {
 	})
 }
 
+func TestRetryWithAdaptiveBudget(t *testing.T) {
+	ctx := xtest.Context(t)
+	b := budget.Adaptive(budget.WithAdaptiveMaxTokens(4))
+
+	var states []budget.State
+	attempts := 0
+	err := Retry(ctx, func(ctx context.Context) (err error) {
+		attempts++
+
+		return RetryableError(errors.New("custom error"))
+	}, WithBudget(b), WithTrace(&trace.Retry{
+		OnBudgetUpdate: func(info trace.RetryBudgetUpdateInfo) {
+			states = append(states, budget.State{
+				Tokens:    info.Tokens,
+				MaxTokens: info.MaxTokens,
+				Throttled: info.Throttled,
+			})
+		},
+	}))
+	require.ErrorIs(t, err, budget.ErrNoQuota)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, []budget.State{
+		{Tokens: 3, MaxTokens: 4},
+		{Tokens: 2, MaxTokens: 4, Throttled: true},
+	}, states)
+
+	attempts = 0
+	require.NoError(t, Retry(ctx, func(ctx context.Context) (err error) {
+		attempts++
+
+		return nil
+	}, WithBudget(b)))
+	require.Equal(t, 1, attempts, "the first attempt is not throttled")
+	require.InDelta(t, 2.1, b.State().Tokens, 1e-9)
+}
+
 type MockPanicCallback struct {
 	called   bool
 	received interface{}
@@ -537,6 +537,14 @@ func WithRetryBudget(b budget.Budget) retryOptionsOption {
 	return []retry.Option{retry.WithBudget(b)}
 }
 
+// WithRetryErrorClassifier creates option with the classifier of the errors which is consulted before
+// the built-in rules of retries
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithRetryErrorClassifier(classifier retry.ErrorClassifier) retryOptionsOption {
+	return []retry.Option{retry.WithErrorClassifier(classifier)}
+}
+
 // Deprecated: redundant option
 // Will be removed after Oct 2024.
 // Read about versioning policy: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#deprecated
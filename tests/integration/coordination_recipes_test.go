@@ -0,0 +1,188 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination"
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination/options"
+)
+
+func TestCoordinationWatchSemaphore(t *testing.T) {
+	scope := newScope(t)
+	nodePath := scope.CoordinationNodePath()
+
+	s, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s.Close(scope.Ctx)
+
+	scope.Require.NoError(s.CreateSemaphore(scope.Ctx, "watched", 10, options.WithCreateData([]byte("v1"))))
+
+	watchCtx, cancel := context.WithCancel(scope.Ctx)
+	defer cancel()
+
+	descriptions, err := s.WatchSemaphore(watchCtx, "watched")
+	scope.Require.NoError(err)
+
+	desc := <-descriptions
+	scope.Require.Equal([]byte("v1"), desc.Data)
+
+	scope.Require.NoError(s.UpdateSemaphore(scope.Ctx, "watched", options.WithUpdateData([]byte("v2"))))
+	desc = <-descriptions
+	scope.Require.Equal([]byte("v2"), desc.Data)
+
+	s.Reconnect()
+
+	lease, err := s.AcquireSemaphore(scope.Ctx, "watched", 1)
+	scope.Require.NoError(err)
+	for desc = range descriptions {
+		if len(desc.Owners) == 1 {
+			break
+		}
+	}
+	scope.Require.Equal(s.SessionID(), desc.Owners[0].SessionID)
+	scope.Require.NoError(lease.Release())
+}
+
+func TestCoordinationElection(t *testing.T) {
+	scope := newScope(t)
+	nodePath := scope.CoordinationNodePath()
+
+	s1, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s1.Close(scope.Ctx)
+
+	s2, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s2.Close(scope.Ctx)
+
+	observeCtx, cancel := context.WithCancel(scope.Ctx)
+	defer cancel()
+
+	leaders := coordination.NewElection(s2, "election").Observe(observeCtx)
+	scope.Require.Nil(<-leaders)
+
+	e1 := coordination.NewElection(s1, "election")
+	leadership, err := e1.Campaign(scope.Ctx, []byte("first"))
+	scope.Require.NoError(err)
+
+	leader := <-leaders
+	scope.Require.Equal(s1.SessionID(), leader.SessionID)
+	scope.Require.Equal([]byte("first"), leader.Value)
+
+	scope.Require.NoError(e1.Resign(scope.Ctx))
+	<-leadership.Done()
+	scope.Require.Nil(<-leaders)
+}
+
+func TestCoordinationMutex(t *testing.T) {
+	scope := newScope(t)
+	nodePath := scope.CoordinationNodePath()
+
+	s1, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s1.Close(scope.Ctx)
+
+	s2, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s2.Close(scope.Ctx)
+
+	m1, m2 := coordination.NewMutex(s1, "mutex"), coordination.NewMutex(s2, "mutex")
+
+	scope.Require.NoError(m1.Lock(scope.Ctx))
+	token := m1.FencingToken()
+
+	locked, err := m2.TryLock(scope.Ctx)
+	scope.Require.NoError(err)
+	scope.Require.False(locked)
+
+	scope.Require.NoError(m1.Unlock())
+	scope.Require.NoError(m2.Lock(scope.Ctx))
+	scope.Require.Greater(m2.FencingToken(), token)
+	scope.Require.NoError(m2.Unlock())
+
+	rw1, rw2 := coordination.NewRWMutex(s1, "rwmutex"), coordination.NewRWMutex(s2, "rwmutex")
+	scope.Require.NoError(rw1.RLock(scope.Ctx))
+	scope.Require.NoError(rw2.RLock(scope.Ctx))
+
+	locked, err = rw1.TryLock(scope.Ctx)
+	scope.Require.NoError(err)
+	scope.Require.False(locked)
+
+	scope.Require.NoError(rw1.RUnlock())
+	scope.Require.NoError(rw2.RUnlock())
+	scope.Require.NoError(rw1.Lock(scope.Ctx))
+	scope.Require.NoError(rw1.Unlock())
+}
+
+func TestCoordinationRegistry(t *testing.T) {
+	scope := newScope(t)
+	nodePath := scope.CoordinationNodePath()
+
+	s1, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s1.Close(scope.Ctx)
+
+	s2, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s2.Close(scope.Ctx)
+
+	r1, r2 := coordination.NewRegistry(s1, "registry"), coordination.NewRegistry(s2, "registry")
+
+	_, err = r1.Register(scope.Ctx, []byte("host-1"))
+	scope.Require.NoError(err)
+
+	watchCtx, cancel := context.WithCancel(scope.Ctx)
+	defer cancel()
+
+	changes, err := r1.Watch(watchCtx)
+	scope.Require.NoError(err)
+	change := <-changes
+	scope.Require.Len(change.Members, 1)
+
+	_, err = r2.Register(scope.Ctx, []byte("host-2"))
+	scope.Require.NoError(err)
+
+	change = <-changes
+	scope.Require.Len(change.Members, 2)
+	scope.Require.Equal([]coordination.Member{{SessionID: s2.SessionID(), Data: []byte("host-2")}}, change.Joined)
+
+	scope.Require.NoError(r2.Unregister(scope.Ctx))
+
+	change = <-changes
+	scope.Require.Len(change.Members, 1)
+	scope.Require.Len(change.Left, 1)
+}
+
+func TestCoordinationBarrierAndLatch(t *testing.T) {
+	scope := newScope(t)
+	nodePath := scope.CoordinationNodePath()
+
+	s1, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s1.Close(scope.Ctx)
+
+	s2, err := scope.Driver().Coordination().Session(scope.Ctx, nodePath)
+	scope.Require.NoError(err)
+	defer s2.Close(scope.Ctx)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- coordination.NewBarrier(s1, "barrier", 2).Wait(scope.Ctx)
+	}()
+	scope.Require.NoError(coordination.NewBarrier(s2, "barrier", 2).Wait(scope.Ctx))
+	scope.Require.NoError(<-done)
+
+	go func() {
+		done <- coordination.NewCountDownLatch(s1, "latch", 2).Wait(scope.Ctx)
+	}()
+	scope.Require.NoError(coordination.NewCountDownLatch(s1, "latch", 2).CountDown(scope.Ctx))
+	scope.Require.NoError(coordination.NewCountDownLatch(s2, "latch", 2).CountDown(scope.Ctx))
+	scope.Require.NoError(<-done)
+
+	scope.Require.NoError(s1.DeleteSemaphore(scope.Ctx, "barrier", options.WithForceDelete(true)))
+	scope.Require.NoError(s1.DeleteSemaphore(scope.Ctx, "latch", options.WithForceDelete(true)))
+}
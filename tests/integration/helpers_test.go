@@ -21,6 +21,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-sdk/v3"
 	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/coordination"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xsync"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
 	"github.com/ydb-platform/ydb-go-sdk/v3/log"
@@ -186,6 +187,30 @@ func (scope *scopeT) Folder() string {
 	return fixenv.CacheResult(scope.Env, f)
 }
 
+func (scope *scopeT) CoordinationNodePath() string {
+	f := func() (*fixenv.GenericResult[string], error) {
+		driver := scope.Driver()
+		nodePath := path.Join(scope.Folder(), "coordination-node")
+
+		scope.Logf("Creating coordination node: %v", nodePath)
+		scope.Require.NoError(driver.Coordination().CreateNode(scope.Ctx, nodePath, coordination.NodeConfig{
+			SelfCheckPeriodMillis:    1000,
+			SessionGracePeriodMillis: 1000,
+			ReadConsistencyMode:      coordination.ConsistencyModeStrict,
+			AttachConsistencyMode:    coordination.ConsistencyModeStrict,
+			RatelimiterCountersMode:  coordination.RatelimiterCountersModeDetailed,
+		}))
+		clean := func() {
+			_ = driver.Coordination().DropNode(scope.Ctx, nodePath)
+		}
+		scope.Logf("Creating coordination node done: %v", nodePath)
+
+		return fixenv.NewGenericResultWithCleanup(nodePath, clean), nil
+	}
+
+	return fixenv.CacheResult(scope.Env, f)
+}
+
 func (scope *scopeT) Logger() *testLogger {
 	return scope.CacheResult(func() (*fixenv.Result, error) {
 		return fixenv.NewResult(newLogger(scope.t)), nil
@@ -20,6 +20,8 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/version"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
 	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicoptions"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicreader"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicsugar"
 	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topictypes"
 	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicwriter"
 )
@@ -131,6 +133,10 @@ func TestTopicDescribe(t *testing.T) {
 		*subset = nil
 	}
 
+	// defaults of auto partitioning settings depends on server version
+	expected.PartitionSettings.MaxActivePartitions = topicDesc.PartitionSettings.MaxActivePartitions
+	expected.PartitionSettings.AutoPartitioningSettings = topicDesc.PartitionSettings.AutoPartitioningSettings
+
 	requireAndCleanSubset(&topicDesc.Attributes, &expected.Attributes)
 
 	for i := range expected.Consumers {
@@ -140,6 +146,55 @@ func TestTopicDescribe(t *testing.T) {
 	require.Equal(t, expected, topicDesc)
 }
 
+func TestTopicAutoPartitioningSettings(t *testing.T) {
+	if os.Getenv("YDB_VERSION") != "nightly" && version.Lt(os.Getenv("YDB_VERSION"), "24.3") {
+		t.Skip("Topic auto partitioning implemented since YDB 24.3, test ran for '" + os.Getenv("YDB_VERSION") + "'")
+	}
+
+	ctx := xtest.Context(t)
+	db := connect(t)
+	topicName := "test-topic-" + t.Name()
+
+	_ = db.Topic().Drop(ctx, topicName)
+	err := db.Topic().Create(ctx, topicName,
+		topicoptions.CreateWithMinActivePartitions(1),
+		topicoptions.CreateWithMaxActivePartitions(10),
+		topicoptions.CreateWithAutoPartitioningStrategy(topictypes.AutoPartitioningStrategyScaleUp),
+		topicoptions.CreateWithAutoPartitioningStabilizationWindow(time.Minute),
+		topicoptions.CreateWithAutoPartitioningUpUtilizationPercent(80),
+		topicoptions.CreateWithConsumer(topictypes.Consumer{Name: commonConsumerName}),
+	)
+	require.NoError(t, err)
+
+	topicDesc, err := db.Topic().Describe(ctx, topicName)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), topicDesc.PartitionSettings.MaxActivePartitions)
+	autoPartitioning := topicDesc.PartitionSettings.AutoPartitioningSettings
+	require.Equal(t, topictypes.AutoPartitioningStrategyScaleUp, autoPartitioning.Strategy)
+	require.Equal(t, time.Minute, autoPartitioning.PartitionWriteSpeed.StabilizationWindow)
+	require.Equal(t, int32(80), autoPartitioning.PartitionWriteSpeed.UpUtilizationPercent)
+
+	err = db.Topic().Alter(ctx, topicName,
+		topicoptions.AlterWithAutoPartitioningStrategy(topictypes.AutoPartitioningStrategyPaused),
+		topicoptions.AlterWithAutoPartitioningDownUtilizationPercent(20),
+	)
+	require.NoError(t, err)
+
+	topicDesc, err = db.Topic().Describe(ctx, topicName)
+	require.NoError(t, err)
+	autoPartitioning = topicDesc.PartitionSettings.AutoPartitioningSettings
+	require.Equal(t, topictypes.AutoPartitioningStrategyPaused, autoPartitioning.Strategy)
+	require.Equal(t, int32(80), autoPartitioning.PartitionWriteSpeed.UpUtilizationPercent)
+	require.Equal(t, int32(20), autoPartitioning.PartitionWriteSpeed.DownUtilizationPercent)
+
+	reader, err := db.Topic().StartReader(commonConsumerName, topicoptions.ReadTopic(topicName),
+		topicoptions.WithReaderAutoPartitioningSupport(true),
+	)
+	require.NoError(t, err)
+	require.NoError(t, reader.WaitInit(ctx))
+	require.NoError(t, reader.Close(ctx))
+}
+
 func TestDescribeTopicConsumer(t *testing.T) {
 	ctx := xtest.Context(t)
 	db := connect(t)
@@ -328,6 +383,57 @@ func TestReaderWithoutConsumer(t *testing.T) {
 		_ = reader1.Close(ctx)
 		_ = reader2.Close(ctx)
 	})
+	t.Run("OffsetStore", func(t *testing.T) {
+		if version.Lt(os.Getenv("YDB_VERSION"), "24.1") {
+			t.Skip("Read topic without consumer implemented since YDB 24.1, test ran for '" + os.Getenv("YDB_VERSION") + "'")
+		}
+		scope := newScope(t)
+		ctx := scope.Ctx
+
+		store := topicsugar.NewTableOffsetStore(scope.Driver().Query(), path.Join(scope.Folder(), "offsets"), "test")
+		require.NoError(t, store.CreateTable(ctx))
+
+		startReader := func() *topicreader.Reader {
+			reader, err := scope.Driver().Topic().StartReader(
+				"",
+				topicoptions.ReadSelectors{
+					{
+						Path:       scope.TopicPath(),
+						Partitions: []int64{0},
+					},
+				},
+				topicoptions.WithReaderWithoutConsumer(false),
+				topicoptions.WithReaderOffsetStore(store),
+			)
+			require.NoError(t, err)
+
+			return reader
+		}
+
+		err := scope.TopicWriter().Write(ctx,
+			topicwriter.Message{Data: strings.NewReader("1")},
+			topicwriter.Message{Data: strings.NewReader("2")},
+		)
+		require.NoError(t, err)
+
+		reader := startReader()
+		msg, err := reader.ReadMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), msg.SeqNo)
+		require.NoError(t, reader.Commit(ctx, msg))
+		require.NoError(t, reader.Close(ctx))
+
+		offset, ok, err := store.LoadOffset(ctx, scope.TopicPath(), 0)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, msg.Offset+1, offset)
+
+		reader = startReader()
+		msg, err = reader.ReadMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), msg.SeqNo)
+		require.NoError(t, reader.Close(ctx))
+	})
 	t.Run("NoNameNoOptionErr", func(t *testing.T) {
 		scope := newScope(t)
 		topicReader, err := scope.Driver().Topic().StartReader("", topicoptions.ReadTopic(scope.TopicPath()))
@@ -346,6 +452,63 @@ func TestReaderWithoutConsumer(t *testing.T) {
 	})
 }
 
+func TestConsumerOffsets(t *testing.T) {
+	scope := newScope(t)
+	ctx := scope.Ctx
+	topicClient := scope.Driver().Topic()
+
+	err := scope.TopicWriter().Write(ctx,
+		topicwriter.Message{Data: strings.NewReader("1")},
+		topicwriter.Message{Data: strings.NewReader("2")},
+	)
+	require.NoError(t, err)
+
+	// server time may differ from local, but write time of next messages can't be less than last message
+	time.Sleep(time.Second)
+	description, err := topicClient.DescribeTopicConsumer(ctx, scope.TopicPath(), scope.TopicConsumerName(),
+		topicoptions.IncludeConsumerStats(),
+	)
+	require.NoError(t, err)
+	resetTime := description.Partitions[0].PartitionStats.LastWriteTime.Add(time.Millisecond)
+	time.Sleep(time.Second)
+
+	err = scope.TopicWriter().Write(ctx, topicwriter.Message{Data: strings.NewReader("3")})
+	require.NoError(t, err)
+
+	committedOffset := func() int64 {
+		description, err := topicClient.DescribeTopicConsumer(ctx, scope.TopicPath(), scope.TopicConsumerName(),
+			topicoptions.IncludeConsumerStats(),
+		)
+		require.NoError(t, err)
+
+		return description.Partitions[0].PartitionConsumerStats.CommittedOffset
+	}
+
+	require.NoError(t, topicClient.CommitOffset(ctx, scope.TopicPath(), 0, scope.TopicConsumerName(), 1))
+	require.Equal(t, int64(1), committedOffset())
+
+	require.NoError(t, topicClient.ResetConsumer(ctx, scope.TopicPath(), scope.TopicConsumerName(),
+		topicoptions.ToLatest(),
+	))
+	require.Equal(t, int64(3), committedOffset())
+
+	require.NoError(t, topicClient.ResetConsumer(ctx, scope.TopicPath(), scope.TopicConsumerName(),
+		topicoptions.ToEarliest(),
+	))
+	require.Equal(t, int64(0), committedOffset())
+
+	require.NoError(t, topicClient.ResetConsumer(ctx, scope.TopicPath(), scope.TopicConsumerName(),
+		topicoptions.ToTimestamp(resetTime),
+	))
+	require.Equal(t, int64(2), committedOffset())
+
+	msg, err := scope.TopicReader().ReadMessage(ctx)
+	require.NoError(t, err)
+	content, err := io.ReadAll(msg)
+	require.NoError(t, err)
+	require.Equal(t, "3", string(content))
+}
+
 func connect(t testing.TB, opts ...ydb.Option) *ydb.Driver {
 	return connectWithLogOption(t, false, opts...)
 }
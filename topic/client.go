@@ -17,6 +17,12 @@ type Client interface {
 	// Alter change topic options
 	Alter(ctx context.Context, path string, opts ...topicoptions.AlterOption) error
 
+	// CommitOffset set committed offset of the consumer for the partition.
+	// It allows to move the consumer without active reader.
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	CommitOffset(ctx context.Context, path string, partitionID int64, consumer string, offset int64) error
+
 	// Create topic
 	Create(ctx context.Context, path string, opts ...topicoptions.CreateOption) error
 
@@ -31,6 +37,12 @@ type Client interface {
 	// Drop topic
 	Drop(ctx context.Context, path string, opts ...topicoptions.DropOption) error
 
+	// ResetConsumer set committed offsets of the consumer for all partitions of the topic
+	// to the target: topicoptions.ToEarliest, topicoptions.ToLatest or topicoptions.ToTimestamp
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	ResetConsumer(ctx context.Context, path, consumer string, target topicoptions.ResetConsumerTarget) error
+
 	// StartListener starts read listen topic with the handler
 	// it is fast non block call, connection starts in background
 	//
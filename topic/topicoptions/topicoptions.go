@@ -53,6 +53,67 @@ func (partitionCountLimit withPartitionCountLimit) ApplyAlterOption(req *rawtopi
 	req.AlterPartitionSettings.SetPartitionCountLimit.Value = int64(partitionCountLimit)
 }
 
+type withMaxActivePartitions int64
+
+func (maxActivePartitions withMaxActivePartitions) ApplyCreateOption(request *rawtopic.CreateTopicRequest) {
+	request.PartitionSettings.MaxActivePartitions = int64(maxActivePartitions)
+}
+
+func (maxActivePartitions withMaxActivePartitions) ApplyAlterOption(req *rawtopic.AlterTopicRequest) {
+	req.AlterPartitionSettings.SetMaxActivePartitions.HasValue = true
+	req.AlterPartitionSettings.SetMaxActivePartitions.Value = int64(maxActivePartitions)
+}
+
+type withAutoPartitioningStrategy topictypes.AutoPartitioningStrategy
+
+func (strategy withAutoPartitioningStrategy) ApplyCreateOption(request *rawtopic.CreateTopicRequest) {
+	request.PartitionSettings.AutoPartitioningSettings.Strategy = rawtopic.AutoPartitioningStrategy(strategy)
+}
+
+func (strategy withAutoPartitioningStrategy) ApplyAlterOption(req *rawtopic.AlterTopicRequest) {
+	settings := &req.AlterPartitionSettings.AlterAutoPartitioningSettings
+	settings.SetStrategy.HasValue = true
+	settings.SetStrategy.Value = rawtopic.AutoPartitioningStrategy(strategy)
+}
+
+type withAutoPartitioningStabilizationWindow time.Duration
+
+func (window withAutoPartitioningStabilizationWindow) ApplyCreateOption(request *rawtopic.CreateTopicRequest) {
+	writeSpeed := &request.PartitionSettings.AutoPartitioningSettings.PartitionWriteSpeed
+	writeSpeed.StabilizationWindow.HasValue = true
+	writeSpeed.StabilizationWindow.Value = time.Duration(window)
+}
+
+func (window withAutoPartitioningStabilizationWindow) ApplyAlterOption(req *rawtopic.AlterTopicRequest) {
+	settings := &req.AlterPartitionSettings.AlterAutoPartitioningSettings
+	settings.SetStabilizationWindow.HasValue = true
+	settings.SetStabilizationWindow.Value = time.Duration(window)
+}
+
+type withAutoPartitioningUpUtilizationPercent int32
+
+func (percent withAutoPartitioningUpUtilizationPercent) ApplyCreateOption(request *rawtopic.CreateTopicRequest) {
+	request.PartitionSettings.AutoPartitioningSettings.PartitionWriteSpeed.UpUtilizationPercent = int32(percent)
+}
+
+func (percent withAutoPartitioningUpUtilizationPercent) ApplyAlterOption(req *rawtopic.AlterTopicRequest) {
+	settings := &req.AlterPartitionSettings.AlterAutoPartitioningSettings
+	settings.SetUpUtilizationPercent.HasValue = true
+	settings.SetUpUtilizationPercent.Value = int32(percent)
+}
+
+type withAutoPartitioningDownUtilizationPercent int32
+
+func (percent withAutoPartitioningDownUtilizationPercent) ApplyCreateOption(request *rawtopic.CreateTopicRequest) {
+	request.PartitionSettings.AutoPartitioningSettings.PartitionWriteSpeed.DownUtilizationPercent = int32(percent)
+}
+
+func (percent withAutoPartitioningDownUtilizationPercent) ApplyAlterOption(req *rawtopic.AlterTopicRequest) {
+	settings := &req.AlterPartitionSettings.AlterAutoPartitioningSettings
+	settings.SetDownUtilizationPercent.HasValue = true
+	settings.SetDownUtilizationPercent.Value = int32(percent)
+}
+
 type withRetentionPeriod time.Duration
 
 func (retentionPeriod withRetentionPeriod) ApplyCreateOption(request *rawtopic.CreateTopicRequest) {
@@ -28,6 +28,45 @@ func AlterWithPartitionCountLimit(partitionCountLimit int64) AlterOption {
 	return withPartitionCountLimit(partitionCountLimit)
 }
 
+// AlterWithMaxActivePartitions change max active partitions of the topic.
+// Auto split stops when the partitions count reaches the value.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func AlterWithMaxActivePartitions(count int64) AlterOption {
+	return withMaxActivePartitions(count)
+}
+
+// AlterWithAutoPartitioningStrategy change auto partitioning strategy of the topic
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func AlterWithAutoPartitioningStrategy(strategy topictypes.AutoPartitioningStrategy) AlterOption {
+	return withAutoPartitioningStrategy(strategy)
+}
+
+// AlterWithAutoPartitioningStabilizationWindow change time of exceed write speed threshold
+// before split or merge partitions
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func AlterWithAutoPartitioningStabilizationWindow(window time.Duration) AlterOption {
+	return withAutoPartitioningStabilizationWindow(window)
+}
+
+// AlterWithAutoPartitioningUpUtilizationPercent change threshold of partition write speed for split the partition.
+// Percent counts from partition write speed limit.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func AlterWithAutoPartitioningUpUtilizationPercent(percent int32) AlterOption {
+	return withAutoPartitioningUpUtilizationPercent(percent)
+}
+
+// AlterWithAutoPartitioningDownUtilizationPercent change threshold of partition write speed for merge partitions.
+// Percent counts from partition write speed limit.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func AlterWithAutoPartitioningDownUtilizationPercent(percent int32) AlterOption {
+	return withAutoPartitioningDownUtilizationPercent(percent)
+}
+
 // AlterWithRetentionPeriod change retention period of topic
 func AlterWithRetentionPeriod(retentionPeriod time.Duration) AlterOption {
 	return withRetentionPeriod(retentionPeriod)
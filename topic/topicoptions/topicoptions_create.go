@@ -28,6 +28,45 @@ func CreateWithPartitionCountLimit(count int64) CreateOption {
 	return withPartitionCountLimit(count)
 }
 
+// CreateWithMaxActivePartitions set max active partitions of the topic.
+// Auto split stops when the partitions count reaches the value.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func CreateWithMaxActivePartitions(count int64) CreateOption {
+	return withMaxActivePartitions(count)
+}
+
+// CreateWithAutoPartitioningStrategy set auto partitioning strategy of the topic
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func CreateWithAutoPartitioningStrategy(strategy topictypes.AutoPartitioningStrategy) CreateOption {
+	return withAutoPartitioningStrategy(strategy)
+}
+
+// CreateWithAutoPartitioningStabilizationWindow set time of exceed write speed threshold
+// before split or merge partitions
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func CreateWithAutoPartitioningStabilizationWindow(window time.Duration) CreateOption {
+	return withAutoPartitioningStabilizationWindow(window)
+}
+
+// CreateWithAutoPartitioningUpUtilizationPercent set threshold of partition write speed for split the partition.
+// Percent counts from partition write speed limit.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func CreateWithAutoPartitioningUpUtilizationPercent(percent int32) CreateOption {
+	return withAutoPartitioningUpUtilizationPercent(percent)
+}
+
+// CreateWithAutoPartitioningDownUtilizationPercent set threshold of partition write speed for merge partitions.
+// Percent counts from partition write speed limit.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func CreateWithAutoPartitioningDownUtilizationPercent(percent int32) CreateOption {
+	return withAutoPartitioningDownUtilizationPercent(percent)
+}
+
 // CreateWithRetentionPeriod set retention time interval for the topic.
 func CreateWithRetentionPeriod(retentionPeriod time.Duration) CreateOption {
 	return withRetentionPeriod(retentionPeriod)
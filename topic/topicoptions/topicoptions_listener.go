@@ -20,3 +20,13 @@ func WithListenerAddDecoder(codec topictypes.Codec, decoderCreate CreateDecoderF
 		cfg.Decoders.AddDecoder(rawtopiccommon.Codec(codec), decoderCreate)
 	}
 }
+
+// WithListenerMiddleware add middlewares for process every read message before OnReadMessages handler.
+// First middleware is outer. Batches with all messages skipped by middlewares confirmed automatically.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithListenerMiddleware(middlewares ...ReaderMiddleware) ListenerOption {
+	return func(cfg *topiclistenerinternal.StreamListenerConfig) {
+		cfg.Middlewares = append(cfg.Middlewares, middlewares...)
+	}
+}
@@ -0,0 +1,34 @@
+package topicoptions
+
+import (
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/topic"
+)
+
+// ResetConsumerTarget is target position of consumer for Client.ResetConsumer
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type ResetConsumerTarget = topic.ResetConsumerTarget
+
+// ToEarliest reset consumer to first available message of every partition
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func ToEarliest() ResetConsumerTarget {
+	return ResetConsumerTarget{Type: topic.ResetConsumerTargetEarliest}
+}
+
+// ToLatest reset consumer to end of every partition, all messages in the partitions will be skipped
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func ToLatest() ResetConsumerTarget {
+	return ResetConsumerTarget{Type: topic.ResetConsumerTargetLatest}
+}
+
+// ToTimestamp reset consumer to first message of every partition, written at the time or later.
+// Offsets found by read messages from the partitions without consumer.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func ToTimestamp(t time.Time) ResetConsumerTarget {
+	return ResetConsumerTarget{Type: topic.ResetConsumerTargetTimestamp, Timestamp: t}
+}
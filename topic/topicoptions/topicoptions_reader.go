@@ -171,6 +171,15 @@ func WithAddDecoder(codec topictypes.Codec, decoderCreate CreateDecoderFunc) Rea
 	}
 }
 
+// Lz4Decoder create lz4 frame decoders, reused between messages.
+// Ydb protocol has no lz4 codec, register it as custom codec, same as for writer with Lz4Encoder.
+// The decoder can be used for readers and listeners.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func Lz4Decoder() CreateDecoderFunc {
+	return topicreadercommon.NewLz4DecoderFunc()
+}
+
 // CommitMode variants of commit mode of the reader
 type CommitMode = topicreadercommon.PublicCommitMode
 
@@ -243,6 +252,49 @@ func WithReaderGetPartitionStartOffset(f GetPartitionStartOffsetFunc) ReaderOpti
 	}
 }
 
+// OffsetStore keep read progress of partitions at external storage.
+// topicsugar package contains in-memory and ydb table implementations.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type OffsetStore = topicreaderinternal.PublicOffsetStore
+
+// WithReaderOffsetStore set external storage for read progress of partitions.
+// The reader loads start offset from the store on start partition and saves offset to the store on every commit.
+// It usually used with WithReaderWithoutConsumer, then commits save progress to the store only.
+// If the reader has a consumer and commits enabled - commits save offsets to the store and to the server both.
+// Can't be used with WithReaderGetPartitionStartOffset.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithReaderOffsetStore(store OffsetStore) ReaderOption {
+	return func(cfg *topicreaderinternal.ReaderConfig) {
+		cfg.OffsetStore = store
+	}
+}
+
+type (
+	// ReaderMessageHandler process the message before it returned to user code.
+	// Return keep=false for skip the message. Skipped messages committed with next read messages of the partition.
+	// Middleware, which read content of the message, must set new content with message.ReplaceData.
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	ReaderMessageHandler = topicreadercommon.PublicReaderMessageHandler
+
+	// ReaderMiddleware wrap message handler for add own logic: decrypt content, filter messages, collect metrics, etc.
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	ReaderMiddleware = topicreadercommon.PublicReaderMiddleware
+)
+
+// WithReaderMiddleware add middlewares for process every read message before ReadMessage/ReadMessagesBatch
+// return it. First middleware is outer. Error from middleware stops the reader.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithReaderMiddleware(middlewares ...ReaderMiddleware) ReaderOption {
+	return func(cfg *topicreaderinternal.ReaderConfig) {
+		cfg.Middlewares = append(cfg.Middlewares, middlewares...)
+	}
+}
+
 // WithReaderTrace set tracer for the topic reader
 //
 // Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
@@ -259,6 +311,18 @@ func WithReaderUpdateTokenInterval(interval time.Duration) ReaderOption {
 	}
 }
 
+// WithReaderAutoPartitioningSupport enable read topics with auto partitioning.
+// Server sends messages of child partitions only after all messages of the parent partition were read
+// and committed, then order of messages with same key is saved across split and merge partitions.
+// Commit messages of ended partitions in time, else reading of child partitions will be delayed.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithReaderAutoPartitioningSupport(enabled bool) ReaderOption {
+	return func(cfg *topicreaderinternal.ReaderConfig) {
+		cfg.AutoPartitioningSupport = enabled
+	}
+}
+
 // WithReaderWithoutConsumer allow read topic without consumer.
 // Read without consumer is special read mode on a server. In the mode every reader without consumer receive all
 // messages from a topic and can't commit them.
@@ -5,6 +5,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Topic"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topictypes"
 )
 
 func TestEqualAlterOptions(t *testing.T) {
@@ -98,3 +105,55 @@ func TestEqualCreateOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestAutoPartitioningOptions(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		req := &rawtopic.CreateTopicRequest{}
+		for _, opt := range []CreateOption{
+			CreateWithMaxActivePartitions(10),
+			CreateWithAutoPartitioningStrategy(topictypes.AutoPartitioningStrategyScaleUpAndDown),
+			CreateWithAutoPartitioningStabilizationWindow(time.Minute),
+			CreateWithAutoPartitioningUpUtilizationPercent(80),
+			CreateWithAutoPartitioningDownUtilizationPercent(20),
+		} {
+			opt.ApplyCreateOption(req)
+		}
+
+		expected := &Ydb_Topic.PartitioningSettings{
+			MaxActivePartitions: 10,
+			AutoPartitioningSettings: &Ydb_Topic.AutoPartitioningSettings{
+				Strategy: Ydb_Topic.AutoPartitioningStrategy_AUTO_PARTITIONING_STRATEGY_SCALE_UP_AND_DOWN,
+				PartitionWriteSpeed: &Ydb_Topic.AutoPartitioningWriteSpeedStrategy{
+					StabilizationWindow:    durationpb.New(time.Minute),
+					UpUtilizationPercent:   80,
+					DownUtilizationPercent: 20,
+				},
+			},
+		}
+		require.True(t, proto.Equal(expected, req.ToProto().GetPartitioningSettings()))
+	})
+	t.Run("Alter", func(t *testing.T) {
+		req := &rawtopic.AlterTopicRequest{}
+		for _, opt := range []AlterOption{
+			AlterWithMaxActivePartitions(10),
+			AlterWithAutoPartitioningStrategy(topictypes.AutoPartitioningStrategyPaused),
+			AlterWithAutoPartitioningUpUtilizationPercent(80),
+		} {
+			opt.ApplyAlterOption(req)
+		}
+
+		maxActivePartitions := int64(10)
+		strategy := Ydb_Topic.AutoPartitioningStrategy_AUTO_PARTITIONING_STRATEGY_PAUSED
+		upUtilizationPercent := int32(80)
+		expected := &Ydb_Topic.AlterPartitioningSettings{
+			SetMaxActivePartitions: &maxActivePartitions,
+			AlterAutoPartitioningSettings: &Ydb_Topic.AlterAutoPartitioningSettings{
+				SetStrategy: &strategy,
+				SetPartitionWriteSpeed: &Ydb_Topic.AlterAutoPartitioningWriteSpeedStrategy{
+					SetUpUtilizationPercent: &upUtilizationPercent,
+				},
+			},
+		}
+		require.True(t, proto.Equal(expected, req.ToProto().GetAlterPartitioningSettings()))
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/grpcwrapper/rawtopic/rawtopiccommon"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/topic/topicwriterinternal"
+	"github.com/ydb-platform/ydb-go-sdk/v3/ratelimiter"
 	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topictypes"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
@@ -25,6 +26,29 @@ func WithWriterAddEncoder(codec topictypes.Codec, f CreateEncoderFunc) WriterOpt
 	return topicwriterinternal.WithAddEncoder(rawtopiccommon.Codec(codec), f)
 }
 
+// ZstdEncoder create zstd encoders with compression level from 1 (fastest) to 22 (best compression),
+// 0 mean default level. Encoders reused between messages.
+// Zstd codec supported by writer by default, use the encoder for change compression level:
+//
+//	topicoptions.WithWriterAddEncoder(topictypes.CodecZstd, topicoptions.ZstdEncoder(level))
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func ZstdEncoder(level int) CreateEncoderFunc {
+	return topicwriterinternal.NewZstdEncoderFunc(level)
+}
+
+// Lz4Encoder create lz4 frame encoders with compression level from 0 (fast) to 9 (best compression).
+// Encoders reused between messages.
+// Ydb protocol has no lz4 codec, register it as custom codec for writers and readers:
+//
+//	topicoptions.WithWriterAddEncoder(codec, topicoptions.Lz4Encoder(level))
+//	topicoptions.WithAddDecoder(codec, topicoptions.Lz4Decoder())
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func Lz4Encoder(level int) CreateEncoderFunc {
+	return topicwriterinternal.NewLz4EncoderFunc(level)
+}
+
 // WithWriterCheckRetryErrorFunction can override default error retry policy
 // use CheckErrorRetryDecisionDefault for use default behavior for the error
 // callback func must be fast and deterministic: always result same result for same error - it can be called
@@ -58,6 +82,68 @@ func WithWriterMessageMaxBytesSize(size int) WriterOption {
 	}
 }
 
+// WithWriterMaxBatchBytes set max uncompressed size of messages, sent to server in one request.
+// A message, bigger than the size, sent in a separate request.
+// 0 (default) mean no limit: all messages from internal queue sent in one request.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithWriterMaxBatchBytes(size int) WriterOption {
+	return topicwriterinternal.WithMaxBatchBytes(size)
+}
+
+// WithWriterLinger set time for wait more messages before send not full batch to server.
+// The time counts from the first unsent message. Batch sent without waiting if it reached
+// WithWriterMaxBatchBytes size, on Flush, Close or write with wait server ack.
+// It allows to decrease requests count by cost of increase latency.
+// 0 (default) mean send messages as soon as possible.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithWriterLinger(linger time.Duration) WriterOption {
+	return topicwriterinternal.WithLinger(linger)
+}
+
+// WithWriterMaxInflightBytes set max summary uncompressed size of messages, which wait ack from server.
+// Write blocks while the messages can't be added to internal buffer without exceed the limit.
+// 0 (default) mean no limit by size, count of messages limited by WithWriterMaxQueueLen only.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithWriterMaxInflightBytes(size int) WriterOption {
+	return topicwriterinternal.WithMaxInflightBytes(size)
+}
+
+// WriterRateLimitCostFunc returns the amount of request units to take for the messages of a write call
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type WriterRateLimitCostFunc = topicwriterinternal.PublicRateLimitCostFunc
+
+// WithWriterRateLimit makes the writer take the quota of the limiter before every write call.
+// The write blocks until the quota is taken. Share the limiter with other clients for a common budget.
+// If costFn is nil, every message takes a single request unit.
+// The limiter is not closed with the writer.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithWriterRateLimit(limiter *ratelimiter.Limiter, costFn WriterRateLimitCostFunc) WriterOption {
+	if limiter == nil {
+		return topicwriterinternal.WithRateLimiter(nil, nil)
+	}
+
+	return topicwriterinternal.WithRateLimiter(limiter, costFn)
+}
+
+// WithWriterSpoolDir enable durable buffer for messages in the dir.
+// Messages saved to segment files in the dir before put to internal queue and segment removed
+// after server ack all its messages.
+// After restart the writer send messages, not acked in previous session with original SeqNo.
+// Set WithWriterProducerID for skip duplicates by server.
+// The dir must not be used by other writers at the same time.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithWriterSpoolDir(dir string) WriterOption {
+	return func(cfg *topicwriterinternal.WriterReconnectorConfig) {
+		cfg.SpoolDir = dir
+	}
+}
+
 // WithWriteSessionMeta
 //
 // Deprecated: was experimental and not actual now.
@@ -176,6 +262,11 @@ func WithCodecAutoSelect() WriterOption {
 // enabled by default
 // if option enabled - send a batch of messages for every allowed codec (for prevent delayed bad codec accident)
 // then from time to time measure all codecs and select codec with the smallest result messages size
+// auto select choose from codecs, supported by the topic and the writer (raw, gzip, zstd and added encoders).
+// If the topic has empty supported codecs list - choose from raw and gzip for compatibility with old readers.
+// Lz4 is not selected by default: ydb protocol has no lz4 codec and readers must know the custom codec number
+// for decode messages. Add lz4 with WithWriterAddEncoder(codec, Lz4Encoder(level)) and the custom codec
+// to the topic supported codecs - then auto select measure it as any other codec.
 func WithWriterCodecAutoSelect() WriterOption {
 	return topicwriterinternal.WithAutoCodec()
 }
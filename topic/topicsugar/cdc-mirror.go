@@ -0,0 +1,465 @@
+//go:build go1.23
+
+package topicsugar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/background"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/empty"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicoptions"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicreader"
+)
+
+var errCDCMirrorClosed = xerrors.Wrap(errors.New("ydb: cdc mirror closed"))
+
+// CDCMirrorTopicClient is interface for topic client, used by CDCMirror. topic.Client implements it
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type CDCMirrorTopicClient interface {
+	TopicConsumerDescriber
+
+	StartReader(
+		consumer string,
+		readSelectors topicoptions.ReadSelectors,
+		opts ...topicoptions.ReaderOption,
+	) (*topicreader.Reader, error)
+}
+
+// CDCMirrorSnapshotFunc read all rows of the table, for example within snapshot read only transaction.
+// The func may be called many times on retries.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type CDCMirrorSnapshotFunc[K comparable, V any] func(ctx context.Context) (map[K]V, error)
+
+// CDCMirrorOption set settings for CDCMirror
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type CDCMirrorOption func(cfg *cdcMirrorConfig)
+
+type cdcMirrorConfig struct {
+	readerOptions []topicoptions.ReaderOption
+	retryOptions  []retry.Option
+	clock         clockwork.Clock
+}
+
+// WithCDCMirrorReaderOptions add options for changefeed reader
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCDCMirrorReaderOptions(opts ...topicoptions.ReaderOption) CDCMirrorOption {
+	return func(cfg *cdcMirrorConfig) {
+		cfg.readerOptions = append(cfg.readerOptions, opts...)
+	}
+}
+
+// WithCDCMirrorRetryOptions add options for retry bootstrap of the mirror
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithCDCMirrorRetryOptions(opts ...retry.Option) CDCMirrorOption {
+	return func(cfg *cdcMirrorConfig) {
+		cfg.retryOptions = append(cfg.retryOptions, opts...)
+	}
+}
+
+func withCDCMirrorClock(clock clockwork.Clock) CDCMirrorOption {
+	return func(cfg *cdcMirrorConfig) {
+		cfg.clock = clock
+	}
+}
+
+type cdcMirrorReader interface {
+	ReadMessage(ctx context.Context) (*topicreader.Message, error)
+	Commit(ctx context.Context, obj topicreader.CommitRangeGetter) error
+	Close(ctx context.Context) error
+}
+
+// CDCMirror is in-memory copy of a table, consistent with the table by changefeed.
+//
+// The mirror read snapshot of the table, then apply changefeed events, written after the snapshot started.
+// Changefeed must be created with mode NEW_IMAGE or NEW_AND_OLD_IMAGES (or UPDATES if all columns
+// written on every change). Enable RESOLVED_TIMESTAMPS for the changefeed for actual Staleness of idle tables.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type CDCMirror[K comparable, V YDBCDCItem[K]] struct {
+	client         CDCMirrorTopicClient
+	changefeedPath string
+	consumer       string
+	snapshot       CDCMirrorSnapshotFunc[K, V]
+	cfg            cdcMirrorConfig
+	startReader    func(startOffsets map[int64]int64) (cdcMirrorReader, error)
+
+	background background.Worker
+	ready      empty.Chan
+	readyOnce  sync.Once
+
+	m              sync.RWMutex
+	values         map[K]V
+	lastUpdateTime time.Time
+	pendingOffsets map[int64]int64
+	nextOffsets    map[int64]int64 // next offsets after the applied messages by partitions
+	err            error
+}
+
+// NewCDCMirror create mirror of the table and start bootstrap in background
+// it is fast non block call, use WaitReady for wait the mirror loaded
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewCDCMirror[K comparable, V YDBCDCItem[K]](
+	client CDCMirrorTopicClient,
+	changefeedPath string,
+	consumer string,
+	snapshot CDCMirrorSnapshotFunc[K, V],
+	opts ...CDCMirrorOption,
+) *CDCMirror[K, V] {
+	m := newCDCMirror(client, changefeedPath, consumer, snapshot, opts...)
+	m.background.Start("cdc mirror", m.run)
+
+	return m
+}
+
+func newCDCMirror[K comparable, V YDBCDCItem[K]](
+	client CDCMirrorTopicClient,
+	changefeedPath string,
+	consumer string,
+	snapshot CDCMirrorSnapshotFunc[K, V],
+	opts ...CDCMirrorOption,
+) *CDCMirror[K, V] {
+	m := &CDCMirror[K, V]{
+		client:         client,
+		changefeedPath: changefeedPath,
+		consumer:       consumer,
+		snapshot:       snapshot,
+		cfg: cdcMirrorConfig{
+			clock: clockwork.NewRealClock(),
+		},
+		ready:       make(empty.Chan),
+		values:      make(map[K]V),
+		nextOffsets: make(map[int64]int64),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&m.cfg)
+		}
+	}
+	m.startReader = m.startTopicReader
+
+	return m
+}
+
+// Get return value by key from the mirror
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *CDCMirror[K, V]) Get(key K) (value V, ok bool) {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	value, ok = m.values[key]
+
+	return value, ok
+}
+
+// Len return count of rows in the mirror
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *CDCMirror[K, V]) Len() int {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return len(m.values)
+}
+
+// Range call f for every row of the mirror, until f return false.
+// Range iterate over copy of the mirror, f can call any methods of the mirror.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *CDCMirror[K, V]) Range(f func(key K, value V) bool) {
+	m.m.RLock()
+	values := make(map[K]V, len(m.values))
+	for k, v := range m.values {
+		values[k] = v
+	}
+	m.m.RUnlock()
+
+	for k, v := range values {
+		if !f(k, v) {
+			return
+		}
+	}
+}
+
+// Ready return true if the mirror loaded snapshot and applied changes, written while snapshot read
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *CDCMirror[K, V]) Ready() bool {
+	select {
+	case <-m.ready:
+		return m.Err() == nil
+	default:
+		return false
+	}
+}
+
+// WaitReady wait until the mirror ready or failed
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *CDCMirror[K, V]) WaitReady(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return xerrors.WithStackTrace(ctx.Err())
+	case <-m.ready:
+		return m.Err()
+	}
+}
+
+// Staleness return time since last known moment, when the mirror was consistent with the table.
+// The moment updated by every changefeed message and resolved timestamp.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *CDCMirror[K, V]) Staleness() time.Duration {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	if m.lastUpdateTime.IsZero() {
+		return 0
+	}
+
+	staleness := m.cfg.clock.Since(m.lastUpdateTime)
+	if staleness < 0 {
+		return 0
+	}
+
+	return staleness
+}
+
+// Err return reason of the mirror stopped
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *CDCMirror[K, V]) Err() error {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return m.err
+}
+
+// Close stop read changefeed. Values of the mirror stay available but will not be updated.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *CDCMirror[K, V]) Close(ctx context.Context) error {
+	return m.background.Close(ctx, errCDCMirrorClosed)
+}
+
+func (m *CDCMirror[K, V]) run(ctx context.Context) {
+	err := m.bootstrapAndRead(ctx)
+	if ctx.Err() != nil {
+		err = errCDCMirrorClosed
+	}
+
+	m.m.Lock()
+	m.err = err
+	m.m.Unlock()
+
+	m.markReady()
+}
+
+func (m *CDCMirror[K, V]) bootstrapAndRead(ctx context.Context) error {
+	var (
+		startOffsets map[int64]int64
+		values       map[K]V
+		endOffsets   map[int64]int64
+		snapshotTime time.Time
+	)
+
+	// changes, written after start offsets captured, are re-applied after the snapshot.
+	// Until the reader reach end offsets, captured after the snapshot, the mirror may contain older values
+	err := retry.Retry(ctx, func(ctx context.Context) (err error) {
+		startOffsets, err = m.partitionEndOffsets(ctx)
+		if err != nil {
+			return err
+		}
+
+		snapshotTime = m.cfg.clock.Now()
+		values, err = m.snapshot(ctx)
+		if err != nil {
+			return err
+		}
+
+		endOffsets, err = m.partitionEndOffsets(ctx)
+
+		return err
+	}, append([]retry.Option{retry.WithIdempotent(true)}, m.cfg.retryOptions...)...)
+	if err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to bootstrap cdc mirror: %w", err))
+	}
+
+	pending := make(map[int64]int64)
+	for partitionID, end := range endOffsets {
+		if end > startOffsets[partitionID] {
+			pending[partitionID] = end
+		}
+	}
+
+	m.m.Lock()
+	m.values = values
+	if m.values == nil {
+		m.values = make(map[K]V)
+	}
+	m.lastUpdateTime = snapshotTime
+	m.pendingOffsets = pending
+	m.m.Unlock()
+
+	if len(pending) == 0 {
+		m.markReady()
+	}
+
+	reader, err := m.startReader(startOffsets)
+	if err != nil {
+		return xerrors.WithStackTrace(err)
+	}
+	defer func() {
+		_ = reader.Close(context.Background())
+	}()
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return xerrors.WithStackTrace(err)
+		}
+
+		if err = m.applyMessage(msg); err != nil {
+			return err
+		}
+
+		if err = reader.Commit(ctx, msg); err != nil {
+			return xerrors.WithStackTrace(err)
+		}
+	}
+}
+
+func (m *CDCMirror[K, V]) partitionEndOffsets(ctx context.Context) (map[int64]int64, error) {
+	description, err := m.client.DescribeTopicConsumer(
+		ctx, m.changefeedPath, m.consumer, topicoptions.IncludeConsumerStats(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int64]int64, len(description.Partitions))
+	for i := range description.Partitions {
+		p := &description.Partitions[i]
+		res[p.PartitionID] = p.PartitionStats.PartitionsOffset.End
+	}
+
+	return res, nil
+}
+
+func (m *CDCMirror[K, V]) startTopicReader(startOffsets map[int64]int64) (cdcMirrorReader, error) {
+	opts := append([]topicoptions.ReaderOption{
+		topicoptions.WithReaderGetPartitionStartOffset(func(
+			ctx context.Context,
+			req topicoptions.GetPartitionStartOffsetRequest,
+		) (res topicoptions.GetPartitionStartOffsetResponse, err error) {
+			if offset, ok := m.partitionStartOffset(startOffsets, req.PartitionID); ok {
+				res.StartFrom(offset)
+			}
+
+			return res, nil
+		}),
+	}, m.cfg.readerOptions...)
+
+	return m.client.StartReader(m.consumer, topicoptions.ReadTopic(m.changefeedPath), opts...)
+}
+
+// partitionStartOffset return offset for start read the partition: next offset after the applied messages
+// if the partition session restarted after reconnect or rebalance, start offset of bootstrap for the first session.
+func (m *CDCMirror[K, V]) partitionStartOffset(startOffsets map[int64]int64, partitionID int64) (int64, bool) {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	if offset, ok := m.nextOffsets[partitionID]; ok {
+		return offset, true
+	}
+
+	offset, ok := startOffsets[partitionID]
+
+	return offset, ok
+}
+
+func (m *CDCMirror[K, V]) applyMessage(msg *topicreader.Message) error {
+	var (
+		resolved struct {
+			Resolved []uint64 `json:"resolved"`
+		}
+		event YDBCDCMessage[V, K]
+	)
+
+	err := ReadMessageDataWithCallback(msg, func(data []byte) error {
+		if err := json.Unmarshal(data, &resolved); err != nil {
+			return err
+		}
+		if len(resolved.Resolved) > 0 {
+			return nil
+		}
+
+		return json.Unmarshal(data, &event)
+	})
+	if err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf(
+			"ydb: failed to unmarshal cdc message (topic: %q, partition: %v, offset: %v): %w",
+			msg.Topic(), msg.PartitionID(), msg.Offset, err,
+		))
+	}
+
+	eventTime := msg.WrittenAt
+	switch {
+	case len(resolved.Resolved) > 0:
+		eventTime = time.UnixMilli(int64(resolved.Resolved[0]))
+	case len(event.TS) > 0:
+		eventTime = time.UnixMilli(int64(event.TS[0]))
+	}
+
+	var zero V
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if len(resolved.Resolved) == 0 {
+		switch {
+		case event.IsErase():
+			delete(m.values, event.Key)
+		case event.NewImage != zero:
+			m.values[event.Key] = event.NewImage
+		case event.Update != zero:
+			m.values[event.Key] = event.Update
+		}
+	}
+
+	if eventTime.After(m.lastUpdateTime) {
+		m.lastUpdateTime = eventTime
+	}
+
+	m.nextOffsets[msg.PartitionID()] = msg.Offset + 1
+
+	if end, ok := m.pendingOffsets[msg.PartitionID()]; ok && msg.Offset+1 >= end {
+		delete(m.pendingOffsets, msg.PartitionID())
+		if len(m.pendingOffsets) == 0 {
+			m.markReady()
+		}
+	}
+
+	return nil
+}
+
+func (m *CDCMirror[K, V]) markReady() {
+	m.readyOnce.Do(func() {
+		close(m.ready)
+	})
+}
@@ -0,0 +1,202 @@
+//go:build go1.23
+
+package topicsugar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/topic/topicreadercommon"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicoptions"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicreader"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topictypes"
+)
+
+func TestCDCMirror(t *testing.T) {
+	ctx := xtest.Context(t)
+	clock := clockwork.NewFakeClockAt(time.UnixMilli(1000))
+
+	describeCount := 0
+	client := testCDCMirrorClient{
+		describerFunc: func(ctx context.Context, path, consumer string) (topictypes.TopicConsumerDescription, error) {
+			require.Equal(t, "table/feed", path)
+			require.Equal(t, "consumer", consumer)
+
+			// before snapshot partition contains 3 messages, after - 5
+			end := int64(3)
+			if describeCount > 0 {
+				end = 5
+			}
+			describeCount++
+
+			return topictypes.TopicConsumerDescription{
+				Partitions: []topictypes.DescribeConsumerPartitionInfo{{
+					PartitionID: 1,
+					PartitionStats: topictypes.PartitionStats{
+						PartitionsOffset: topictypes.OffsetRange{End: end},
+					},
+				}},
+			}, nil
+		},
+	}
+
+	snapshot := func(ctx context.Context) (map[int64]*testCDCItem, error) {
+		return map[int64]*testCDCItem{
+			1: {ID: 1, Val: "snapshot-1"},
+			2: {ID: 2, Val: "snapshot-2"},
+		}, nil
+	}
+
+	reader := newTestCDCMirrorReader()
+	mirror := newCDCMirror[int64, *testCDCItem](client, "table/feed", "consumer", snapshot, withCDCMirrorClock(clock))
+	mirror.startReader = func(startOffsets map[int64]int64) (cdcMirrorReader, error) {
+		require.Equal(t, map[int64]int64{1: 3}, startOffsets)
+
+		return reader, nil
+	}
+	mirror.background.Start("cdc mirror", mirror.run)
+	defer func() {
+		_ = mirror.Close(ctx)
+	}()
+
+	reader.messages <- testCDCMessage(3, `{"key":[3],"newImage":{"val":"new-3"},"ts":[2000,1]}`)
+	xtest.WaitChannelClosed(t, reader.committed[3])
+	require.False(t, mirror.Ready())
+
+	v, ok := mirror.Get(3)
+	require.True(t, ok)
+	require.Equal(t, &testCDCItem{ID: 3, Val: "new-3"}, v)
+
+	reader.messages <- testCDCMessage(4, `{"key":[1],"erase":{},"ts":[3000,1]}`)
+	require.NoError(t, mirror.WaitReady(ctx))
+	require.True(t, mirror.Ready())
+
+	_, ok = mirror.Get(1)
+	require.False(t, ok)
+	require.Equal(t, 2, mirror.Len())
+
+	values := make(map[int64]string)
+	mirror.Range(func(key int64, value *testCDCItem) bool {
+		values[key] = value.Val
+
+		return true
+	})
+	require.Equal(t, map[int64]string{2: "snapshot-2", 3: "new-3"}, values)
+
+	clock.Advance(3 * time.Second)
+	require.Equal(t, time.Second, mirror.Staleness())
+
+	reader.messages <- testCDCMessage(5, `{"resolved":[5000,0]}`)
+	xtest.WaitChannelClosed(t, reader.committed[5])
+	require.Equal(t, time.Duration(0), mirror.Staleness())
+	clock.Advance(2 * time.Second)
+	require.Equal(t, time.Second, mirror.Staleness())
+
+	testErr := errors.New("test")
+	reader.err <- testErr
+	xtest.SpinWaitCondition(t, nil, func() bool {
+		return mirror.Err() != nil
+	})
+	require.ErrorIs(t, mirror.Err(), testErr)
+	require.False(t, mirror.Ready())
+}
+
+func TestCDCMirrorPartitionStartOffset(t *testing.T) {
+	mirror := newCDCMirror[int64, *testCDCItem](testCDCMirrorClient{}, "table/feed", "consumer", nil)
+	startOffsets := map[int64]int64{1: 3}
+
+	offset, ok := mirror.partitionStartOffset(startOffsets, 1)
+	require.True(t, ok)
+	require.Equal(t, int64(3), offset, "first session start from the bootstrap offset")
+
+	_, ok = mirror.partitionStartOffset(startOffsets, 2)
+	require.False(t, ok, "unknown partition start from the committed offset")
+
+	require.NoError(t, mirror.applyMessage(testCDCMessage(7, `{"key":[3],"newImage":{"val":"new-3"},"ts":[2000,1]}`)))
+	offset, ok = mirror.partitionStartOffset(startOffsets, 1)
+	require.True(t, ok)
+	require.Equal(t, int64(8), offset, "restarted session continue after the applied messages")
+}
+
+type testCDCItem struct {
+	ID  int64  `json:"-"`
+	Val string `json:"val"`
+}
+
+func (item *testCDCItem) ParseCDCKey(keyFields []json.RawMessage) (int64, error) {
+	var id int64
+	err := json.Unmarshal(keyFields[0], &id)
+
+	return id, err
+}
+
+func (item *testCDCItem) SetPrimaryKey(key int64) {
+	item.ID = key
+}
+
+func testCDCMessage(offset int64, data string) *topicreader.Message {
+	return topicreadercommon.NewPublicMessageBuilder().
+		PartitionID(1).
+		Offset(offset).
+		DataAndUncompressedSize([]byte(data)).
+		Build()
+}
+
+type testCDCMirrorClient struct {
+	describerFunc
+}
+
+func (testCDCMirrorClient) StartReader(
+	consumer string,
+	readSelectors topicoptions.ReadSelectors,
+	opts ...topicoptions.ReaderOption,
+) (*topicreader.Reader, error) {
+	return nil, errors.New("not implemented")
+}
+
+type testCDCMirrorReader struct {
+	messages  chan *topicreader.Message
+	err       chan error
+	committed map[int64]chan struct{}
+}
+
+func newTestCDCMirrorReader() *testCDCMirrorReader {
+	r := &testCDCMirrorReader{
+		messages:  make(chan *topicreader.Message),
+		err:       make(chan error),
+		committed: make(map[int64]chan struct{}),
+	}
+	for offset := int64(0); offset < 10; offset++ {
+		r.committed[offset] = make(chan struct{})
+	}
+
+	return r
+}
+
+func (r *testCDCMirrorReader) ReadMessage(ctx context.Context) (*topicreader.Message, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-r.err:
+		return nil, err
+	case msg := <-r.messages:
+		return msg, nil
+	}
+}
+
+func (r *testCDCMirrorReader) Commit(ctx context.Context, obj topicreader.CommitRangeGetter) error {
+	close(r.committed[obj.(*topicreader.Message).Offset])
+
+	return nil
+}
+
+func (r *testCDCMirrorReader) Close(ctx context.Context) error {
+	return nil
+}
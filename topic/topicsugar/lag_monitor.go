@@ -0,0 +1,337 @@
+package topicsugar
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/background"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/metrics"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicoptions"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topictypes"
+)
+
+const defaultLagMonitorInterval = time.Minute
+
+var errLagMonitorClosed = xerrors.Wrap(errors.New("ydb: topic lag monitor closed"))
+
+// TopicConsumerDescriber is interface for describe topic consumer, topic.Client implements it
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type TopicConsumerDescriber interface {
+	DescribeTopicConsumer(
+		ctx context.Context, path string, consumer string, opts ...topicoptions.DescribeConsumerOption,
+	) (topictypes.TopicConsumerDescription, error)
+}
+
+// LagMonitorTarget is pair of topic and consumer for monitoring
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type LagMonitorTarget struct {
+	Path     string
+	Consumer string
+}
+
+// PartitionLag contains lag of consumer for one partition of topic
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type PartitionLag struct {
+	Path            string
+	Consumer        string
+	PartitionID     int64
+	EndOffset       int64
+	CommittedOffset int64
+
+	// MessageLag is count of messages, written to the partition and not committed by the consumer yet
+	MessageLag int64
+
+	// TimeLag is estimation of wait time for oldest uncommitted message.
+	// It is zero if the consumer committed all messages.
+	TimeLag time.Duration
+
+	// Alert is true if MessageLag or TimeLag exceed the thresholds of the monitor
+	Alert bool
+}
+
+// LagMonitorOption set settings for LagMonitor
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type LagMonitorOption func(cfg *lagMonitorConfig)
+
+type lagMonitorConfig struct {
+	interval            time.Duration
+	messageLagThreshold int64
+	timeLagThreshold    time.Duration
+	registry            metrics.Registry
+	onLag               func(lags []PartitionLag, err error)
+	onAlert             func(lag PartitionLag)
+	clock               clockwork.Clock
+}
+
+// WithLagMonitorInterval set interval between describe consumers calls
+// default: 1 minute, non-positive interval is ignored
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLagMonitorInterval(interval time.Duration) LagMonitorOption {
+	return func(cfg *lagMonitorConfig) {
+		if interval > 0 {
+			cfg.interval = interval
+		}
+	}
+}
+
+// WithLagMonitorMessageLagThreshold set messages count, partition with greater lag marked as alert.
+// Zero (default) disable the threshold.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLagMonitorMessageLagThreshold(messages int64) LagMonitorOption {
+	return func(cfg *lagMonitorConfig) {
+		cfg.messageLagThreshold = messages
+	}
+}
+
+// WithLagMonitorTimeLagThreshold set time lag, partition with greater lag marked as alert.
+// Zero (default) disable the threshold.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLagMonitorTimeLagThreshold(lag time.Duration) LagMonitorOption {
+	return func(cfg *lagMonitorConfig) {
+		cfg.timeLagThreshold = lag
+	}
+}
+
+// WithLagMonitorMetrics set registry for publish lags as gauges
+// topic_consumer_message_lag, topic_consumer_time_lag_seconds and topic_consumer_lag_alert
+// with labels topic, consumer and partition.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLagMonitorMetrics(registry metrics.Registry) LagMonitorOption {
+	return func(cfg *lagMonitorConfig) {
+		cfg.registry = registry
+	}
+}
+
+// WithLagMonitorCallback set callback, called after every check with all partition lags.
+// err is not nil if describe some of consumers failed, lags contains results of succeeded describes.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLagMonitorCallback(f func(lags []PartitionLag, err error)) LagMonitorOption {
+	return func(cfg *lagMonitorConfig) {
+		cfg.onLag = f
+	}
+}
+
+// WithLagMonitorAlert set callback, called for every partition with lag over thresholds
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithLagMonitorAlert(f func(lag PartitionLag)) LagMonitorOption {
+	return func(cfg *lagMonitorConfig) {
+		cfg.onAlert = f
+	}
+}
+
+func withLagMonitorClock(clock clockwork.Clock) LagMonitorOption {
+	return func(cfg *lagMonitorConfig) {
+		cfg.clock = clock
+	}
+}
+
+// LagMonitor periodically describe topic consumers and compute lag for every partition
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type LagMonitor struct {
+	client  TopicConsumerDescriber
+	targets []LagMonitorTarget
+	cfg     lagMonitorConfig
+
+	messageLag metrics.GaugeVec
+	timeLag    metrics.GaugeVec
+	alert      metrics.GaugeVec
+
+	background background.Worker
+
+	m        sync.Mutex
+	lastLags []PartitionLag
+}
+
+// StartLagMonitor create monitor for targets and start background polling
+// it is fast non block call, first check starts in background
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func StartLagMonitor(
+	client TopicConsumerDescriber,
+	targets []LagMonitorTarget,
+	opts ...LagMonitorOption,
+) *LagMonitor {
+	m := newLagMonitor(client, targets, opts...)
+	m.background.Start("lag monitor loop", m.loop)
+
+	return m
+}
+
+func newLagMonitor(client TopicConsumerDescriber, targets []LagMonitorTarget, opts ...LagMonitorOption) *LagMonitor {
+	m := &LagMonitor{
+		client:  client,
+		targets: append([]LagMonitorTarget(nil), targets...),
+		cfg: lagMonitorConfig{
+			interval: defaultLagMonitorInterval,
+			clock:    clockwork.NewRealClock(),
+		},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&m.cfg)
+		}
+	}
+
+	if m.cfg.registry != nil {
+		labels := []string{"topic", "consumer", "partition"}
+		m.messageLag = m.cfg.registry.GaugeVec("topic_consumer_message_lag", labels...)
+		m.timeLag = m.cfg.registry.GaugeVec("topic_consumer_time_lag_seconds", labels...)
+		m.alert = m.cfg.registry.GaugeVec("topic_consumer_lag_alert", labels...)
+	}
+
+	return m
+}
+
+// Lags return result of last check
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *LagMonitor) Lags() []PartitionLag {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	return append([]PartitionLag(nil), m.lastLags...)
+}
+
+// Check describe all consumers now, publish and return lags
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *LagMonitor) Check(ctx context.Context) ([]PartitionLag, error) {
+	var (
+		lags []PartitionLag
+		errs []error
+	)
+
+	for _, target := range m.targets {
+		description, err := m.client.DescribeTopicConsumer(
+			ctx, target.Path, target.Consumer, topicoptions.IncludeConsumerStats(),
+		)
+		if err != nil {
+			errs = append(errs, xerrors.WithStackTrace(err))
+
+			continue
+		}
+
+		now := m.cfg.clock.Now()
+		for i := range description.Partitions {
+			lags = append(lags, m.partitionLag(now, target, &description.Partitions[i]))
+		}
+	}
+
+	err := xerrors.Join(errs...)
+
+	m.publish(lags, err)
+
+	return lags, err
+}
+
+// Close stop background polling
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (m *LagMonitor) Close(ctx context.Context) error {
+	return m.background.Close(ctx, errLagMonitorClosed)
+}
+
+func (m *LagMonitor) loop(ctx context.Context) {
+	ticker := m.cfg.clock.NewTicker(m.cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = m.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+		}
+	}
+}
+
+func (m *LagMonitor) publish(lags []PartitionLag, err error) {
+	m.m.Lock()
+	m.lastLags = lags
+	m.m.Unlock()
+
+	for i := range lags {
+		lag := &lags[i]
+		if m.cfg.registry != nil {
+			labels := map[string]string{
+				"topic":     lag.Path,
+				"consumer":  lag.Consumer,
+				"partition": strconv.FormatInt(lag.PartitionID, 10),
+			}
+			m.messageLag.With(labels).Set(float64(lag.MessageLag))
+			m.timeLag.With(labels).Set(lag.TimeLag.Seconds())
+			if lag.Alert {
+				m.alert.With(labels).Set(1)
+			} else {
+				m.alert.With(labels).Set(0)
+			}
+		}
+		if lag.Alert && m.cfg.onAlert != nil {
+			m.cfg.onAlert(*lag)
+		}
+	}
+
+	if m.cfg.onLag != nil {
+		m.cfg.onLag(lags, err)
+	}
+}
+
+func (m *LagMonitor) partitionLag(
+	now time.Time,
+	target LagMonitorTarget,
+	p *topictypes.DescribeConsumerPartitionInfo,
+) PartitionLag {
+	res := PartitionLag{
+		Path:            target.Path,
+		Consumer:        target.Consumer,
+		PartitionID:     p.PartitionID,
+		EndOffset:       p.PartitionStats.PartitionsOffset.End,
+		CommittedOffset: p.PartitionConsumerStats.CommittedOffset,
+	}
+
+	res.MessageLag = res.EndOffset - res.CommittedOffset
+	if res.MessageLag < 0 {
+		res.MessageLag = 0
+	}
+
+	if res.MessageLag > 0 {
+		// server has no write time of first uncommitted message, estimate it by
+		// time since last read (stopped consumer) or read lag of last read messages (slow consumer)
+		stats := &p.PartitionConsumerStats
+		switch {
+		case stats.LastReadTime != nil:
+			res.TimeLag = now.Sub(*stats.LastReadTime)
+		case p.PartitionStats.LastWriteTime != nil:
+			res.TimeLag = now.Sub(*p.PartitionStats.LastWriteTime)
+		}
+		if stats.MaxReadTimeLag != nil && *stats.MaxReadTimeLag > res.TimeLag {
+			res.TimeLag = *stats.MaxReadTimeLag
+		}
+		if res.TimeLag < 0 {
+			res.TimeLag = 0
+		}
+	}
+
+	res.Alert = (m.cfg.messageLagThreshold > 0 && res.MessageLag > m.cfg.messageLagThreshold) ||
+		(m.cfg.timeLagThreshold > 0 && res.TimeLag > m.cfg.timeLagThreshold)
+
+	return res
+}
@@ -0,0 +1,211 @@
+package topicsugar
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/metrics"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicoptions"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topictypes"
+)
+
+func TestLagMonitorCheck(t *testing.T) {
+	ctx := xtest.Context(t)
+	clock := clockwork.NewFakeClock()
+	now := clock.Now()
+	lastRead := now.Add(-time.Minute)
+	readLag := 2 * time.Minute
+
+	client := describerFunc(func(ctx context.Context, path, consumer string) (topictypes.TopicConsumerDescription, error) {
+		if path == "broken" {
+			return topictypes.TopicConsumerDescription{}, errors.New("test")
+		}
+
+		return topictypes.TopicConsumerDescription{
+			Path: path,
+			Partitions: []topictypes.DescribeConsumerPartitionInfo{
+				{
+					PartitionID: 0,
+					PartitionStats: topictypes.PartitionStats{
+						PartitionsOffset: topictypes.OffsetRange{End: 10},
+					},
+					PartitionConsumerStats: topictypes.PartitionConsumerStats{CommittedOffset: 10},
+				},
+				{
+					PartitionID: 1,
+					PartitionStats: topictypes.PartitionStats{
+						PartitionsOffset: topictypes.OffsetRange{End: 110},
+					},
+					PartitionConsumerStats: topictypes.PartitionConsumerStats{
+						CommittedOffset: 10,
+						LastReadTime:    &lastRead,
+					},
+				},
+				{
+					PartitionID: 2,
+					PartitionStats: topictypes.PartitionStats{
+						PartitionsOffset: topictypes.OffsetRange{End: 5},
+					},
+					PartitionConsumerStats: topictypes.PartitionConsumerStats{
+						CommittedOffset: 4,
+						LastReadTime:    &now,
+						MaxReadTimeLag:  &readLag,
+					},
+				},
+			},
+		}, nil
+	})
+
+	registry := newTestGaugeRegistry()
+	var alerts []PartitionLag
+	var callbackLags []PartitionLag
+	monitor := newLagMonitor(client,
+		[]LagMonitorTarget{{Path: "topic", Consumer: "consumer"}, {Path: "broken", Consumer: "consumer"}},
+		withLagMonitorClock(clock),
+		WithLagMonitorMetrics(registry),
+		WithLagMonitorMessageLagThreshold(50),
+		WithLagMonitorTimeLagThreshold(5*time.Minute),
+		WithLagMonitorAlert(func(lag PartitionLag) {
+			alerts = append(alerts, lag)
+		}),
+		WithLagMonitorCallback(func(lags []PartitionLag, err error) {
+			require.Error(t, err)
+			callbackLags = lags
+		}),
+	)
+
+	lags, err := monitor.Check(ctx)
+	require.Error(t, err)
+
+	expected := []PartitionLag{
+		{Path: "topic", Consumer: "consumer", PartitionID: 0, EndOffset: 10, CommittedOffset: 10},
+		{
+			Path: "topic", Consumer: "consumer", PartitionID: 1, EndOffset: 110, CommittedOffset: 10,
+			MessageLag: 100, TimeLag: time.Minute, Alert: true,
+		},
+		{
+			Path: "topic", Consumer: "consumer", PartitionID: 2, EndOffset: 5, CommittedOffset: 4,
+			MessageLag: 1, TimeLag: 2 * time.Minute,
+		},
+	}
+	require.Equal(t, expected, lags)
+	require.Equal(t, expected, callbackLags)
+	require.Equal(t, expected, monitor.Lags())
+	require.Equal(t, []PartitionLag{expected[1]}, alerts)
+
+	require.Equal(t, 100.0, registry.value("topic_consumer_message_lag", "topic", "consumer", "1"))
+	require.Equal(t, 60.0, registry.value("topic_consumer_time_lag_seconds", "topic", "consumer", "1"))
+	require.Equal(t, 1.0, registry.value("topic_consumer_lag_alert", "topic", "consumer", "1"))
+	require.Equal(t, 0.0, registry.value("topic_consumer_lag_alert", "topic", "consumer", "2"))
+}
+
+func TestLagMonitorBackground(t *testing.T) {
+	ctx := xtest.Context(t)
+
+	checked := make(chan struct{})
+	var checkedOnce sync.Once
+	client := describerFunc(func(ctx context.Context, path, consumer string) (topictypes.TopicConsumerDescription, error) {
+		return topictypes.TopicConsumerDescription{}, nil
+	})
+
+	monitor := StartLagMonitor(client, []LagMonitorTarget{{Path: "topic", Consumer: "consumer"}},
+		WithLagMonitorInterval(time.Millisecond),
+		WithLagMonitorCallback(func(lags []PartitionLag, err error) {
+			checkedOnce.Do(func() {
+				close(checked)
+			})
+		}),
+	)
+
+	xtest.WaitChannelClosed(t, checked)
+	require.NoError(t, monitor.Close(ctx))
+}
+
+func TestLagMonitorInterval(t *testing.T) {
+	monitor := newLagMonitor(nil, nil, WithLagMonitorInterval(time.Second))
+	require.Equal(t, time.Second, monitor.cfg.interval)
+
+	monitor = newLagMonitor(nil, nil, WithLagMonitorInterval(0))
+	require.Equal(t, defaultLagMonitorInterval, monitor.cfg.interval)
+
+	monitor = newLagMonitor(nil, nil, WithLagMonitorInterval(-time.Second))
+	require.Equal(t, defaultLagMonitorInterval, monitor.cfg.interval)
+}
+
+type describerFunc func(ctx context.Context, path, consumer string) (topictypes.TopicConsumerDescription, error)
+
+func (f describerFunc) DescribeTopicConsumer(
+	ctx context.Context, path string, consumer string, _ ...topicoptions.DescribeConsumerOption,
+) (topictypes.TopicConsumerDescription, error) {
+	return f(ctx, path, consumer)
+}
+
+type testGaugeRegistry struct {
+	m      sync.Mutex
+	gauges map[string]*testGauge
+}
+
+func newTestGaugeRegistry() *testGaugeRegistry {
+	return &testGaugeRegistry{gauges: make(map[string]*testGauge)}
+}
+
+func (r *testGaugeRegistry) value(name, topic, consumer, partition string) float64 {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return r.gauges[name+"/"+topic+"/"+consumer+"/"+partition].value
+}
+
+func (r *testGaugeRegistry) CounterVec(name string, labelNames ...string) metrics.CounterVec {
+	panic("not implemented")
+}
+
+func (r *testGaugeRegistry) GaugeVec(name string, labelNames ...string) metrics.GaugeVec {
+	return testGaugeVec{registry: r, name: name}
+}
+
+func (r *testGaugeRegistry) TimerVec(name string, labelNames ...string) metrics.TimerVec {
+	panic("not implemented")
+}
+
+func (r *testGaugeRegistry) HistogramVec(name string, buckets []float64, labelNames ...string) metrics.HistogramVec {
+	panic("not implemented")
+}
+
+type testGaugeVec struct {
+	registry *testGaugeRegistry
+	name     string
+}
+
+func (v testGaugeVec) With(labels map[string]string) metrics.Gauge {
+	v.registry.m.Lock()
+	defer v.registry.m.Unlock()
+
+	key := v.name + "/" + labels["topic"] + "/" + labels["consumer"] + "/" + labels["partition"]
+	g, ok := v.registry.gauges[key]
+	if !ok {
+		g = &testGauge{}
+		v.registry.gauges[key] = g
+	}
+
+	return g
+}
+
+type testGauge struct {
+	value float64
+}
+
+func (g *testGauge) Add(delta float64) {
+	g.value += delta
+}
+
+func (g *testGauge) Set(value float64) {
+	g.value = value
+}
@@ -0,0 +1,368 @@
+package topicsugar
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/background"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/empty"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicreader"
+)
+
+const (
+	defaultMergeByWriteTimeWatermarkDelay      = time.Second
+	defaultMergeByWriteTimeMaxBufferedMessages = 10000
+)
+
+var errMergedReaderClosed = xerrors.Wrap(errors.New("ydb: merged by write time reader closed"))
+
+// MergeByWriteTimeSource is interface of the source reader for MergeByWriteTime. topicreader.Reader implements it
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type MergeByWriteTimeSource interface {
+	ReadMessage(ctx context.Context) (*topicreader.Message, error)
+	Commit(ctx context.Context, obj topicreader.CommitRangeGetter) error
+}
+
+// MergeByWriteTimeOption set settings for MergeByWriteTime
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type MergeByWriteTimeOption func(cfg *mergeByWriteTimeConfig)
+
+type mergeByWriteTimeConfig struct {
+	watermarkDelay      time.Duration
+	maxBufferedMessages int
+	useCreatedAt        bool
+	clock               clockwork.Clock
+}
+
+func (cfg *mergeByWriteTimeConfig) messageTime(msg *topicreader.Message) time.Time {
+	if cfg.useCreatedAt {
+		return msg.CreatedAt
+	}
+
+	return msg.WrittenAt
+}
+
+// WithMergeByWriteTimeWatermarkDelay set time without new messages from a partition, after which the partition
+// is idle and doesn't hold messages of other partitions. Default: 1s
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithMergeByWriteTimeWatermarkDelay(delay time.Duration) MergeByWriteTimeOption {
+	return func(cfg *mergeByWriteTimeConfig) {
+		cfg.watermarkDelay = delay
+	}
+}
+
+// WithMergeByWriteTimeMaxBufferedMessages set max count of messages in buffer.
+// When the buffer is full - the oldest message release without wait watermark delay. Default: 10000
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithMergeByWriteTimeMaxBufferedMessages(count int) MergeByWriteTimeOption {
+	return func(cfg *mergeByWriteTimeConfig) {
+		if count > 0 {
+			cfg.maxBufferedMessages = count
+		}
+	}
+}
+
+// WithMergeByWriteTimeUseCreatedAt order messages by CreatedAt (set by producer) instead of WrittenAt (set by server)
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func WithMergeByWriteTimeUseCreatedAt() MergeByWriteTimeOption {
+	return func(cfg *mergeByWriteTimeConfig) {
+		cfg.useCreatedAt = true
+	}
+}
+
+func withMergeByWriteTimeClock(clock clockwork.Clock) MergeByWriteTimeOption {
+	return func(cfg *mergeByWriteTimeConfig) {
+		cfg.clock = clock
+	}
+}
+
+type mergePartitionKey struct {
+	topic       string
+	partitionID int64
+}
+
+func (k mergePartitionKey) less(other mergePartitionKey) bool {
+	if k.topic != other.topic {
+		return k.topic < other.topic
+	}
+
+	return k.partitionID < other.partitionID
+}
+
+// MergedByWriteTimeReader release messages of all partitions of the source reader ordered by write time.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type MergedByWriteTimeReader struct {
+	source     MergeByWriteTimeSource
+	cfg        mergeByWriteTimeConfig
+	background background.Worker
+	startedAt  time.Time
+
+	m          sync.Mutex
+	partitions map[mergePartitionKey]*mergePartition
+	buffered   int
+	changed    empty.Chan
+	err        error
+	closed     bool
+}
+
+// mergePartition is the buffer of the partition and its low watermark: the time of the last message, read from
+// the partition. Messages of the partition are read in offset order, then the partition has no unread messages
+// with time less than the watermark
+type mergePartition struct {
+	messages    []*topicreader.Message
+	watermark   time.Time
+	lastReadAt  time.Time
+	sessionDone <-chan struct{}
+}
+
+// MergeByWriteTime read messages from the source in background, buffer them per partition and
+// release in WrittenAt (or CreatedAt) order. The oldest buffered message released when every active partition
+// has read a message with the same or greater time, or has no new messages for watermark delay (idle).
+// Messages are held for watermark delay after start, then partitions of the source can read their first
+// messages.
+// Messages within one partition released in offset order, then commit of released messages
+// keep commit order of every partition.
+// The source reader must not be used directly after call MergeByWriteTime.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func MergeByWriteTime(source MergeByWriteTimeSource, opts ...MergeByWriteTimeOption) *MergedByWriteTimeReader {
+	r := newMergedByWriteTimeReader(source, opts...)
+	r.background.Start("merge by write time reader", r.readLoop)
+
+	return r
+}
+
+func newMergedByWriteTimeReader(
+	source MergeByWriteTimeSource,
+	opts ...MergeByWriteTimeOption,
+) *MergedByWriteTimeReader {
+	r := &MergedByWriteTimeReader{
+		source: source,
+		cfg: mergeByWriteTimeConfig{
+			watermarkDelay:      defaultMergeByWriteTimeWatermarkDelay,
+			maxBufferedMessages: defaultMergeByWriteTimeMaxBufferedMessages,
+			clock:               clockwork.NewRealClock(),
+		},
+		partitions: make(map[mergePartitionKey]*mergePartition),
+		changed:    make(empty.Chan),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&r.cfg)
+		}
+	}
+	r.startedAt = r.cfg.clock.Now()
+
+	return r
+}
+
+// ReadMessage return next message in write time order
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (r *MergedByWriteTimeReader) ReadMessage(ctx context.Context) (*topicreader.Message, error) {
+	for {
+		r.m.Lock()
+		if r.closed {
+			r.m.Unlock()
+
+			return nil, xerrors.WithStackTrace(errMergedReaderClosed)
+		}
+		msg, wait, ok := r.popNeedLock()
+		if ok {
+			r.notifyNeedLock()
+			r.m.Unlock()
+
+			return msg, nil
+		}
+		if r.err != nil {
+			err := r.err
+			r.m.Unlock()
+
+			return nil, err
+		}
+		changed := r.changed
+		r.m.Unlock()
+
+		var timer clockwork.Timer
+		var timerChan <-chan time.Time
+		if wait > 0 {
+			timer = r.cfg.clock.NewTimer(wait)
+			timerChan = timer.Chan()
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+
+			return nil, xerrors.WithStackTrace(ctx.Err())
+		case <-changed:
+		case <-timerChan:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// Commit commits the message or batch through the source reader
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (r *MergedByWriteTimeReader) Commit(ctx context.Context, obj topicreader.CommitRangeGetter) error {
+	return r.source.Commit(ctx, obj)
+}
+
+// Close stop read from the source. It doesn't close the source reader.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (r *MergedByWriteTimeReader) Close(ctx context.Context) error {
+	r.m.Lock()
+	r.closed = true
+	r.notifyNeedLock()
+	r.m.Unlock()
+
+	return r.background.Close(ctx, errMergedReaderClosed)
+}
+
+func (r *MergedByWriteTimeReader) readLoop(ctx context.Context) {
+	for {
+		r.m.Lock()
+		full := r.buffered >= r.cfg.maxBufferedMessages
+		changed := r.changed
+		r.m.Unlock()
+
+		if full {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				continue
+			}
+		}
+
+		msg, err := r.source.ReadMessage(ctx)
+
+		r.m.Lock()
+		if err != nil {
+			if r.err == nil {
+				r.err = err
+			}
+			r.notifyNeedLock()
+			r.m.Unlock()
+
+			return
+		}
+		key := mergePartitionKey{topic: msg.Topic(), partitionID: msg.PartitionID()}
+		partition, has := r.partitions[key]
+		if !has {
+			partition = &mergePartition{}
+			r.partitions[key] = partition
+		}
+		partition.messages = append(partition.messages, msg)
+		messageTime := r.cfg.messageTime(msg)
+		if partition.sessionDone != msg.Context().Done() || messageTime.After(partition.watermark) {
+			// the watermark of new partition session starts again, it reads messages from the committed offset
+			partition.watermark = messageTime
+			partition.sessionDone = msg.Context().Done()
+		}
+		partition.lastReadAt = r.cfg.clock.Now()
+		r.buffered++
+		r.notifyNeedLock()
+		r.m.Unlock()
+	}
+}
+
+// popNeedLock return the oldest head of partition buffers if it can be released
+// or time for wait until it can be released
+func (r *MergedByWriteTimeReader) popNeedLock() (msg *topicreader.Message, wait time.Duration, ok bool) {
+	var (
+		found    bool
+		bestKey  mergePartitionKey
+		bestTime time.Time
+	)
+
+	for key, partition := range r.partitions {
+		// messages of stopped partition sessions can't be committed, the server resend them to new session
+		for len(partition.messages) > 0 && partition.messages[0].Context().Err() != nil {
+			partition.messages[0] = nil
+			partition.messages = partition.messages[1:]
+			r.buffered--
+		}
+		if len(partition.messages) == 0 {
+			if isClosed(partition.sessionDone) {
+				delete(r.partitions, key)
+			}
+
+			continue
+		}
+
+		messageTime := r.cfg.messageTime(partition.messages[0])
+		if !found || messageTime.Before(bestTime) || (messageTime.Equal(bestTime) && key.less(bestKey)) {
+			found = true
+			bestKey = key
+			bestTime = messageTime
+		}
+	}
+
+	if !found {
+		return nil, 0, false
+	}
+
+	if force := r.err != nil || r.buffered >= r.cfg.maxBufferedMessages; !force {
+		if wait = r.holdNeedLock(bestTime); wait > 0 {
+			return nil, wait, false
+		}
+	}
+
+	partition := r.partitions[bestKey]
+	msg = partition.messages[0]
+	partition.messages[0] = nil
+	partition.messages = partition.messages[1:]
+	r.buffered--
+
+	return msg, 0, true
+}
+
+// holdNeedLock return time for wait until the message with the time can be released: while the start delay
+// or some active partition may read a message with less time
+func (r *MergedByWriteTimeReader) holdNeedLock(messageTime time.Time) (wait time.Duration) {
+	now := r.cfg.clock.Now()
+	wait = r.cfg.watermarkDelay - now.Sub(r.startedAt)
+
+	for _, partition := range r.partitions {
+		if !partition.watermark.Before(messageTime) {
+			continue
+		}
+		if idleWait := r.cfg.watermarkDelay - now.Sub(partition.lastReadAt); idleWait > wait {
+			wait = idleWait
+		}
+	}
+
+	return wait
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *MergedByWriteTimeReader) notifyNeedLock() {
+	close(r.changed)
+	r.changed = make(empty.Chan)
+}
@@ -0,0 +1,276 @@
+package topicsugar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/topic/topicreadercommon"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicreader"
+)
+
+func TestMergeByWriteTime(t *testing.T) {
+	baseTime := time.UnixMilli(1000)
+
+	t.Run("OrderByWriteTime", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		clock := clockwork.NewFakeClockAt(baseTime.Add(time.Minute))
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source, withMergeByWriteTimeClock(clock))
+		defer func() {
+			_ = r.Close(ctx)
+		}()
+
+		source.messages <- testMergeMessage("orders", 1, 0, baseTime.Add(3*time.Second))
+		source.messages <- testMergeMessage("orders", 1, 1, baseTime.Add(5*time.Second))
+		source.messages <- testMergeMessage("payments", 1, 0, baseTime.Add(1*time.Second))
+		source.messages <- testMergeMessage("payments", 1, 1, baseTime.Add(4*time.Second))
+		xtest.SpinWaitCondition(t, &r.m, func() bool {
+			return r.buffered == 4
+		})
+		clock.Advance(time.Second)
+
+		var order []string
+		for i := 0; i < 4; i++ {
+			msg, err := r.ReadMessage(ctx)
+			require.NoError(t, err)
+			order = append(order, msg.Topic()+"-"+msg.WrittenAt.Sub(baseTime).String())
+		}
+		require.Equal(t, []string{"payments-1s", "orders-3s", "payments-4s", "orders-5s"}, order)
+	})
+	t.Run("UseCreatedAt", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		clock := clockwork.NewFakeClockAt(baseTime.Add(time.Minute))
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source, withMergeByWriteTimeClock(clock), WithMergeByWriteTimeUseCreatedAt())
+		defer func() {
+			_ = r.Close(ctx)
+		}()
+
+		first := testMergeMessage("orders", 1, 0, baseTime)
+		first.CreatedAt = baseTime.Add(time.Second)
+		second := testMergeMessage("payments", 1, 0, baseTime.Add(time.Second))
+		second.CreatedAt = baseTime
+		source.messages <- first
+		source.messages <- second
+		xtest.SpinWaitCondition(t, &r.m, func() bool {
+			return r.buffered == 2
+		})
+		clock.Advance(time.Second)
+
+		msg, err := r.ReadMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, second, msg)
+	})
+	t.Run("StartDelay", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		clock := clockwork.NewFakeClockAt(baseTime)
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source, withMergeByWriteTimeClock(clock), WithMergeByWriteTimeWatermarkDelay(time.Second))
+		defer func() {
+			_ = r.Close(ctx)
+		}()
+
+		late := testMergeMessage("orders", 1, 0, baseTime)
+		source.messages <- late
+
+		received := make(chan *topicreader.Message, 1)
+		go func() {
+			msg, _ := r.ReadMessage(ctx)
+			received <- msg
+		}()
+
+		clock.BlockUntil(1)
+		early := testMergeMessage("payments", 1, 0, baseTime.Add(-time.Second))
+		source.messages <- early
+		xtest.SpinWaitCondition(t, &r.m, func() bool {
+			return r.buffered == 2
+		})
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		require.Equal(t, early, <-received)
+	})
+	t.Run("WaitPartitionWatermark", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		// read of the old messages: write time of messages is far before the clock
+		clock := clockwork.NewFakeClockAt(baseTime.Add(time.Hour))
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source, withMergeByWriteTimeClock(clock), WithMergeByWriteTimeWatermarkDelay(time.Second))
+		defer func() {
+			_ = r.Close(ctx)
+		}()
+		clock.Advance(time.Second)
+
+		source.messages <- testMergeMessage("orders", 1, 0, baseTime.Add(3*time.Second))
+		source.messages <- testMergeMessage("orders", 1, 1, baseTime.Add(5*time.Second))
+		source.messages <- testMergeMessage("payments", 1, 0, baseTime.Add(1*time.Second))
+		xtest.SpinWaitCondition(t, &r.m, func() bool {
+			return r.buffered == 3
+		})
+
+		received := make(chan *topicreader.Message, 1)
+		readInBackground := func() {
+			go func() {
+				msg, _ := r.ReadMessage(ctx)
+				received <- msg
+			}()
+		}
+		writeTime := func(msg *topicreader.Message) string {
+			return msg.Topic() + "-" + msg.WrittenAt.Sub(baseTime).String()
+		}
+
+		readInBackground()
+		require.Equal(t, "payments-1s", writeTime(<-received))
+
+		// payments may read a message before 3s yet
+		readInBackground()
+		clock.BlockUntil(1)
+		source.messages <- testMergeMessage("payments", 1, 1, baseTime.Add(4*time.Second))
+		require.Equal(t, "orders-3s", writeTime(<-received))
+
+		readInBackground()
+		require.Equal(t, "payments-4s", writeTime(<-received))
+
+		// payments without buffered messages holds orders until it is idle
+		readInBackground()
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		require.Equal(t, "orders-5s", writeTime(<-received))
+	})
+	t.Run("FullBuffer", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		clock := clockwork.NewFakeClockAt(baseTime)
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source, withMergeByWriteTimeClock(clock), WithMergeByWriteTimeMaxBufferedMessages(2))
+		defer func() {
+			_ = r.Close(ctx)
+		}()
+
+		second := testMergeMessage("orders", 1, 0, baseTime.Add(time.Second))
+		first := testMergeMessage("payments", 1, 0, baseTime)
+		source.messages <- second
+		source.messages <- first
+		xtest.SpinWaitCondition(t, &r.m, func() bool {
+			return r.buffered == 2
+		})
+
+		msg, err := r.ReadMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, first, msg)
+	})
+	t.Run("SkipStoppedPartitionSession", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		clock := clockwork.NewFakeClockAt(baseTime.Add(time.Minute))
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source, withMergeByWriteTimeClock(clock))
+		defer func() {
+			_ = r.Close(ctx)
+		}()
+
+		sessionCtx, sessionCancel := context.WithCancel(ctx)
+		stopped := topicreadercommon.NewPublicMessageBuilder().
+			Context(sessionCtx).
+			Topic("orders").
+			WrittenAt(baseTime).
+			Build()
+		actual := testMergeMessage("payments", 1, 0, baseTime.Add(time.Second))
+		source.messages <- stopped
+		source.messages <- actual
+		xtest.SpinWaitCondition(t, &r.m, func() bool {
+			return r.buffered == 2
+		})
+		sessionCancel()
+		clock.Advance(time.Second)
+
+		msg, err := r.ReadMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, actual, msg)
+	})
+	t.Run("SourceError", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		clock := clockwork.NewFakeClockAt(baseTime)
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source, withMergeByWriteTimeClock(clock))
+		defer func() {
+			_ = r.Close(ctx)
+		}()
+
+		buffered := testMergeMessage("orders", 1, 0, baseTime)
+		source.messages <- buffered
+		testErr := errors.New("test")
+		source.err <- testErr
+
+		// buffered messages released without wait watermark after the source failed
+		msg, err := r.ReadMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, buffered, msg)
+
+		_, err = r.ReadMessage(ctx)
+		require.ErrorIs(t, err, testErr)
+	})
+	t.Run("Commit", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source)
+		defer func() {
+			_ = r.Close(ctx)
+		}()
+
+		msg := testMergeMessage("orders", 1, 0, baseTime)
+		require.NoError(t, r.Commit(ctx, msg))
+		require.Equal(t, []topicreader.CommitRangeGetter{msg}, source.committed)
+	})
+	t.Run("Close", func(t *testing.T) {
+		ctx := xtest.Context(t)
+		source := newTestMergeSource()
+		r := MergeByWriteTime(source)
+		require.NoError(t, r.Close(ctx))
+
+		_, err := r.ReadMessage(ctx)
+		require.ErrorIs(t, err, errMergedReaderClosed)
+	})
+}
+
+func testMergeMessage(topic string, partitionID, offset int64, writtenAt time.Time) *topicreader.Message {
+	return topicreadercommon.NewPublicMessageBuilder().
+		Topic(topic).
+		PartitionID(partitionID).
+		Offset(offset).
+		WrittenAt(writtenAt).
+		Build()
+}
+
+type testMergeSource struct {
+	messages  chan *topicreader.Message
+	err       chan error
+	committed []topicreader.CommitRangeGetter
+}
+
+func newTestMergeSource() *testMergeSource {
+	return &testMergeSource{
+		messages: make(chan *topicreader.Message),
+		err:      make(chan error),
+	}
+}
+
+func (s *testMergeSource) ReadMessage(ctx context.Context) (*topicreader.Message, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case msg := <-s.messages:
+		return msg, nil
+	case err := <-s.err:
+		return nil, err
+	}
+}
+
+func (s *testMergeSource) Commit(ctx context.Context, obj topicreader.CommitRangeGetter) error {
+	s.committed = append(s.committed, obj)
+
+	return nil
+}
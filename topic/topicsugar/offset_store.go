@@ -0,0 +1,187 @@
+package topicsugar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/params"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/query"
+	"github.com/ydb-platform/ydb-go-sdk/v3/topic/topicoptions"
+)
+
+var (
+	_ topicoptions.OffsetStore = (*MemoryOffsetStore)(nil)
+	_ topicoptions.OffsetStore = (*TableOffsetStore)(nil)
+)
+
+type offsetStoreKey struct {
+	topic       string
+	partitionID int64
+}
+
+// MemoryOffsetStore keep read progress in memory. It is useful for tests.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type MemoryOffsetStore struct {
+	m       sync.Mutex
+	offsets map[offsetStoreKey]int64
+}
+
+// NewMemoryOffsetStore create empty in-memory offset store
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewMemoryOffsetStore() *MemoryOffsetStore {
+	return &MemoryOffsetStore{
+		offsets: make(map[offsetStoreKey]int64),
+	}
+}
+
+// LoadOffset implements topicoptions.OffsetStore
+func (s *MemoryOffsetStore) LoadOffset(
+	ctx context.Context,
+	topic string,
+	partitionID int64,
+) (offset int64, ok bool, err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	offset, ok = s.offsets[offsetStoreKey{topic: topic, partitionID: partitionID}]
+
+	return offset, ok, nil
+}
+
+// StoreOffset implements topicoptions.OffsetStore
+func (s *MemoryOffsetStore) StoreOffset(ctx context.Context, topic string, partitionID, offset int64) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.offsets[offsetStoreKey{topic: topic, partitionID: partitionID}] = offset
+
+	return nil
+}
+
+// TableOffsetStore keep read progress in ydb table.
+// The table has columns: consumer Text, topic Text, partition_id Int64, next_offset Int64
+// with primary key (consumer, topic, partition_id). Use CreateTable for create it.
+//
+// For exactly once processing save result of the processing and offset within one transaction
+// with StoreOffsetTx. Commit of the reader after the transaction rewrite the same offset.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+type TableOffsetStore struct {
+	db        query.Executor
+	tablePath string
+	consumer  string
+}
+
+// NewTableOffsetStore create offset store over ydb table.
+// consumer is name of logical consumer, it allow to share one table for many readers.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func NewTableOffsetStore(db query.Executor, tablePath, consumer string) *TableOffsetStore {
+	return &TableOffsetStore{
+		db:        db,
+		tablePath: tablePath,
+		consumer:  consumer,
+	}
+}
+
+// CreateTable create the table for store offsets if it not exists
+func (s *TableOffsetStore) CreateTable(ctx context.Context) error {
+	err := s.db.Exec(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			consumer Text NOT NULL,
+			topic Text NOT NULL,
+			partition_id Int64 NOT NULL,
+			next_offset Int64 NOT NULL,
+			PRIMARY KEY (consumer, topic, partition_id)
+		)`, "`"+s.tablePath+"`"),
+		query.WithIdempotent(),
+	)
+	if err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to create offsets table: %w", err))
+	}
+
+	return nil
+}
+
+// LoadOffset implements topicoptions.OffsetStore
+func (s *TableOffsetStore) LoadOffset(
+	ctx context.Context,
+	topic string,
+	partitionID int64,
+) (offset int64, ok bool, err error) {
+	row, err := s.db.QueryRow(ctx, fmt.Sprintf(`
+		DECLARE $consumer AS Text;
+		DECLARE $topic AS Text;
+		DECLARE $partitionID AS Int64;
+
+		SELECT next_offset FROM %s
+		WHERE consumer = $consumer AND topic = $topic AND partition_id = $partitionID`, "`"+s.tablePath+"`"),
+		query.WithParameters(s.keyParams(topic, partitionID).Build()),
+		query.WithIdempotent(),
+	)
+	if err != nil {
+		if xerrors.Is(err, io.EOF) {
+			return 0, false, nil
+		}
+
+		return 0, false, xerrors.WithStackTrace(fmt.Errorf("ydb: failed to load offset from table: %w", err))
+	}
+
+	if err = row.Scan(&offset); err != nil {
+		return 0, false, xerrors.WithStackTrace(fmt.Errorf("ydb: failed to scan offset from table: %w", err))
+	}
+
+	return offset, true, nil
+}
+
+// StoreOffset implements topicoptions.OffsetStore
+func (s *TableOffsetStore) StoreOffset(ctx context.Context, topic string, partitionID, offset int64) error {
+	return s.storeOffset(ctx, s.db, topic, partitionID, offset, query.WithIdempotent())
+}
+
+// StoreOffsetTx save offset within the user transaction
+func (s *TableOffsetStore) StoreOffsetTx(
+	ctx context.Context,
+	tx query.TxActor,
+	topic string,
+	partitionID, offset int64,
+) error {
+	return s.storeOffset(ctx, tx, topic, partitionID, offset)
+}
+
+func (s *TableOffsetStore) storeOffset(
+	ctx context.Context,
+	executor query.Executor,
+	topic string,
+	partitionID, offset int64,
+	opts ...query.ExecuteOption,
+) error {
+	opts = append(opts, query.WithParameters(s.keyParams(topic, partitionID).Param("$offset").Int64(offset).Build()))
+	err := executor.Exec(ctx, fmt.Sprintf(`
+		DECLARE $consumer AS Text;
+		DECLARE $topic AS Text;
+		DECLARE $partitionID AS Int64;
+		DECLARE $offset AS Int64;
+
+		UPSERT INTO %s (consumer, topic, partition_id, next_offset)
+		VALUES ($consumer, $topic, $partitionID, $offset)`, "`"+s.tablePath+"`"),
+		opts...,
+	)
+	if err != nil {
+		return xerrors.WithStackTrace(fmt.Errorf("ydb: failed to store offset to table: %w", err))
+	}
+
+	return nil
+}
+
+func (s *TableOffsetStore) keyParams(topic string, partitionID int64) params.Builder {
+	return params.Builder{}.
+		Param("$consumer").Text(s.consumer).
+		Param("$topic").Text(topic).
+		Param("$partitionID").Int64(partitionID)
+}
@@ -0,0 +1,36 @@
+package topicsugar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+)
+
+func TestMemoryOffsetStore(t *testing.T) {
+	ctx := xtest.Context(t)
+	store := NewMemoryOffsetStore()
+
+	_, ok, err := store.LoadOffset(ctx, "topic", 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, store.StoreOffset(ctx, "topic", 1, 10))
+	require.NoError(t, store.StoreOffset(ctx, "topic", 2, 20))
+	require.NoError(t, store.StoreOffset(ctx, "topic", 1, 15))
+
+	offset, ok, err := store.LoadOffset(ctx, "topic", 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(15), offset)
+
+	offset, ok, err = store.LoadOffset(ctx, "topic", 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(20), offset)
+
+	_, ok, err = store.LoadOffset(ctx, "other", 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -20,7 +20,7 @@ const (
 	// CodecLzop not supported by default, customer need provide own codec library
 	CodecLzop = Codec(rawtopiccommon.CodecLzop)
 
-	// CodecZstd not supported by default, customer need provide own codec library
+	// CodecZstd supported by default, use topicoptions.ZstdEncoder for change compression level
 	CodecZstd = Codec(rawtopiccommon.CodecZstd)
 
 	CodecCustomerFirst = Codec(rawtopiccommon.CodecCustomerFirst)
@@ -95,20 +95,95 @@ func (m *MeteringMode) ToRaw(raw *rawtopic.MeteringMode) {
 
 // PartitionSettings settings of partitions
 type PartitionSettings struct {
-	MinActivePartitions int64
-	PartitionCountLimit int64
+	MinActivePartitions      int64
+	MaxActivePartitions      int64
+	PartitionCountLimit      int64
+	AutoPartitioningSettings AutoPartitioningSettings
 }
 
 // ToRaw convert public format to internal. Used internally only.
 func (s *PartitionSettings) ToRaw(raw *rawtopic.PartitioningSettings) {
 	raw.MinActivePartitions = s.MinActivePartitions
+	raw.MaxActivePartitions = s.MaxActivePartitions
 	raw.PartitionCountLimit = s.PartitionCountLimit
+	s.AutoPartitioningSettings.ToRaw(&raw.AutoPartitioningSettings)
 }
 
 // FromRaw convert internal format to public. Used internally only.
 func (s *PartitionSettings) FromRaw(raw *rawtopic.PartitioningSettings) {
 	s.MinActivePartitions = raw.MinActivePartitions
+	s.MaxActivePartitions = raw.MaxActivePartitions
 	s.PartitionCountLimit = raw.PartitionCountLimit
+	s.AutoPartitioningSettings.FromRaw(&raw.AutoPartitioningSettings)
+}
+
+// AutoPartitioningStrategy is strategy of auto split and merge partitions of the topic
+type AutoPartitioningStrategy int32
+
+const (
+	AutoPartitioningStrategyUnspecified = AutoPartitioningStrategy(rawtopic.AutoPartitioningStrategyUnspecified)
+
+	// AutoPartitioningStrategyDisabled partitions count changed by user only
+	AutoPartitioningStrategyDisabled = AutoPartitioningStrategy(rawtopic.AutoPartitioningStrategyDisabled)
+
+	// AutoPartitioningStrategyScaleUp partitions splitted when write speed is high
+	AutoPartitioningStrategyScaleUp = AutoPartitioningStrategy(rawtopic.AutoPartitioningStrategyScaleUp)
+
+	// AutoPartitioningStrategyScaleUpAndDown partitions splitted when write speed is high
+	// and merged when write speed is low
+	AutoPartitioningStrategyScaleUpAndDown = AutoPartitioningStrategy(rawtopic.AutoPartitioningStrategyScaleUpAndDown)
+
+	// AutoPartitioningStrategyPaused auto partitioning stopped temporary
+	AutoPartitioningStrategyPaused = AutoPartitioningStrategy(rawtopic.AutoPartitioningStrategyPaused)
+)
+
+// AutoPartitioningSettings settings of auto split and merge partitions
+type AutoPartitioningSettings struct {
+	Strategy            AutoPartitioningStrategy
+	PartitionWriteSpeed AutoPartitioningWriteSpeedStrategy
+}
+
+// ToRaw convert public format to internal. Used internally only.
+func (s *AutoPartitioningSettings) ToRaw(raw *rawtopic.AutoPartitioningSettings) {
+	raw.Strategy = rawtopic.AutoPartitioningStrategy(s.Strategy)
+	s.PartitionWriteSpeed.ToRaw(&raw.PartitionWriteSpeed)
+}
+
+// FromRaw convert internal format to public. Used internally only.
+func (s *AutoPartitioningSettings) FromRaw(raw *rawtopic.AutoPartitioningSettings) {
+	s.Strategy = AutoPartitioningStrategy(raw.Strategy)
+	s.PartitionWriteSpeed.FromRaw(&raw.PartitionWriteSpeed)
+}
+
+// AutoPartitioningWriteSpeedStrategy thresholds of write speed for split and merge partitions.
+// Utilization percents counts from partition write speed limit.
+// Zero values mean server defaults.
+type AutoPartitioningWriteSpeedStrategy struct {
+	// StabilizationWindow is time of exceed the threshold before split or merge partition
+	StabilizationWindow time.Duration
+
+	// UpUtilizationPercent is threshold of write speed for split partition
+	UpUtilizationPercent int32
+
+	// DownUtilizationPercent is threshold of write speed for merge partitions
+	DownUtilizationPercent int32
+}
+
+// ToRaw convert public format to internal. Used internally only.
+func (s *AutoPartitioningWriteSpeedStrategy) ToRaw(raw *rawtopic.AutoPartitioningWriteSpeedStrategy) {
+	if s.StabilizationWindow != 0 {
+		raw.StabilizationWindow.HasValue = true
+		raw.StabilizationWindow.Value = s.StabilizationWindow
+	}
+	raw.UpUtilizationPercent = s.UpUtilizationPercent
+	raw.DownUtilizationPercent = s.DownUtilizationPercent
+}
+
+// FromRaw convert internal format to public. Used internally only.
+func (s *AutoPartitioningWriteSpeedStrategy) FromRaw(raw *rawtopic.AutoPartitioningWriteSpeedStrategy) {
+	s.StabilizationWindow = raw.StabilizationWindow.Value
+	s.UpUtilizationPercent = raw.UpUtilizationPercent
+	s.DownUtilizationPercent = raw.DownUtilizationPercent
 }
 
 // TopicDescription contains info about topic.
@@ -52,7 +52,16 @@ func TestTopicDescriptionFromRaw(t *testing.T) {
 				Path: "some/path",
 				PartitionSettings: PartitionSettings{
 					MinActivePartitions: 4,
+					MaxActivePartitions: 8,
 					PartitionCountLimit: 4,
+					AutoPartitioningSettings: AutoPartitioningSettings{
+						Strategy: AutoPartitioningStrategyScaleUp,
+						PartitionWriteSpeed: AutoPartitioningWriteSpeedStrategy{
+							StabilizationWindow:    time.Minute,
+							UpUtilizationPercent:   80,
+							DownUtilizationPercent: 20,
+						},
+					},
 				},
 				Partitions: []PartitionInfo{
 					{
@@ -92,7 +101,16 @@ func TestTopicDescriptionFromRaw(t *testing.T) {
 				},
 				PartitioningSettings: rawtopic.PartitioningSettings{
 					MinActivePartitions: 4,
+					MaxActivePartitions: 8,
 					PartitionCountLimit: 4,
+					AutoPartitioningSettings: rawtopic.AutoPartitioningSettings{
+						Strategy: rawtopic.AutoPartitioningStrategyScaleUp,
+						PartitionWriteSpeed: rawtopic.AutoPartitioningWriteSpeedStrategy{
+							StabilizationWindow:    rawoptional.Duration{Value: time.Minute, HasValue: true},
+							UpUtilizationPercent:   80,
+							DownUtilizationPercent: 20,
+						},
+					},
 				},
 				Partitions: []rawtopic.PartitionInfo{
 					{
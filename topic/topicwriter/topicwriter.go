@@ -8,6 +8,27 @@ import (
 
 type (
 	Message = topicwriterinternal.PublicMessage
+
+	// WriteFuture is result of WriteAsync for one message, resolved by ack from server
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	WriteFuture = topicwriterinternal.PublicWriteFuture
+
+	// WriteResult is server ack for one message
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	WriteResult = topicwriterinternal.PublicWriteResult
+
+	// WriteStatus is status of message write
+	//
+	// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+	WriteStatus = topicwriterinternal.PublicWriteStatus
+)
+
+const (
+	WriteStatusWritten     = topicwriterinternal.PublicWriteStatusWritten
+	WriteStatusSkipped     = topicwriterinternal.PublicWriteStatusSkipped
+	WriteStatusWrittenInTx = topicwriterinternal.PublicWriteStatusWrittenInTx
 )
 
 var (
@@ -48,6 +69,17 @@ func (w *Writer) Write(ctx context.Context, messages ...Message) error {
 	return w.inner.Write(ctx, messages)
 }
 
+// WriteAsync put messages to internal buffer and return future for every message.
+// Future resolved after ack from server with offset, partition and status of the message.
+// It doesn't wait ack from server even if topicoptions.WithWriterWaitServerAck enabled.
+//
+// Errors of WriteAsync same as Write.
+//
+// Experimental: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#experimental
+func (w *Writer) WriteAsync(ctx context.Context, messages ...Message) ([]*WriteFuture, error) {
+	return w.inner.WriteAsync(ctx, messages)
+}
+
 // WaitInit waits until the reader is initialized
 // or an error occurs, return PublicInitialInfo and err
 func (w *Writer) WaitInit(ctx context.Context) (err error) {
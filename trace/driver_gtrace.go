@@ -860,6 +860,79 @@ func (t *Driver) Compose(x *Driver, opts ...DriverComposeOption) *Driver {
 			}
 		}
 	}
+	{
+		h1 := t.OnBalancerEject
+		h2 := x.OnBalancerEject
+		ret.OnBalancerEject = func(d DriverBalancerEjectInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(d)
+			}
+			if h2 != nil {
+				h2(d)
+			}
+		}
+	}
+	{
+		h1 := t.OnBalancerProbe
+		h2 := x.OnBalancerProbe
+		ret.OnBalancerProbe = func(d DriverBalancerProbeStartInfo) func(DriverBalancerProbeDoneInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			var r, r1 func(DriverBalancerProbeDoneInfo)
+			if h1 != nil {
+				r = h1(d)
+			}
+			if h2 != nil {
+				r1 = h2(d)
+			}
+			return func(d DriverBalancerProbeDoneInfo) {
+				if options.panicCallback != nil {
+					defer func() {
+						if e := recover(); e != nil {
+							options.panicCallback(e)
+						}
+					}()
+				}
+				if r != nil {
+					r(d)
+				}
+				if r1 != nil {
+					r1(d)
+				}
+			}
+		}
+	}
+	{
+		h1 := t.OnBalancerRestore
+		h2 := x.OnBalancerRestore
+		ret.OnBalancerRestore = func(d DriverBalancerRestoreInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(d)
+			}
+			if h2 != nil {
+				h2(d)
+			}
+		}
+	}
 	{
 		h1 := t.OnGetCredentials
 		h2 := x.OnGetCredentials
@@ -1249,6 +1322,35 @@ func (t *Driver) onBalancerUpdate(d DriverBalancerUpdateStartInfo) func(DriverBa
 	}
 	return res
 }
+func (t *Driver) onBalancerEject(d DriverBalancerEjectInfo) {
+	fn := t.OnBalancerEject
+	if fn == nil {
+		return
+	}
+	fn(d)
+}
+func (t *Driver) onBalancerProbe(d DriverBalancerProbeStartInfo) func(DriverBalancerProbeDoneInfo) {
+	fn := t.OnBalancerProbe
+	if fn == nil {
+		return func(DriverBalancerProbeDoneInfo) {
+			return
+		}
+	}
+	res := fn(d)
+	if res == nil {
+		return func(DriverBalancerProbeDoneInfo) {
+			return
+		}
+	}
+	return res
+}
+func (t *Driver) onBalancerRestore(d DriverBalancerRestoreInfo) {
+	fn := t.OnBalancerRestore
+	if fn == nil {
+		return
+	}
+	fn(d)
+}
 func (t *Driver) onGetCredentials(d DriverGetCredentialsStartInfo) func(DriverGetCredentialsDoneInfo) {
 	fn := t.OnGetCredentials
 	if fn == nil {
@@ -1585,6 +1687,38 @@ func DriverOnBalancerUpdate(t *Driver, c *context.Context, call call, needLocalD
 	}
 }
 // Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+func DriverOnBalancerEject(t *Driver, c context.Context, call call, endpoint EndpointInfo, cause error, ejected int) {
+	var p DriverBalancerEjectInfo
+	p.Context = c
+	p.Call = call
+	p.Endpoint = endpoint
+	p.Cause = cause
+	p.Ejected = ejected
+	t.onBalancerEject(p)
+}
+// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+func DriverOnBalancerProbe(t *Driver, c *context.Context, call call, endpoint EndpointInfo) func(error) {
+	var p DriverBalancerProbeStartInfo
+	p.Context = c
+	p.Call = call
+	p.Endpoint = endpoint
+	res := t.onBalancerProbe(p)
+	return func(e error) {
+		var p DriverBalancerProbeDoneInfo
+		p.Error = e
+		res(p)
+	}
+}
+// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+func DriverOnBalancerRestore(t *Driver, c context.Context, call call, endpoint EndpointInfo, ejected int) {
+	var p DriverBalancerRestoreInfo
+	p.Context = c
+	p.Call = call
+	p.Endpoint = endpoint
+	p.Ejected = ejected
+	t.onBalancerRestore(p)
+}
+// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
 func DriverOnGetCredentials(t *Driver, c *context.Context, call call) func(token string, _ error) {
 	var p DriverGetCredentialsStartInfo
 	p.Context = c
@@ -4,6 +4,7 @@ package trace
 
 import (
 	"context"
+	"time"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
 )
@@ -547,6 +548,41 @@ func (t *Query) Compose(x *Query, opts ...QueryComposeOption) *Query {
 			}
 		}
 	}
+	{
+		h1 := t.OnHedge
+		h2 := x.OnHedge
+		ret.OnHedge = func(q QueryHedgeStartInfo) func(QueryHedgeDoneInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			var r, r1 func(QueryHedgeDoneInfo)
+			if h1 != nil {
+				r = h1(q)
+			}
+			if h2 != nil {
+				r1 = h2(q)
+			}
+			return func(q QueryHedgeDoneInfo) {
+				if options.panicCallback != nil {
+					defer func() {
+						if e := recover(); e != nil {
+							options.panicCallback(e)
+						}
+					}()
+				}
+				if r != nil {
+					r(q)
+				}
+				if r1 != nil {
+					r1(q)
+				}
+			}
+		}
+	}
 	{
 		h1 := t.OnSessionCreate
 		h2 := x.OnSessionCreate
@@ -1326,6 +1362,21 @@ func (t *Query) onQueryRow(q QueryQueryRowStartInfo) func(QueryQueryRowDoneInfo)
 	}
 	return res
 }
+func (t *Query) onHedge(q QueryHedgeStartInfo) func(QueryHedgeDoneInfo) {
+	fn := t.OnHedge
+	if fn == nil {
+		return func(QueryHedgeDoneInfo) {
+			return
+		}
+	}
+	res := fn(q)
+	if res == nil {
+		return func(QueryHedgeDoneInfo) {
+			return
+		}
+	}
+	return res
+}
 func (t *Query) onSessionCreate(q QuerySessionCreateStartInfo) func(info QuerySessionCreateDoneInfo) {
 	fn := t.OnSessionCreate
 	if fn == nil {
@@ -1754,6 +1805,21 @@ func QueryOnQueryRow(t *Query, c *context.Context, call call, query string) func
 	}
 }
 // Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+func QueryOnHedge(t *Query, c *context.Context, call call, attempt int, delay time.Duration) func(won bool, _ error) {
+	var p QueryHedgeStartInfo
+	p.Context = c
+	p.Call = call
+	p.Attempt = attempt
+	p.Delay = delay
+	res := t.onHedge(p)
+	return func(won bool, e error) {
+		var p QueryHedgeDoneInfo
+		p.Won = won
+		p.Error = e
+		res(p)
+	}
+}
+// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
 func QueryOnSessionCreate(t *Query, c *context.Context, call call) func(session sessionInfo, _ error) {
 	var p QuerySessionCreateStartInfo
 	p.Context = c
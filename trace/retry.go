@@ -15,6 +15,12 @@ type (
 	Retry struct {
 		// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
 		OnRetry func(RetryLoopStartInfo) func(RetryLoopDoneInfo)
+
+		// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+		OnCircuitBreakerStateChange func(RetryCircuitBreakerStateChangeInfo)
+
+		// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+		OnBudgetUpdate func(RetryBudgetUpdateInfo)
 	}
 	// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
 	RetryLoopStartInfo struct {
@@ -35,4 +41,28 @@ type (
 		Attempts int
 		Error    error
 	}
+	// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+	RetryCircuitBreakerStateChangeInfo struct {
+		// Context make available context in trace callback function.
+		// Pointer to context provide replacement of context in trace callback function.
+		// Warning: concurrent access to pointer on client side must be excluded.
+		// Safe replacement of context are provided only inside callback function
+		Context *context.Context
+
+		Key           string
+		PreviousState string
+		State         string
+	}
+	// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+	RetryBudgetUpdateInfo struct {
+		// Context make available context in trace callback function.
+		// Pointer to context provide replacement of context in trace callback function.
+		// Warning: concurrent access to pointer on client side must be excluded.
+		// Safe replacement of context are provided only inside callback function
+		Context *context.Context
+
+		Tokens    float64
+		MaxTokens float64
+		Throttled bool
+	}
 )
@@ -71,6 +71,44 @@ func (t *Retry) Compose(x *Retry, opts ...RetryComposeOption) *Retry {
 			}
 		}
 	}
+	{
+		h1 := t.OnCircuitBreakerStateChange
+		h2 := x.OnCircuitBreakerStateChange
+		ret.OnCircuitBreakerStateChange = func(r RetryCircuitBreakerStateChangeInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(r)
+			}
+			if h2 != nil {
+				h2(r)
+			}
+		}
+	}
+	{
+		h1 := t.OnBudgetUpdate
+		h2 := x.OnBudgetUpdate
+		ret.OnBudgetUpdate = func(r RetryBudgetUpdateInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(r)
+			}
+			if h2 != nil {
+				h2(r)
+			}
+		}
+	}
 	return &ret
 }
 func (t *Retry) onRetry(r RetryLoopStartInfo) func(RetryLoopDoneInfo) {
@@ -88,6 +126,20 @@ func (t *Retry) onRetry(r RetryLoopStartInfo) func(RetryLoopDoneInfo) {
 	}
 	return res
 }
+func (t *Retry) onCircuitBreakerStateChange(r RetryCircuitBreakerStateChangeInfo) {
+	fn := t.OnCircuitBreakerStateChange
+	if fn == nil {
+		return
+	}
+	fn(r)
+}
+func (t *Retry) onBudgetUpdate(r RetryBudgetUpdateInfo) {
+	fn := t.OnBudgetUpdate
+	if fn == nil {
+		return
+	}
+	fn(r)
+}
 // Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
 func RetryOnRetry(t *Retry, c *context.Context, call call, label string, idempotent bool, nestedCall bool) func(attempts int, _ error) {
 	var p RetryLoopStartInfo
@@ -104,3 +156,21 @@ func RetryOnRetry(t *Retry, c *context.Context, call call, label string, idempot
 		res(p)
 	}
 }
+// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+func RetryOnCircuitBreakerStateChange(t *Retry, c *context.Context, key string, previousState string, state string) {
+	var p RetryCircuitBreakerStateChangeInfo
+	p.Context = c
+	p.Key = key
+	p.PreviousState = previousState
+	p.State = state
+	t.onCircuitBreakerStateChange(p)
+}
+// Internals: https://github.com/ydb-platform/ydb-go-sdk/blob/master/VERSIONING.md#internals
+func RetryOnBudgetUpdate(t *Retry, c *context.Context, tokens float64, maxTokens float64, throttled bool) {
+	var p RetryBudgetUpdateInfo
+	p.Context = c
+	p.Tokens = tokens
+	p.MaxTokens = maxTokens
+	p.Throttled = throttled
+	t.onBudgetUpdate(p)
+}